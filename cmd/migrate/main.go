@@ -8,6 +8,8 @@ import (
 
 	"FLOWGO/internal/domain/entity"
 	"FLOWGO/internal/infrastructure/config"
+	"FLOWGO/internal/infrastructure/dao"
+	"FLOWGO/internal/infrastructure/database"
 )
 
 func main() {
@@ -31,10 +33,36 @@ func main() {
 		&entity.User{},
 		&entity.Project{},
 		&entity.Team{},
+		&dao.ProjectTeamPO{},
+		&dao.ProjectUserPO{},
+		&dao.AccessTokenPO{},
+		&dao.OneTimeTokenPO{},
+		&dao.RecoveryCodePO{},
+		&dao.SecurityEventPO{},
+		&dao.UserIdentityPO{},
+		&dao.TeamMemberPO{},
+		&dao.InvitationPO{},
+		&dao.TaskPO{},
+		&dao.BoardPO{},
+		&dao.BoardColumnPO{},
+		&dao.TagPO{},
+		&dao.ProjectTagPO{},
+		&dao.ProjectStatusChangePO{},
+		&dao.MilestonePO{},
+		&dao.SchemaMigrationPO{},
 	)
 	if err != nil {
 		log.Fatalf("Failed to migrate schema: %v", err)
 	}
+	if err := database.MigrateTeamMembers(sqliteDB); err != nil {
+		log.Fatalf("Failed to migrate team members: %v", err)
+	}
+	if err := database.MigrateUserUniqueIndexes(sqliteDB); err != nil {
+		log.Fatalf("Failed to migrate user indexes: %v", err)
+	}
+	if err := database.MigrateTaskRanks(sqliteDB); err != nil {
+		log.Fatalf("Failed to migrate task ranks: %v", err)
+	}
 
 	log.Println("Migration complete!")
 }
@@ -13,6 +13,7 @@ import (
 	"github.com/gin-gonic/gin"
 
 	"FLOWGO/internal/application/service"
+	"FLOWGO/internal/domain/permission"
 	"FLOWGO/internal/infrastructure/config"
 	"FLOWGO/internal/infrastructure/database"
 	"FLOWGO/internal/infrastructure/redis"
@@ -20,6 +21,9 @@ import (
 	"FLOWGO/internal/interfaces/http/handler"
 	"FLOWGO/internal/interfaces/http/router"
 	"FLOWGO/pkg/jwt"
+	"FLOWGO/pkg/mailer"
+	"FLOWGO/pkg/oidc"
+	"FLOWGO/pkg/utils"
 
 	"github.com/R2Remote/ChronoGo/sdk/worker"
 )
@@ -44,7 +48,13 @@ func main() {
 	defer redis.CloseRedis()
 
 	// 初始化JWT配置
-	if config.AppConfig.JWT.PrivateKeyLocation != "" && config.AppConfig.JWT.PublicKeyLocation != "" {
+	if len(config.AppConfig.JWT.Keys) > 0 {
+		// 使用密钥环（支持多密钥轮换）
+		if err := initJWTKeyring(config.AppConfig.JWT); err != nil {
+			log.Fatalf("Failed to load JWT keyring: %v", err)
+		}
+		log.Printf("JWT initialized with keyring (%d keys, active kid %s)", len(config.AppConfig.JWT.Keys), config.AppConfig.JWT.ActiveKid)
+	} else if config.AppConfig.JWT.PrivateKeyLocation != "" && config.AppConfig.JWT.PublicKeyLocation != "" {
 		// 使用RSA密钥对（RS256）
 		if err := jwt.SetRSAPrivateKey(config.AppConfig.JWT.PrivateKeyLocation); err != nil {
 			log.Fatalf("Failed to load RSA private key: %v", err)
@@ -60,7 +70,12 @@ func main() {
 	} else {
 		log.Fatal("JWT configuration is missing: either provide RSA key pair or secret key")
 	}
-	jwt.SetTokenExpiration(time.Duration(config.AppConfig.JWT.Expiration) * time.Hour)
+	if config.AppConfig.JWT.AccessExpiration > 0 {
+		jwt.SetTokenExpiration(time.Duration(config.AppConfig.JWT.AccessExpiration) * time.Minute)
+	} else {
+		jwt.SetTokenExpiration(time.Duration(config.AppConfig.JWT.Expiration) * time.Hour)
+	}
+	jwt.SetRefreshTokenExpiration(time.Duration(config.AppConfig.JWT.RefreshExpiration) * time.Hour)
 
 	// 设置Gin模式
 	ginMode := config.AppConfig.Server.Mode
@@ -72,20 +87,92 @@ func main() {
 	// 基础设施
 	userRepo := repository.NewUserRepository(database.DB)
 	projectRepo := repository.NewProjectsRepository(database.DB)
+	tokenRepo := repository.NewTokenRepository(redis.Client)
+	accessTokenRepo := repository.NewAccessTokenRepository(database.DB)
+	oneTimeTokenRepo := repository.NewOneTimeTokenRepository(database.DB)
+	recoveryCodeRepo := repository.NewRecoveryCodeRepository(database.DB)
+	loginAttemptRepo := repository.NewLoginAttemptRepository(redis.Client)
+	securityEventRepo := repository.NewSecurityEventRepository(database.DB)
+	userIdentityRepo := repository.NewUserIdentityRepository(database.DB)
+	oidcStateRepo := repository.NewOIDCStateRepository(redis.Client)
+	teamRepo := repository.NewTeamRepository(database.DB)
+	invitationRepo := repository.NewInvitationRepository(database.DB)
+	taskRepo := repository.NewTaskRepository(database.DB)
+	boardRepo := repository.NewBoardRepository(database.DB)
+	tagRepo := repository.NewTagRepository(database.DB)
+	milestoneRepo := repository.NewMilestoneRepository(database.DB)
+	mailCfg := config.AppConfig.Mail
+	mail, err := mailer.New(mailer.Config{
+		Driver:   mailCfg.Driver,
+		Host:     mailCfg.Host,
+		Port:     mailCfg.Port,
+		Username: mailCfg.Username,
+		Password: mailCfg.Password,
+		From:     mailCfg.From,
+		Dir:      mailCfg.Dir,
+	})
+	if err != nil {
+		log.Fatalf("Failed to initialize mailer: %v", err)
+	}
 
 	// 应用服务
-	userService := service.NewUserService(userRepo)
-	authService := service.NewAuthService(userRepo)
-	projectService := service.NewProjectService(projectRepo)
+	secretBox, err := utils.NewSecretBox(config.AppConfig.Security.EncryptionKey)
+	if err != nil {
+		log.Fatalf("Failed to initialize encryption: %v", err)
+	}
+	twoFactorService := service.NewTwoFactorService(userRepo, recoveryCodeRepo, secretBox)
+	loginCfg := config.AppConfig.Security.Login
+	loginGuard := service.NewLoginGuard(loginAttemptRepo, securityEventRepo, service.LoginPolicy{
+		Window:          time.Duration(loginCfg.Window) * time.Minute,
+		BackoffAfter:    loginCfg.BackoffAfter,
+		MaxBackoff:      time.Duration(loginCfg.MaxBackoff) * time.Second,
+		LockoutAfter:    loginCfg.LockoutAfter,
+		LockoutDuration: time.Duration(loginCfg.LockoutDuration) * time.Minute,
+		MaxIPAttempts:   loginCfg.MaxIPAttempts,
+	})
+	authService := service.NewAuthService(userRepo, tokenRepo, accessTokenRepo, twoFactorService, loginGuard)
+	userService := service.NewUserService(userRepo, oneTimeTokenRepo, authService)
+	securityService := service.NewSecurityService(userRepo, securityEventRepo, loginGuard)
+	oidcService, err := service.NewOIDCService(oidcProviders(), userRepo, userIdentityRepo, oidcStateRepo, authService, config.AppConfig.OIDC.LoginRedirectURL)
+	if err != nil {
+		log.Fatalf("Failed to initialize oidc: %v", err)
+	}
+	projectService := service.NewProjectService(projectRepo, tagRepo, milestoneRepo, userRepo)
+	accessTokenService := service.NewAccessTokenService(accessTokenRepo)
+	teamService := service.NewTeamService(teamRepo, userRepo)
+	taskService := service.NewTaskService(taskRepo, projectRepo)
+	boardService := service.NewBoardService(boardRepo, taskRepo, projectRepo)
+	tagService := service.NewTagService(tagRepo)
+	milestoneService := service.NewMilestoneService(milestoneRepo, projectRepo)
+	trashService := service.NewTrashService(projectRepo, userRepo, time.Duration(config.AppConfig.Trash.RetentionDays)*24*time.Hour)
+	accountService := service.NewAccountService(userRepo, oneTimeTokenRepo, authService, mail, config.AppConfig.Server.PublicURL)
+	invitationService := service.NewInvitationService(invitationRepo, userRepo, teamRepo, projectRepo, authService, mail, config.AppConfig.Server.PublicURL)
 
 	// 控制器
 	userHandler := handler.NewUserHandler(userService)
 	authHandler := handler.NewAuthHandler(authService)
 	projectHandler := handler.NewProjectsHandler(projectService)
 	statsHandler := handler.NewStatsHandler()
+	accessTokenHandler := handler.NewAccessTokenHandler(accessTokenService)
+	jwksHandler := handler.NewJWKSHandler()
+	accountHandler := handler.NewAccountHandler(accountService)
+	twoFactorHandler := handler.NewTwoFactorHandler(twoFactorService)
+	securityHandler := handler.NewSecurityHandler(securityService)
+	oidcHandler := handler.NewOIDCHandler(oidcService)
+	teamHandler := handler.NewTeamHandler(teamService)
+	invitationHandler := handler.NewInvitationHandler(invitationService)
+	taskHandler := handler.NewTaskHandler(taskService)
+	boardHandler := handler.NewBoardHandler(boardService)
+	tagHandler := handler.NewTagHandler(tagService)
+	milestoneHandler := handler.NewMilestoneHandler(milestoneService)
+	trashHandler := handler.NewTrashHandler(trashService)
 
 	// 设置路由
-	r := router.SetupRouter(authHandler, userHandler, projectHandler, statsHandler)
+	r := router.SetupRouter(authService, authHandler, userHandler, projectHandler, statsHandler, accessTokenHandler, jwksHandler, accountHandler, twoFactorHandler, securityHandler, oidcHandler, teamHandler, invitationHandler, taskHandler, boardHandler, tagHandler, milestoneHandler, trashHandler)
+	// 只采信可信代理转发的客户端 IP，避免伪造 X-Forwarded-For 绕过登录限流
+	if err := r.SetTrustedProxies(config.AppConfig.Server.TrustedProxies); err != nil {
+		log.Fatalf("Failed to set trusted proxies: %v", err)
+	}
 
 	// 加载定时任务
 	wk := worker.NewWorker()
@@ -93,6 +180,15 @@ func main() {
 		log.Println("some_task", params)
 		return nil
 	})
+	// 永久删除回收站中超过保留期的项目和用户
+	wk.RegisterHandler("purge_trash", func(params string) error {
+		result, err := trashService.PurgeExpired(context.Background())
+		if err != nil {
+			return err
+		}
+		log.Printf("purge_trash: purged %d projects, %d users", result.Projects, result.Users)
+		return nil
+	})
 	go wk.Start(8888)
 
 	// 启动服务器
@@ -129,3 +225,48 @@ func main() {
 
 	log.Println("Server exited")
 }
+
+// initJWTKeyring 根据配置构建JWT密钥环
+func initJWTKeyring(cfg config.JWTConfig) error {
+	keyring := jwt.NewKeyring()
+	for _, kc := range cfg.Keys {
+		key, err := jwt.LoadKey(jwt.KeyConfig{
+			Kid:                kc.Kid,
+			Algorithm:          kc.Algorithm,
+			SecretKey:          kc.SecretKey,
+			PrivateKeyLocation: kc.PrivateKeyLocation,
+			PublicKeyLocation:  kc.PublicKeyLocation,
+		})
+		if err != nil {
+			return err
+		}
+		if err := keyring.Add(key); err != nil {
+			return err
+		}
+	}
+	if err := keyring.SetActive(cfg.ActiveKid); err != nil {
+		return err
+	}
+	return jwt.SetKeyring(keyring)
+}
+
+// oidcProviders 根据配置创建单点登录提供方
+func oidcProviders() []*service.OIDCProvider {
+	providers := make([]*service.OIDCProvider, 0, len(config.AppConfig.OIDC.Providers))
+	for _, cfg := range config.AppConfig.OIDC.Providers {
+		providers = append(providers, &service.OIDCProvider{
+			Provider: oidc.NewProvider(oidc.Config{
+				Name:         cfg.Name,
+				IssuerURL:    cfg.Issuer,
+				ClientID:     cfg.ClientID,
+				ClientSecret: cfg.ClientSecret,
+				RedirectURL:  cfg.RedirectURL,
+				Scopes:       cfg.Scopes,
+			}, nil),
+			DisplayName: cfg.DisplayName,
+			DefaultRole: permission.ParseRole(cfg.DefaultRole),
+			TrustEmail:  cfg.TrustEmail,
+		})
+	}
+	return providers
+}
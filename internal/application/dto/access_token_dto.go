@@ -0,0 +1,33 @@
+package dto
+
+import "FLOWGO/pkg/utils"
+
+// CreateAccessTokenRequest 创建个人访问令牌请求
+type CreateAccessTokenRequest struct {
+	Name          string   `json:"name" binding:"required,max=100"`
+	Scopes        []string `json:"scopes" binding:"required,min=1"`                   // 权限范围，如 project:read
+	ExpiresInDays int      `json:"expires_in_days" binding:"omitempty,min=1,max=365"` // 为空表示永不过期
+}
+
+// AccessTokenResponse 个人访问令牌响应（不含明文）
+type AccessTokenResponse struct {
+	ID         uint64     `json:"id"`
+	Name       string     `json:"name"`
+	Prefix     string     `json:"prefix"`
+	Scopes     []string   `json:"scopes"`
+	ExpiresAt  utils.Time `json:"expires_at"`
+	LastUsedAt utils.Time `json:"last_used_at"`
+	RevokedAt  utils.Time `json:"revoked_at"`
+	CreatedAt  utils.Time `json:"created_at"`
+}
+
+// CreateAccessTokenResponse 创建个人访问令牌响应，明文令牌只在创建时返回一次
+type CreateAccessTokenResponse struct {
+	AccessTokenResponse
+	Token string `json:"token"`
+}
+
+// AccessTokenListResponse 个人访问令牌列表响应
+type AccessTokenListResponse struct {
+	List []*AccessTokenResponse `json:"list"`
+}
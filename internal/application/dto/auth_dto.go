@@ -2,13 +2,83 @@ package dto
 
 // LoginRequest 登录请求
 type LoginRequest struct {
-	Username string `json:"username" binding:"required"`
+	Username string `json:"username" binding:"required"` // 用户名或邮箱
 	Password string `json:"password" binding:"required"`
 }
 
 // LoginResponse 登录响应
+// 用户开启两步验证时只返回 two_factor_required 和 challenge_token，
+// 客户端需要调用 /auth/2fa 换取正式Token
 type LoginResponse struct {
-	Token     string       `json:"token"`
-	User      UserResponse `json:"user"`
-	ExpiresIn int64        `json:"expires_in"` // 过期时间（秒）
+	Token            string        `json:"token,omitempty"`
+	RefreshToken     string        `json:"refresh_token,omitempty"`
+	User             *UserResponse `json:"user,omitempty"`
+	ExpiresIn        int64         `json:"expires_in"`                   // 过期时间（秒）
+	RefreshExpiresIn int64         `json:"refresh_expires_in,omitempty"` // 刷新Token过期时间（秒）
+
+	TwoFactorRequired bool   `json:"two_factor_required,omitempty"`
+	ChallengeToken    string `json:"challenge_token,omitempty"`
+}
+
+// RefreshTokenRequest 刷新Token请求
+type RefreshTokenRequest struct {
+	RefreshToken string `json:"refresh_token" binding:"required"`
+}
+
+// LogoutRequest 退出登录请求
+type LogoutRequest struct {
+	RefreshToken string `json:"refresh_token" binding:"omitempty"`
+}
+
+// TwoFactorLoginRequest 两步验证登录请求，code 可以是TOTP验证码或恢复码
+type TwoFactorLoginRequest struct {
+	ChallengeToken string `json:"challenge_token" binding:"required"`
+	Code           string `json:"code" binding:"required"`
+}
+
+// TwoFactorSetupResponse 开启两步验证响应
+type TwoFactorSetupResponse struct {
+	Secret string `json:"secret"`
+	URI    string `json:"uri"` // otpauth:// 链接，可生成二维码供验证器 App 扫描
+}
+
+// TwoFactorConfirmRequest 确认开启两步验证请求
+type TwoFactorConfirmRequest struct {
+	Code string `json:"code" binding:"required"`
+}
+
+// TwoFactorDisableRequest 关闭两步验证请求，code 可以是TOTP验证码或恢复码
+type TwoFactorDisableRequest struct {
+	Password string `json:"password" binding:"required"`
+	Code     string `json:"code" binding:"required"`
+}
+
+// RecoveryCodesRequest 重新生成恢复码请求
+type RecoveryCodesRequest struct {
+	Code string `json:"code" binding:"required"`
+}
+
+// RecoveryCodesResponse 恢复码响应，恢复码只在生成时返回一次
+type RecoveryCodesResponse struct {
+	RecoveryCodes []string `json:"recovery_codes"`
+}
+
+// OIDCProviderResponse 单点登录提供方
+type OIDCProviderResponse struct {
+	Name        string `json:"name"`
+	DisplayName string `json:"display_name"`
+	LoginURL    string `json:"login_url"`
+}
+
+// OIDCCallbackRequest 身份提供方回调参数
+type OIDCCallbackRequest struct {
+	Code             string `form:"code"`
+	State            string `form:"state" binding:"required"`
+	Error            string `form:"error"`
+	ErrorDescription string `form:"error_description"`
+}
+
+// OIDCExchangeRequest 用单点登录回调得到的一次性登录代码换取 Token
+type OIDCExchangeRequest struct {
+	Code string `json:"code" binding:"required"`
 }
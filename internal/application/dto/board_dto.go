@@ -0,0 +1,71 @@
+package dto
+
+import "FLOWGO/pkg/utils"
+
+// BoardURI 看板路径参数
+type BoardURI struct {
+	ProjectID uint64 `uri:"id" binding:"required"`
+	BoardID   uint64 `uri:"bid"`
+}
+
+// BoardColumnRequest 看板列，数组顺序即从左到右的顺序
+type BoardColumnRequest struct {
+	ID       uint64 `json:"id"` // 更新看板时传入已有列的ID，新列不传
+	Name     string `json:"name" binding:"required,max=50"`
+	Status   string `json:"status" binding:"required,oneof=todo in_progress in_review done cancelled"`
+	WIPLimit int    `json:"wip_limit" binding:"min=0"` // 0 表示不限制
+}
+
+// CreateBoardRequest 创建看板请求，不传列时使用默认列
+type CreateBoardRequest struct {
+	Name    string                `json:"name" binding:"required,max=100"`
+	Columns []*BoardColumnRequest `json:"columns" binding:"omitempty,max=20,dive"`
+}
+
+// UpdateBoardRequest 更新看板请求，未出现在列表中的已有列会被删除
+type UpdateBoardRequest struct {
+	Name    string                `json:"name" binding:"required,max=100"`
+	Columns []*BoardColumnRequest `json:"columns" binding:"required,min=1,max=20,dive"`
+}
+
+// MoveCardRequest 移动卡片请求
+type MoveCardRequest struct {
+	TaskID      uint64 `json:"task_id" binding:"required"`
+	ColumnID    uint64 `json:"column_id" binding:"required"`
+	AfterTaskID uint64 `json:"after_task_id"` // 放在该卡片之后，0 表示放在列首
+}
+
+// BoardColumnResponse 看板列响应
+type BoardColumnResponse struct {
+	ID       uint64 `json:"id"`
+	Name     string `json:"name"`
+	Status   string `json:"status"`
+	Position int    `json:"position"`
+	WIPLimit int    `json:"wip_limit"`
+}
+
+// BoardResponse 看板响应
+type BoardResponse struct {
+	ID        uint64                 `json:"id"`
+	ProjectID uint64                 `json:"project_id"`
+	Name      string                 `json:"name"`
+	Columns   []*BoardColumnResponse `json:"columns"`
+	CreatedAt utils.Time             `json:"created_at"`
+	UpdatedAt utils.Time             `json:"updated_at"`
+}
+
+// BoardColumnDetailResponse 看板列及其中的卡片
+type BoardColumnDetailResponse struct {
+	BoardColumnResponse
+	Cards []*TaskResponse `json:"cards"` // 按排序键从上到下排列
+}
+
+// BoardDetailResponse 看板详情响应
+type BoardDetailResponse struct {
+	ID        uint64                       `json:"id"`
+	ProjectID uint64                       `json:"project_id"`
+	Name      string                       `json:"name"`
+	Columns   []*BoardColumnDetailResponse `json:"columns"`
+	CreatedAt utils.Time                   `json:"created_at"`
+	UpdatedAt utils.Time                   `json:"updated_at"`
+}
@@ -0,0 +1,57 @@
+package dto
+
+import "FLOWGO/pkg/utils"
+
+// CreateInvitationRequest 创建邀请请求，可同时邀请加入一个团队和一个项目
+type CreateInvitationRequest struct {
+	Email       string `json:"email" binding:"required,email"`
+	Role        string `json:"role" binding:"omitempty,oneof=admin manager member guest"` // 默认 member
+	TeamID      uint64 `json:"team_id"`
+	TeamRole    string `json:"team_role" binding:"omitempty,oneof=lead member"` // 默认 member
+	ProjectID   uint64 `json:"project_id"`
+	ProjectRole string `json:"project_role" binding:"omitempty,oneof=maintainer member viewer"` // 默认 member
+}
+
+// InvitationListRequest 邀请列表请求
+type InvitationListRequest struct {
+	PageRequest
+	Status string `json:"status" form:"status" binding:"omitempty,oneof=pending accepted revoked expired"` // 为空时返回全部
+}
+
+// AcceptInvitationRequest 接受邀请请求
+type AcceptInvitationRequest struct {
+	Token    string `json:"token" binding:"required"`
+	Name     string `json:"name" binding:"required,min=3,max=20"`
+	Password string `json:"password" binding:"required,min=6"`
+}
+
+// InvitationResponse 邀请响应
+type InvitationResponse struct {
+	ID          uint64     `json:"id"`
+	Email       string     `json:"email"`
+	Role        string     `json:"role"`
+	TeamID      uint64     `json:"team_id,omitempty"`
+	TeamRole    string     `json:"team_role,omitempty"`
+	ProjectID   uint64     `json:"project_id,omitempty"`
+	ProjectRole string     `json:"project_role,omitempty"`
+	InvitedBy   uint64     `json:"invited_by"`
+	Status      string     `json:"status"`
+	ExpiresAt   utils.Time `json:"expires_at"`
+	AcceptedAt  utils.Time `json:"accepted_at"` // 未接受时为 null
+	CreatedAt   utils.Time `json:"created_at"`
+	Link        string     `json:"link,omitempty"` // 仅在创建和重新发送时返回
+}
+
+// InvitationListResponse 邀请列表响应
+type InvitationListResponse struct {
+	List []*InvitationResponse `json:"list"`
+	Page PageResponse          `json:"page"`
+}
+
+// InvitationPreviewResponse 接受邀请前展示的邀请信息
+type InvitationPreviewResponse struct {
+	Email       string     `json:"email"`
+	TeamName    string     `json:"team_name,omitempty"`
+	ProjectName string     `json:"project_name,omitempty"`
+	ExpiresAt   utils.Time `json:"expires_at"`
+}
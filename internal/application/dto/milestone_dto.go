@@ -0,0 +1,53 @@
+package dto
+
+import "FLOWGO/pkg/utils"
+
+// MilestoneURI 里程碑路径参数，列表、创建和排序接口没有里程碑ID
+type MilestoneURI struct {
+	ProjectID   uint64 `uri:"id" binding:"required"`
+	MilestoneID uint64 `uri:"mid"`
+}
+
+// CreateMilestoneRequest 创建里程碑请求
+type CreateMilestoneRequest struct {
+	Name        string     `json:"name" binding:"required,max=100"`
+	Description string     `json:"description" binding:"omitempty,max=10000"`
+	DueDate     utils.Time `json:"due_date"` // 必填
+}
+
+// UpdateMilestoneRequest 更新里程碑请求
+type UpdateMilestoneRequest struct {
+	Name        string     `json:"name" binding:"required,max=100"`
+	Description string     `json:"description" binding:"omitempty,max=10000"`
+	DueDate     utils.Time `json:"due_date"` // 必填
+	State       string     `json:"state" binding:"required,oneof=open closed"`
+}
+
+// ReorderMilestonesRequest 调整里程碑顺序请求，需要包含项目的全部里程碑
+type ReorderMilestonesRequest struct {
+	IDs []uint64 `json:"ids" binding:"required"`
+}
+
+// MilestoneResponse 里程碑响应
+type MilestoneResponse struct {
+	ID          uint64     `json:"id"`
+	ProjectID   uint64     `json:"project_id"`
+	Name        string     `json:"name"`
+	Description string     `json:"description"`
+	DueDate     utils.Time `json:"due_date"`
+	State       string     `json:"state"`
+	Position    int        `json:"position"`
+	Overdue     bool       `json:"overdue"` // 目标日期已过但仍未关闭
+	ClosedAt    utils.Time `json:"closed_at"`
+	CreatedAt   utils.Time `json:"created_at"`
+	UpdatedAt   utils.Time `json:"updated_at"`
+}
+
+// MilestoneSummaryResponse 项目详情中的里程碑摘要
+type MilestoneSummaryResponse struct {
+	ID      uint64     `json:"id"`
+	Name    string     `json:"name"`
+	DueDate utils.Time `json:"due_date"`
+	State   string     `json:"state"`
+	Overdue bool       `json:"overdue"`
+}
@@ -2,6 +2,7 @@ package dto
 
 import (
 	"FLOWGO/pkg/utils"
+	"time"
 )
 
 // CreateProjectRequest 创建项目请求
@@ -9,6 +10,8 @@ type CreateProjectRequest struct {
 	Name        string `json:"name" binding:"required"`
 	Description string `json:"description" binding:"required"`
 	OwnerID     uint64 `json:"owner_id"`
+
+	ProgressMode string `json:"progress_mode" binding:"omitempty,oneof=manual tasks estimate milestones"` // 默认 tasks
 }
 
 // CreateProjectResponse 创建项目响应
@@ -18,17 +21,20 @@ type CreateProjectResponse struct {
 
 // UpdateProjectRequest 更新项目请求
 type UpdateProjectRequest struct {
-	ID          uint64     `json:"id" binding:"required"`
+	ID          uint64     `json:"id"` // 取路径中的项目ID，请求体中携带时必须一致
 	Name        string     `json:"name" binding:"required"`
 	Description string     `json:"description" binding:"required"`
 	OwnerID     uint64     `json:"owner_id" binding:"required"`
-	Status      int        `json:"status" binding:"required"`
+	Status      int        `json:"status" binding:"omitempty,oneof=1 2 3"` // 只能传当前状态，变更状态使用状态变更接口
 	Deadline    utils.Time `json:"deadline" binding:"required"`
 	StartDate   utils.Time `json:"start_date" binding:"required"`
 	Priority    int        `json:"priority" binding:"required"`
-	CoverImage  string     `json:"cover_image" binding:"omitempty"`
+	CoverImage  string     `json:"cover_image" binding:"omitempty"` // 全量更新，不传时清空封面
 	TeamIds     []uint64   `json:"team_ids" binding:"omitempty"`
-	Tags        []string   `json:"tags" binding:"omitempty"`
+	Tags        []string   `json:"tags" binding:"omitempty,max=20,dive,required,max=50"`
+
+	ProgressMode string `json:"progress_mode" binding:"omitempty,oneof=manual tasks estimate milestones"` // 不传时保持不变
+	Progress     *int   `json:"progress" binding:"omitempty,min=0,max=100"`                               // 仅手动模式可以设置
 }
 
 // UpdateProjectResponse 更新项目响应
@@ -44,6 +50,26 @@ type UpdateProjectResponse struct {
 	Description string     `json:"description"`
 	OwnerID     uint64     `json:"owner_id"`
 	Status      int        `json:"status"`
+
+	Progress     int    `json:"progress"`
+	ProgressMode string `json:"progress_mode"`
+
+	Version uint64 `json:"version"` // 保存后的版本号，与 ETag 响应头一致
+}
+
+// ProjectPatchDocument PATCH 项目时补丁作用的文档，校验规则与 UpdateProjectRequest 一致
+// 状态通过状态变更接口修改，负责人不能通过更新接口修改，都不在文档中
+type ProjectPatchDocument struct {
+	Name         string     `json:"name" binding:"required"`
+	Description  string     `json:"description" binding:"required"`
+	Deadline     utils.Time `json:"deadline"`
+	StartDate    utils.Time `json:"start_date"`
+	Priority     int        `json:"priority" binding:"required"`
+	CoverImage   string     `json:"cover_image"`
+	TeamIds      []uint64   `json:"team_ids"`
+	Tags         []string   `json:"tags" binding:"max=20,dive,required,max=50"`
+	ProgressMode string     `json:"progress_mode" binding:"required,oneof=manual tasks estimate milestones"`
+	Progress     int        `json:"progress" binding:"min=0,max=100"` // 仅手动模式可以修改
 }
 
 // DeleteProjectRequest 删除项目请求
@@ -77,6 +103,32 @@ type GetProjectResponse struct {
 	TeamIds     []uint64        `json:"team_ids"`
 	Users       []*UserResponse `json:"users"`
 	CreatedAt   utils.Time      `json:"created_at"`
+
+	ProgressMode string         `json:"progress_mode"`
+	TagList      []*TagResponse `json:"tag_list"` // 标签详情，包含颜色
+
+	Milestones []*MilestoneSummaryResponse `json:"milestones"` // 按顺序排列
+
+	Version uint64 `json:"version"` // 与 ETag 响应头一致，更新项目时通过 If-Match 传回
+}
+
+// ListProjectsRequest 项目列表请求
+type ListProjectsRequest struct {
+	PageRequest
+	TeamID             uint64 `form:"team_id"`             // 只查询关联到该团队的项目
+	IncludeDescendants bool   `form:"include_descendants"` // 同时包含下级团队的项目
+
+	Tags    []string `form:"tags" binding:"omitempty,max=20"`            // 按标签过滤，可重复传参或用逗号分隔
+	TagMode string   `form:"tag_mode" binding:"omitempty,oneof=any all"` // any 包含任意一个（默认），all 包含全部
+
+	Keyword      string    `form:"q" binding:"omitempty,max=100"` // 按名称和描述搜索
+	Status       *int      `form:"status" binding:"omitempty,oneof=1 2 3"`
+	Priority     *int      `form:"priority" binding:"omitempty,min=0,max=3"`
+	OwnerID      uint64    `form:"owner_id"`
+	MemberID     uint64    `form:"member_id"`                              // 负责人或项目成员
+	DeadlineFrom time.Time `form:"deadline_from" time_format:"2006-01-02"` // 截止日期不早于该日
+	DeadlineTo   time.Time `form:"deadline_to" time_format:"2006-01-02"`   // 截止日期不晚于该日
+	Sort         string    `form:"sort" binding:"omitempty,max=200"`       // 如 -priority,deadline，字段前加 - 表示降序；团队和成员只能过滤，不支持排序
 }
 
 // ProjectListResponse 项目列表响应
@@ -96,6 +148,10 @@ type ProjectResponse struct {
 	StartDate   utils.Time `json:"start_date"`
 	Progress    int        `json:"progress"`
 	Priority    int        `json:"priority"`
+
+	ProgressMode string `json:"progress_mode"`
+
+	Version uint64 `json:"version"`
 }
 
 // ProjectTeamsResponse 项目团队响应
@@ -113,10 +169,44 @@ type ProjectUsersRequest struct {
 	ID uint64 `uri:"id" binding:"required"`
 }
 
+// ProjectAvailableUsersQuery 候选成员查询参数
+type ProjectAvailableUsersQuery struct {
+	IncludeDescendants bool `form:"include_descendants"` // 同时包含下级团队的成员
+}
+
 type AddProjectUsersRequest struct {
 	Users []uint64 `json:"users" binding:"required"`
+	Role  string   `json:"role" binding:"omitempty,oneof=owner maintainer member viewer"` // 默认 member
+}
+
+// UpdateProjectUserRoleRequest 修改项目成员角色请求
+type UpdateProjectUserRoleRequest struct {
+	Role string `json:"role" binding:"required,oneof=owner maintainer member viewer"`
 }
 
 type ProjectUsersResponse struct {
 	Users []*UserResponse `json:"users"`
 }
+
+// ChangeProjectStatusRequest 项目状态变更请求
+type ChangeProjectStatusRequest struct {
+	Status int    `json:"status" binding:"required,oneof=1 2 3"` // 1 进行中，2 已完成，3 已归档
+	Reason string `json:"reason" binding:"max=500"`              // 重新打开项目时必填
+}
+
+// ProjectStatusChangeResponse 项目状态变更历史响应
+type ProjectStatusChangeResponse struct {
+	ID         uint64     `json:"id"`
+	ProjectID  uint64     `json:"project_id"`
+	FromStatus int        `json:"from_status"`
+	ToStatus   int        `json:"to_status"`
+	Reason     string     `json:"reason"`
+	OperatorID uint64     `json:"operator_id"`
+	CreatedAt  utils.Time `json:"created_at"`
+}
+
+// ChangeProjectStatusResponse 变更项目状态响应，包含变更后的项目版本号
+type ChangeProjectStatusResponse struct {
+	*ProjectStatusChangeResponse
+	Version uint64 `json:"version"`
+}
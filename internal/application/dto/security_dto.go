@@ -0,0 +1,27 @@
+package dto
+
+import "FLOWGO/pkg/utils"
+
+// SecurityEventListRequest 安全事件列表请求
+type SecurityEventListRequest struct {
+	PageRequest
+	Type string `json:"type" form:"type"` // 按事件类型过滤，为空时返回全部
+}
+
+// SecurityEventResponse 安全事件响应
+type SecurityEventResponse struct {
+	ID        uint64     `json:"id"`
+	Type      string     `json:"type"`
+	UserID    uint64     `json:"user_id,omitempty"`
+	Username  string     `json:"username"`
+	IP        string     `json:"ip,omitempty"`
+	ActorID   uint64     `json:"actor_id,omitempty"`
+	Detail    string     `json:"detail"`
+	CreatedAt utils.Time `json:"created_at"`
+}
+
+// SecurityEventListResponse 安全事件列表响应
+type SecurityEventListResponse struct {
+	List []*SecurityEventResponse `json:"list"`
+	Page PageResponse             `json:"page"`
+}
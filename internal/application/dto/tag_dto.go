@@ -0,0 +1,27 @@
+package dto
+
+// CreateTagRequest 创建标签请求
+type CreateTagRequest struct {
+	Name  string `json:"name" binding:"required,max=50"`
+	Color string `json:"color" binding:"omitempty,hexcolor,len=7"` // #RRGGBB，默认灰色
+}
+
+// UpdateTagRequest 修改标签请求
+type UpdateTagRequest struct {
+	Name  string `json:"name" binding:"required,max=50"`
+	Color string `json:"color" binding:"required,hexcolor,len=7"`
+}
+
+// SuggestTagsRequest 标签自动补全请求
+type SuggestTagsRequest struct {
+	Q     string `form:"q" binding:"omitempty,max=50"`           // 名称前缀，为空时返回最常用的标签
+	Limit int    `form:"limit" binding:"omitempty,min=1,max=50"` // 默认 10
+}
+
+// TagResponse 标签响应
+type TagResponse struct {
+	ID         uint64 `json:"id"`
+	Name       string `json:"name"`
+	Color      string `json:"color"`
+	UsageCount int64  `json:"usage_count"`
+}
@@ -0,0 +1,68 @@
+package dto
+
+import "FLOWGO/pkg/utils"
+
+// TaskURI 任务路径参数，项目内的任务列表、创建接口没有任务ID
+type TaskURI struct {
+	ProjectID uint64 `uri:"id" binding:"required"`
+	TaskID    uint64 `uri:"tid"`
+}
+
+// CreateTaskRequest 创建任务请求
+type CreateTaskRequest struct {
+	Title       string     `json:"title" binding:"required,max=200"`
+	Description string     `json:"description" binding:"omitempty,max=10000"`
+	AssigneeID  uint64     `json:"assignee_id"` // 0 表示不指派
+	Status      string     `json:"status" binding:"omitempty,oneof=todo in_progress in_review done cancelled"`
+	Priority    *int       `json:"priority" binding:"omitempty,min=0,max=3"` // 默认 2
+	DueDate     utils.Time `json:"due_date"`
+	Estimate    float64    `json:"estimate" binding:"min=0"` // 预估工时（小时）
+}
+
+// UpdateTaskRequest 更新任务请求
+type UpdateTaskRequest struct {
+	Title       string     `json:"title" binding:"required,max=200"`
+	Description string     `json:"description" binding:"omitempty,max=10000"`
+	AssigneeID  uint64     `json:"assignee_id"` // 0 表示取消指派
+	Status      string     `json:"status" binding:"required,oneof=todo in_progress in_review done cancelled"`
+	Priority    int        `json:"priority" binding:"min=0,max=3"`
+	DueDate     utils.Time `json:"due_date"`
+	Estimate    float64    `json:"estimate" binding:"min=0"`
+}
+
+// ListTasksRequest 任务列表请求
+type ListTasksRequest struct {
+	PageRequest
+	Status     string `form:"status" binding:"omitempty,oneof=todo in_progress in_review done cancelled"`
+	Priority   *int   `form:"priority" binding:"omitempty,min=0,max=3"`
+	AssigneeID uint64 `form:"assignee_id"`
+	Unassigned bool   `form:"unassigned"` // 只查询未指派的任务
+	ReporterID uint64 `form:"reporter_id"`
+	Keyword    string `form:"q" binding:"omitempty,max=100"` // 按标题搜索
+	Overdue    bool   `form:"overdue"`                       // 只查询已逾期的任务
+}
+
+// TaskResponse 任务响应
+type TaskResponse struct {
+	ID          uint64     `json:"id"`
+	ProjectID   uint64     `json:"project_id"`
+	Title       string     `json:"title"`
+	Description string     `json:"description"`
+	AssigneeID  uint64     `json:"assignee_id"`
+	ReporterID  uint64     `json:"reporter_id"`
+	Status      string     `json:"status"`
+	Priority    int        `json:"priority"`
+	DueDate     utils.Time `json:"due_date"`
+	Estimate    float64    `json:"estimate"`
+	Rank        string     `json:"rank"`
+	Overdue     bool       `json:"overdue"`
+	CompletedAt utils.Time `json:"completed_at"`
+	CreatedAt   utils.Time `json:"created_at"`
+	UpdatedAt   utils.Time `json:"updated_at"`
+}
+
+// TaskListResponse 任务列表响应
+type TaskListResponse struct {
+	List []*TaskResponse `json:"list"`
+	Page PageResponse    `json:"page"`
+}
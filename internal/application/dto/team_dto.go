@@ -0,0 +1,70 @@
+package dto
+
+import "FLOWGO/pkg/utils"
+
+// CreateTeamRequest 创建团队请求
+type CreateTeamRequest struct {
+	Name        string `json:"name" binding:"required,max=100"`
+	Description string `json:"description" binding:"omitempty,max=500"`
+	ParentID    uint64 `json:"parent_id"` // 上级团队，0 表示顶级团队
+}
+
+// UpdateTeamRequest 更新团队请求
+type UpdateTeamRequest struct {
+	Name        string  `json:"name" binding:"required,max=100"`
+	Description string  `json:"description" binding:"omitempty,max=500"`
+	ParentID    *uint64 `json:"parent_id"` // 上级团队，0 表示移到顶级，不传则保持不变
+}
+
+// TransferTeamOwnerRequest 转移团队负责人请求
+type TransferTeamOwnerRequest struct {
+	OwnerID uint64 `json:"owner_id" binding:"required"`
+}
+
+// AddTeamMembersRequest 添加团队成员请求
+type AddTeamMembersRequest struct {
+	Users []uint64 `json:"users" binding:"required,min=1"`
+	Role  string   `json:"role" binding:"omitempty,oneof=lead member"` // 默认 member
+}
+
+// UpdateTeamMemberRoleRequest 修改团队成员角色请求
+type UpdateTeamMemberRoleRequest struct {
+	Role string `json:"role" binding:"required,oneof=lead member"`
+}
+
+// TeamDetailResponse 团队详情响应
+type TeamDetailResponse struct {
+	ID          uint64     `json:"id"`
+	Name        string     `json:"name"`
+	Description string     `json:"description"`
+	OwnerID     uint64     `json:"owner_id"`
+	ParentID    uint64     `json:"parent_id"`
+	CreatedAt   utils.Time `json:"created_at"`
+	UpdatedAt   utils.Time `json:"updated_at"`
+}
+
+// TeamListResponse 团队列表响应
+type TeamListResponse struct {
+	List []*TeamDetailResponse `json:"list"`
+	Page PageResponse          `json:"page"`
+}
+
+// TeamMembersResponse 团队成员响应
+type TeamMembersResponse struct {
+	Users []*UserResponse `json:"users"`
+}
+
+// TeamTreeNode 团队树节点
+type TeamTreeNode struct {
+	ID          uint64          `json:"id"`
+	Name        string          `json:"name"`
+	Description string          `json:"description"`
+	OwnerID     uint64          `json:"owner_id"`
+	ParentID    uint64          `json:"parent_id"`
+	Children    []*TeamTreeNode `json:"children"`
+}
+
+// TeamTreeResponse 团队树响应
+type TeamTreeResponse struct {
+	Teams []*TeamTreeNode `json:"teams"`
+}
@@ -0,0 +1,46 @@
+package dto
+
+import "FLOWGO/pkg/utils"
+
+// ListTrashRequest 回收站列表请求
+type ListTrashRequest struct {
+	PageRequest
+}
+
+// TrashProjectResponse 回收站中的项目
+type TrashProjectResponse struct {
+	ID        uint64     `json:"id"`
+	Name      string     `json:"name"`
+	OwnerID   uint64     `json:"owner_id"`
+	Status    int        `json:"status"`
+	DeletedAt utils.Time `json:"deleted_at"`
+	PurgeAt   utils.Time `json:"purge_at"` // 超过保留期后将被永久删除的时间
+}
+
+// TrashProjectListResponse 回收站项目列表响应
+type TrashProjectListResponse struct {
+	List []*TrashProjectResponse `json:"list"`
+	Page PageResponse            `json:"page"`
+}
+
+// TrashUserResponse 回收站中的用户
+type TrashUserResponse struct {
+	ID          uint64     `json:"id"`
+	Name        string     `json:"name"`
+	DisplayName string     `json:"display_name"`
+	Email       string     `json:"email"`
+	DeletedAt   utils.Time `json:"deleted_at"`
+	PurgeAt     utils.Time `json:"purge_at"` // 超过保留期后将被永久删除的时间，仍是项目负责人的用户不会被清除
+}
+
+// TrashUserListResponse 回收站用户列表响应
+type TrashUserListResponse struct {
+	List []*TrashUserResponse `json:"list"`
+	Page PageResponse         `json:"page"`
+}
+
+// PurgeTrashResult 清理回收站的结果
+type PurgeTrashResult struct {
+	Projects int64 `json:"projects"`
+	Users    int64 `json:"users"`
+}
@@ -5,23 +5,62 @@ type CreateUserRequest struct {
 	Name     string `json:"name" binding:"required,min=3,max=20"`
 	Email    string `json:"email" binding:"required,email"`
 	Password string `json:"password" binding:"required,min=6"`
+	Role     string `json:"role" binding:"omitempty,oneof=admin manager member guest"`
 }
 
-// UpdateUserRequest 更新用户请求
+// UpdateUserRequest 管理员更新用户请求，字段为空时保持不变
 type UpdateUserRequest struct {
-	Email  string `json:"email" binding:"omitempty,email"`
-	Status int    `json:"status" binding:"omitempty,oneof=1 2"`
+	Email       string `json:"email" binding:"omitempty,email"`
+	DisplayName string `json:"display_name" binding:"omitempty,max=50"`
+	Role        string `json:"role" binding:"omitempty,oneof=admin manager member guest"`
+	Status      int    `json:"status" binding:"omitempty,oneof=1 2"`
+}
+
+// UserPatchDocument PATCH 用户时补丁作用的文档，校验规则与 UpdateUserRequest 一致，显示名称可以清空
+type UserPatchDocument struct {
+	Email       string `json:"email" binding:"required,email"`
+	DisplayName string `json:"display_name" binding:"omitempty,max=50"`
+	Role        string `json:"role" binding:"required,oneof=admin manager member guest"`
+	Status      int    `json:"status" binding:"required,oneof=1 2"`
+}
+
+// UpdateProfileRequest 更新个人资料请求
+type UpdateProfileRequest struct {
+	DisplayName string `json:"display_name" binding:"omitempty,max=50"`
+	Avatar      string `json:"avatar" binding:"omitempty,max=500"`
+}
+
+// ChangePasswordRequest 修改密码请求
+type ChangePasswordRequest struct {
+	CurrentPassword string `json:"current_password" binding:"required"`
+	NewPassword     string `json:"new_password" binding:"required,min=6"`
 }
 
 // UserResponse 用户响应
 type UserResponse struct {
-	ID     uint64 `json:"id"`
-	Name   string `json:"name"`
-	Email  string `json:"email"`
-	Avatar string `json:"avatar"`
-	TeamID uint64 `json:"team_id"`
-	Role   string `json:"role"`
-	Status int    `json:"status"`
+	ID          uint64 `json:"id"`
+	Name        string `json:"name"`
+	DisplayName string `json:"display_name"`
+	Email       string `json:"email"`
+	Avatar      string `json:"avatar"`
+	TeamID      uint64 `json:"team_id"`
+	Role        string `json:"role"`
+	ProjectRole string `json:"project_role,omitempty"` // 仅在项目成员列表中返回
+	TeamRole    string `json:"team_role,omitempty"`    // 仅在团队成员列表中返回
+	Status      int    `json:"status"`
+
+	EmailVerified    bool `json:"email_verified"`
+	TwoFactorEnabled bool `json:"two_factor_enabled"`
+}
+
+// ListUsersRequest 用户列表请求
+type ListUsersRequest struct {
+	PageRequest
+	Keyword string `form:"q" binding:"omitempty,max=100"` // 按用户名、显示名称和邮箱搜索
+	Status  int    `form:"status" binding:"omitempty,oneof=1 2"`
+	Role    string `form:"role" binding:"omitempty,oneof=admin manager member guest"`
+	TeamID  uint64 `form:"team_id"`                          // 团队成员
+	Sort    string `form:"sort" binding:"omitempty,max=200"` // 如 name,-created_at，字段前加 - 表示降序；团队只能过滤，不支持排序
 }
 
 // UserListResponse 用户列表响应
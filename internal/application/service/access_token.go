@@ -0,0 +1,143 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"FLOWGO/internal/application/dto"
+	"FLOWGO/internal/domain/entity"
+	"FLOWGO/internal/domain/permission"
+	"FLOWGO/internal/domain/repository"
+	"FLOWGO/pkg/contextutil"
+	apperrors "FLOWGO/pkg/errors"
+	"FLOWGO/pkg/utils"
+)
+
+// AccessTokenPrefix 个人访问令牌的固定前缀，用于和JWT区分
+const AccessTokenPrefix = "fgp_"
+
+// AccessTokenService 个人访问令牌服务
+type AccessTokenService struct {
+	tokenRepo repository.AccessTokenRepository
+}
+
+// NewAccessTokenService 创建个人访问令牌服务实例
+func NewAccessTokenService(tokenRepo repository.AccessTokenRepository) *AccessTokenService {
+	return &AccessTokenService{
+		tokenRepo: tokenRepo,
+	}
+}
+
+// CreateToken 为当前用户创建令牌
+func (s *AccessTokenService) CreateToken(ctx context.Context, req dto.CreateAccessTokenRequest) (*dto.CreateAccessTokenResponse, error) {
+	userID, err := s.requireInteractiveUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// 令牌的范围不能超出当前角色拥有的权限
+	role := permission.ParseRole(contextutil.GetRole(ctx))
+	for _, scope := range req.Scopes {
+		if !role.Can(permission.Permission(scope)) {
+			return nil, apperrors.NewAppError(400, "无效的权限范围: "+scope, nil)
+		}
+	}
+
+	secret, err := utils.GenerateRandomToken(20)
+	if err != nil {
+		return nil, apperrors.NewAppError(500, "生成令牌失败", err)
+	}
+	plain := AccessTokenPrefix + secret
+
+	token := &entity.AccessToken{
+		UserID:    userID,
+		Name:      req.Name,
+		Prefix:    plain[:len(AccessTokenPrefix)+6],
+		TokenHash: utils.HashToken(plain),
+		Scopes:    req.Scopes,
+	}
+	if req.ExpiresInDays > 0 {
+		expiresAt := time.Now().AddDate(0, 0, req.ExpiresInDays)
+		token.ExpiresAt = &expiresAt
+	}
+
+	if err := s.tokenRepo.Create(ctx, token); err != nil {
+		return nil, apperrors.NewAppError(500, "创建令牌失败", err)
+	}
+
+	return &dto.CreateAccessTokenResponse{
+		AccessTokenResponse: *toAccessTokenResponse(token),
+		Token:               plain,
+	}, nil
+}
+
+// ListTokens 查询当前用户的令牌
+func (s *AccessTokenService) ListTokens(ctx context.Context) (*dto.AccessTokenListResponse, error) {
+	userID, err := contextutil.GetUserID(ctx)
+	if err != nil {
+		return nil, apperrors.ErrUnauthorized
+	}
+
+	tokens, err := s.tokenRepo.ListByUserID(ctx, userID)
+	if err != nil {
+		return nil, apperrors.NewAppError(500, "查询令牌失败", err)
+	}
+	list := make([]*dto.AccessTokenResponse, 0, len(tokens))
+	for _, token := range tokens {
+		list = append(list, toAccessTokenResponse(token))
+	}
+	return &dto.AccessTokenListResponse{List: list}, nil
+}
+
+// RevokeToken 吊销当前用户的令牌
+func (s *AccessTokenService) RevokeToken(ctx context.Context, id uint64) error {
+	userID, err := s.requireInteractiveUser(ctx)
+	if err != nil {
+		return err
+	}
+
+	token, err := s.tokenRepo.FindByID(ctx, id)
+	if err != nil {
+		return apperrors.NewAppError(500, "查询令牌失败", err)
+	}
+	if token == nil || token.UserID != userID {
+		return apperrors.ErrNotFound
+	}
+
+	if err := s.tokenRepo.Revoke(ctx, id, time.Now()); err != nil {
+		return apperrors.NewAppError(500, "吊销令牌失败", err)
+	}
+	return nil
+}
+
+// requireInteractiveUser 令牌只能通过登录会话管理，不能用令牌本身创建或吊销令牌
+func (s *AccessTokenService) requireInteractiveUser(ctx context.Context) (uint64, error) {
+	userID, err := contextutil.GetUserID(ctx)
+	if err != nil {
+		return 0, apperrors.ErrUnauthorized
+	}
+	if contextutil.GetScopes(ctx) != nil {
+		return 0, apperrors.ErrForbidden
+	}
+	return userID, nil
+}
+
+func toAccessTokenResponse(token *entity.AccessToken) *dto.AccessTokenResponse {
+	resp := &dto.AccessTokenResponse{
+		ID:        token.ID,
+		Name:      token.Name,
+		Prefix:    token.Prefix,
+		Scopes:    token.Scopes,
+		CreatedAt: utils.NewTime(token.CreatedAt),
+	}
+	if token.ExpiresAt != nil {
+		resp.ExpiresAt = utils.NewTime(*token.ExpiresAt)
+	}
+	if token.LastUsedAt != nil {
+		resp.LastUsedAt = utils.NewTime(*token.LastUsedAt)
+	}
+	if token.RevokedAt != nil {
+		resp.RevokedAt = utils.NewTime(*token.RevokedAt)
+	}
+	return resp
+}
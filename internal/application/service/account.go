@@ -0,0 +1,217 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/url"
+	"strings"
+	"time"
+
+	"FLOWGO/internal/application/dto"
+	"FLOWGO/internal/domain/entity"
+	"FLOWGO/internal/domain/repository"
+	"FLOWGO/pkg/contextutil"
+	apperrors "FLOWGO/pkg/errors"
+	"FLOWGO/pkg/mailer"
+	"FLOWGO/pkg/utils"
+)
+
+const (
+	passwordResetTokenTTL = time.Hour
+	emailVerifyTokenTTL   = 24 * time.Hour
+)
+
+// AccountService 账号服务：找回密码、邮箱验证
+type AccountService struct {
+	userRepo    repository.UserRepository
+	oneTimeRepo repository.OneTimeTokenRepository
+	authService *AuthService
+	mailer      mailer.Mailer
+	publicURL   string
+}
+
+// NewAccountService 创建账号服务实例，publicURL 为邮件链接指向的前端地址
+func NewAccountService(
+	userRepo repository.UserRepository,
+	oneTimeRepo repository.OneTimeTokenRepository,
+	authService *AuthService,
+	mailer mailer.Mailer,
+	publicURL string,
+) *AccountService {
+	return &AccountService{
+		userRepo:    userRepo,
+		oneTimeRepo: oneTimeRepo,
+		authService: authService,
+		mailer:      mailer,
+		publicURL:   strings.TrimRight(publicURL, "/"),
+	}
+}
+
+// ForgotPassword 发送重置密码邮件
+// 无论邮箱是否存在都返回成功，避免泄露账号信息
+func (s *AccountService) ForgotPassword(ctx context.Context, req dto.ForgotPasswordRequest) error {
+	user, err := s.userRepo.FindByEmail(ctx, req.Email)
+	if err != nil {
+		return apperrors.NewAppError(500, "查询用户失败", err)
+	}
+	if user == nil || !user.IsActive() {
+		return nil
+	}
+
+	token, err := s.issueToken(ctx, user, entity.OneTimeTokenPasswordReset, passwordResetTokenTTL)
+	if err != nil {
+		return err
+	}
+
+	link := s.link("/reset-password", token)
+	body := fmt.Sprintf("%s，您好：\n\n请在 %d 分钟内打开以下链接重置密码：\n%s\n\n如果这不是您本人的操作，请忽略此邮件。\n",
+		user.Name, int(passwordResetTokenTTL.Minutes()), link)
+	// 发送失败只记录日志，同样返回成功
+	_ = s.send(ctx, user.Email, "FlowGo 重置密码", body)
+	return nil
+}
+
+// ResetPassword 使用一次性令牌重置密码，并吊销该用户的全部登录会话
+func (s *AccountService) ResetPassword(ctx context.Context, req dto.ResetPasswordRequest) error {
+	token, err := s.consumeToken(ctx, entity.OneTimeTokenPasswordReset, req.Token)
+	if err != nil {
+		return err
+	}
+
+	user, err := s.userRepo.FindByID(ctx, token.UserID)
+	if err != nil {
+		return apperrors.NewAppError(500, "查询用户失败", err)
+	}
+	// 邮箱变更后，发往旧邮箱的链接不再有效
+	if user == nil || !user.IsActive() || !token.MatchesEmail(user.Email) {
+		return apperrors.NewAppError(400, "链接无效或已过期", nil)
+	}
+
+	hashedPassword, err := utils.HashPassword(req.Password)
+	if err != nil {
+		return apperrors.NewAppError(500, "密码加密失败", err)
+	}
+	user.Password = hashedPassword
+	// 能收到重置邮件即证明拥有该邮箱
+	user.MarkEmailVerified(time.Now())
+	if err := s.userRepo.Update(ctx, user); err != nil {
+		return apperrors.NewAppError(500, "更新密码失败", err)
+	}
+
+	if err := s.oneTimeRepo.InvalidateByUser(ctx, user.ID, entity.OneTimeTokenPasswordReset, time.Now()); err != nil {
+		return apperrors.NewAppError(500, "作废令牌失败", err)
+	}
+	if err := s.authService.RevokeUserSessions(ctx, user.ID); err != nil {
+		return apperrors.NewAppError(500, "吊销登录会话失败", err)
+	}
+	return nil
+}
+
+// SendEmailVerification 向当前用户发送邮箱验证邮件
+func (s *AccountService) SendEmailVerification(ctx context.Context) error {
+	userID, err := contextutil.GetUserID(ctx)
+	if err != nil {
+		return apperrors.ErrUnauthorized
+	}
+	user, err := s.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		return apperrors.NewAppError(500, "查询用户失败", err)
+	}
+	if user == nil {
+		return apperrors.ErrNotFound
+	}
+	if user.EmailVerified {
+		return apperrors.NewAppError(400, "邮箱已验证", nil)
+	}
+
+	// 只保留最新一封邮件中的链接有效
+	if err := s.oneTimeRepo.InvalidateByUser(ctx, user.ID, entity.OneTimeTokenEmailVerify, time.Now()); err != nil {
+		return apperrors.NewAppError(500, "作废令牌失败", err)
+	}
+	token, err := s.issueToken(ctx, user, entity.OneTimeTokenEmailVerify, emailVerifyTokenTTL)
+	if err != nil {
+		return err
+	}
+
+	link := s.link("/verify-email", token)
+	body := fmt.Sprintf("%s，您好：\n\n请在 %d 小时内打开以下链接验证您的邮箱：\n%s\n",
+		user.Name, int(emailVerifyTokenTTL.Hours()), link)
+	return s.send(ctx, user.Email, "FlowGo 邮箱验证", body)
+}
+
+// VerifyEmail 使用一次性令牌验证邮箱
+func (s *AccountService) VerifyEmail(ctx context.Context, req dto.VerifyEmailRequest) error {
+	token, err := s.consumeToken(ctx, entity.OneTimeTokenEmailVerify, req.Token)
+	if err != nil {
+		return err
+	}
+
+	user, err := s.userRepo.FindByID(ctx, token.UserID)
+	if err != nil {
+		return apperrors.NewAppError(500, "查询用户失败", err)
+	}
+	// 只有收到邮件的那个邮箱才算验证通过
+	if user == nil || !token.MatchesEmail(user.Email) {
+		return apperrors.NewAppError(400, "链接无效或已过期", nil)
+	}
+
+	user.MarkEmailVerified(time.Now())
+	if err := s.userRepo.Update(ctx, user); err != nil {
+		return apperrors.NewAppError(500, "更新用户失败", err)
+	}
+	return nil
+}
+
+// issueToken 为用户当前邮箱生成一次性令牌，数据库只保存摘要，返回明文
+func (s *AccountService) issueToken(ctx context.Context, user *entity.User, purpose entity.OneTimeTokenPurpose, ttl time.Duration) (string, error) {
+	plain, err := utils.GenerateRandomToken(32)
+	if err != nil {
+		return "", apperrors.NewAppError(500, "生成令牌失败", err)
+	}
+	token := &entity.OneTimeToken{
+		UserID:    user.ID,
+		Purpose:   purpose,
+		Email:     user.Email,
+		TokenHash: utils.HashToken(plain),
+		ExpiresAt: time.Now().Add(ttl),
+	}
+	if err := s.oneTimeRepo.Create(ctx, token); err != nil {
+		return "", apperrors.NewAppError(500, "保存令牌失败", err)
+	}
+	return plain, nil
+}
+
+// consumeToken 校验并消费一次性令牌
+func (s *AccountService) consumeToken(ctx context.Context, purpose entity.OneTimeTokenPurpose, plain string) (*entity.OneTimeToken, error) {
+	token, err := s.oneTimeRepo.FindByHash(ctx, purpose, utils.HashToken(plain))
+	if err != nil {
+		return nil, apperrors.NewAppError(500, "查询令牌失败", err)
+	}
+	now := time.Now()
+	if token == nil || !token.IsUsable(now) {
+		return nil, apperrors.NewAppError(400, "链接无效或已过期", nil)
+	}
+
+	ok, err := s.oneTimeRepo.MarkUsed(ctx, token.ID, now)
+	if err != nil {
+		return nil, apperrors.NewAppError(500, "更新令牌失败", err)
+	}
+	if !ok {
+		return nil, apperrors.NewAppError(400, "链接无效或已过期", nil)
+	}
+	return token, nil
+}
+
+func (s *AccountService) link(path, token string) string {
+	return s.publicURL + path + "?token=" + url.QueryEscape(token)
+}
+
+func (s *AccountService) send(ctx context.Context, to, subject, body string) error {
+	err := s.mailer.Send(ctx, mailer.Message{To: []string{to}, Subject: subject, Body: body})
+	if err != nil {
+		log.Printf("Failed to send mail to %s: %v", to, err)
+		return apperrors.NewAppError(500, "发送邮件失败", err)
+	}
+	return nil
+}
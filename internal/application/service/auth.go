@@ -2,62 +2,366 @@ package service
 
 import (
 	"context"
+	"strings"
 	"time"
 
 	"FLOWGO/internal/application/dto"
+	"FLOWGO/internal/domain/entity"
+	"FLOWGO/internal/domain/permission"
 	"FLOWGO/internal/domain/repository"
+	"FLOWGO/pkg/contextutil"
 	apperrors "FLOWGO/pkg/errors"
 	"FLOWGO/pkg/jwt"
+	"FLOWGO/pkg/utils"
 )
 
 // LoginUseCase 登录用例
 type AuthService struct {
-	userRepo repository.UserRepository
+	userRepo        repository.UserRepository
+	tokenRepo       repository.TokenRepository
+	accessTokenRepo repository.AccessTokenRepository
+	twoFactor       *TwoFactorService
+	loginGuard      *LoginGuard
+}
+
+// Principal 认证主体，由认证中间件写入请求上下文
+type Principal struct {
+	UserID   uint64
+	Username string
+	Role     string
+	TokenID  string   // JWT 的 jti，个人访问令牌为空
+	Scopes   []string // 个人访问令牌的权限范围，JWT 为 nil
 }
 
 // NewAuthService 创建认证服务实例
-func NewAuthService(userRepo repository.UserRepository) *AuthService {
+func NewAuthService(
+	userRepo repository.UserRepository,
+	tokenRepo repository.TokenRepository,
+	accessTokenRepo repository.AccessTokenRepository,
+	twoFactor *TwoFactorService,
+	loginGuard *LoginGuard,
+) *AuthService {
 	return &AuthService{
-		userRepo: userRepo,
+		userRepo:        userRepo,
+		tokenRepo:       tokenRepo,
+		accessTokenRepo: accessTokenRepo,
+		twoFactor:       twoFactor,
+		loginGuard:      loginGuard,
+	}
+}
+
+// Login 登录，clientIP 用于按IP统计失败次数
+func (uc *AuthService) Login(ctx context.Context, req dto.LoginRequest, clientIP string) (*dto.LoginResponse, error) {
+	// 根据用户名或邮箱查找用户
+	user, err := uc.findLoginUser(ctx, req.Username)
+	if err != nil {
+		return nil, apperrors.NewAppError(500, "查询用户失败", err)
+	}
+
+	// 失败次数按用户名统计，使用邮箱登录时也归到同一账号
+	loginName, userID := req.Username, uint64(0)
+	if user != nil {
+		loginName, userID = user.Name, user.ID
+	}
+	if err := uc.loginGuard.Check(ctx, loginName, clientIP); err != nil {
+		return nil, err
+	}
+
+	// 验证密码
+	if user == nil || !utils.CheckPassword(req.Password, user.Password) {
+		uc.loginGuard.Fail(ctx, loginName, userID, clientIP)
+		return nil, apperrors.NewAppError(401, "用户名或密码错误", nil)
+	}
+
+	// 检查用户状态（放在密码校验之后，避免泄露账号是否存在）
+	if !user.IsActive() {
+		return nil, apperrors.NewAppError(403, "用户已被禁用", nil)
+	}
+
+	// 开启两步验证时先返回挑战Token，由 LoginTwoFactor 换取正式Token
+	// 此时不清除失败记录，避免通过反复输入正确密码绕过验证码的失败计数
+	if user.TwoFactorEnabled {
+		return uc.twoFactorChallenge(user)
+	}
+
+	uc.loginGuard.Succeed(ctx, user.Name)
+	return uc.issueTokens(user)
+}
+
+// LoginTwoFactor 校验挑战Token和验证码（或恢复码），签发正式Token
+// 验证码错误与密码错误一样计入失败次数
+func (uc *AuthService) LoginTwoFactor(ctx context.Context, req dto.TwoFactorLoginRequest, clientIP string) (*dto.LoginResponse, error) {
+	claims, err := uc.parseToken(ctx, req.ChallengeToken, jwt.TokenTypeTwoFactor)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := uc.userRepo.FindByID(ctx, claims.UserID)
+	if err != nil {
+		return nil, apperrors.NewAppError(500, "查询用户失败", err)
+	}
+	if user == nil || !user.IsActive() {
+		return nil, apperrors.NewAppError(401, "用户不存在或已被禁用", nil)
+	}
+	if !user.TwoFactorEnabled {
+		return nil, apperrors.NewAppError(401, "Token无效或已过期", nil)
+	}
+
+	if err := uc.loginGuard.Check(ctx, user.Name, clientIP); err != nil {
+		return nil, err
+	}
+	if err := uc.twoFactor.Verify(ctx, user, req.Code); err != nil {
+		if appErr, ok := err.(*apperrors.AppError); ok && appErr.Code == 401 {
+			uc.loginGuard.Fail(ctx, user.Name, user.ID, clientIP)
+		}
+		return nil, err
+	}
+	uc.loginGuard.Succeed(ctx, user.Name)
+
+	// 挑战Token只能使用一次，并发提交同一挑战时只有一个请求能换取正式Token
+	consumed, err := uc.tokenRepo.Consume(ctx, claims.ID, claims.ExpiresAt.Time)
+	if err != nil {
+		return nil, apperrors.NewAppError(500, "吊销Token失败", err)
+	}
+	if !consumed {
+		return nil, apperrors.NewAppError(401, "Token已失效", nil)
+	}
+
+	return uc.issueTokens(user)
+}
+
+// Refresh 使用刷新Token换取新的Token对，旧的刷新Token随即失效
+// 已使用过的刷新Token再次出现说明可能已泄露，吊销该用户的全部会话
+func (uc *AuthService) Refresh(ctx context.Context, req dto.RefreshTokenRequest) (*dto.LoginResponse, error) {
+	claims, err := jwt.ParseToken(req.RefreshToken)
+	if err != nil || claims.TokenType != jwt.TokenTypeRefresh || claims.ID == "" {
+		return nil, apperrors.NewAppError(401, "Token无效或已过期", err)
+	}
+	if err := uc.checkUserRevocation(ctx, claims); err != nil {
+		return nil, err
+	}
+
+	user, err := uc.userRepo.FindByID(ctx, claims.UserID)
+	if err != nil {
+		return nil, apperrors.NewAppError(500, "查询用户失败", err)
+	}
+	if user == nil || !user.IsActive() {
+		return nil, apperrors.NewAppError(401, "用户不存在或已被禁用", nil)
+	}
+
+	// 轮换：原子地吊销旧的刷新Token，并发刷新时只有一个请求成功
+	consumed, err := uc.tokenRepo.Consume(ctx, claims.ID, claims.ExpiresAt.Time)
+	if err != nil {
+		return nil, apperrors.NewAppError(500, "吊销Token失败", err)
+	}
+	if !consumed {
+		if err := uc.RevokeUserSessions(ctx, user.ID); err != nil {
+			return nil, apperrors.NewAppError(500, "吊销Token失败", err)
+		}
+		return nil, apperrors.NewAppError(401, "Token已失效", nil)
+	}
+
+	return uc.issueTokens(user)
+}
+
+// Logout 退出登录，吊销当前访问Token以及请求中携带的刷新Token
+func (uc *AuthService) Logout(ctx context.Context, req dto.LogoutRequest) error {
+	userID, err := contextutil.GetUserID(ctx)
+	if err != nil {
+		return apperrors.ErrUnauthorized
+	}
+
+	// 访问Token的剩余有效期不会超过其完整有效期
+	if jti := contextutil.GetTokenID(ctx); jti != "" {
+		if err := uc.tokenRepo.Revoke(ctx, jti, time.Now().Add(jwt.GetTokenExpiration())); err != nil {
+			return apperrors.NewAppError(500, "吊销Token失败", err)
+		}
+	}
+
+	if req.RefreshToken != "" {
+		claims, err := uc.parseToken(ctx, req.RefreshToken, jwt.TokenTypeRefresh)
+		if err != nil {
+			return err
+		}
+		if claims.UserID != userID {
+			return apperrors.ErrForbidden
+		}
+		if err := uc.tokenRepo.Revoke(ctx, claims.ID, claims.ExpiresAt.Time); err != nil {
+			return apperrors.NewAppError(500, "吊销Token失败", err)
+		}
 	}
+	return nil
 }
 
-// Login 登录
-func (uc *AuthService) Login(ctx context.Context, req dto.LoginRequest) (*dto.LoginResponse, error) {
-	// // 根据用户名查找用户
-	// user, err := uc.userRepo.FindByUsername(ctx, req.Username)
-	// if err != nil {
-	// 	return nil, apperrors.NewAppError(500, "查询用户失败", err)
-	// }
-	// if user == nil {
-	// 	return nil, apperrors.NewAppError(401, "用户名或密码错误", nil)
-	// }
+// Authenticate 校验访问Token或个人访问令牌，供认证中间件使用
+func (uc *AuthService) Authenticate(ctx context.Context, token string) (*Principal, error) {
+	if strings.HasPrefix(token, AccessTokenPrefix) {
+		return uc.authenticateAccessToken(ctx, token)
+	}
+
+	claims, err := uc.parseToken(ctx, token, jwt.TokenTypeAccess)
+	if err != nil {
+		return nil, err
+	}
+	return &Principal{
+		UserID:   claims.UserID,
+		Username: claims.Username,
+		Role:     claims.Role,
+		TokenID:  claims.ID,
+	}, nil
+}
+
+// authenticateAccessToken 校验个人访问令牌
+func (uc *AuthService) authenticateAccessToken(ctx context.Context, plain string) (*Principal, error) {
+	token, err := uc.accessTokenRepo.FindByHash(ctx, utils.HashToken(plain))
+	if err != nil {
+		return nil, apperrors.NewAppError(500, "查询令牌失败", err)
+	}
+	now := time.Now()
+	if token == nil || !token.IsUsable(now) {
+		return nil, apperrors.NewAppError(401, "Token无效或已过期", nil)
+	}
+
+	user, err := uc.userRepo.FindByID(ctx, token.UserID)
+	if err != nil {
+		return nil, apperrors.NewAppError(500, "查询用户失败", err)
+	}
+	if user == nil || !user.IsActive() {
+		return nil, apperrors.NewAppError(401, "用户不存在或已被禁用", nil)
+	}
+
+	// 降低写入频率：一分钟内重复使用不再更新
+	if token.LastUsedAt == nil || now.Sub(*token.LastUsedAt) > time.Minute {
+		if err := uc.accessTokenRepo.TouchLastUsed(ctx, token.ID, now); err != nil {
+			return nil, apperrors.NewAppError(500, "更新令牌失败", err)
+		}
+	}
+
+	scopes := token.Scopes
+	if scopes == nil {
+		scopes = []string{}
+	}
+	return &Principal{
+		UserID:   user.ID,
+		Username: user.Name,
+		Role:     string(permission.ParseRole(user.Role)),
+		Scopes:   scopes,
+	}, nil
+}
+
+// parseToken 解析Token并检查类型与吊销状态
+func (uc *AuthService) parseToken(ctx context.Context, token, tokenType string) (*jwt.Claims, error) {
+	claims, err := jwt.ParseToken(token)
+	if err != nil || claims.TokenType != tokenType || claims.ID == "" {
+		return nil, apperrors.NewAppError(401, "Token无效或已过期", err)
+	}
+
+	revoked, err := uc.tokenRepo.IsRevoked(ctx, claims.ID)
+	if err != nil {
+		return nil, apperrors.NewAppError(500, "检查Token状态失败", err)
+	}
+	if revoked {
+		return nil, apperrors.NewAppError(401, "Token已失效", nil)
+	}
+	if err := uc.checkUserRevocation(ctx, claims); err != nil {
+		return nil, err
+	}
+	return claims, nil
+}
+
+// checkUserRevocation 检查Token是否因用户级别的吊销而失效
+func (uc *AuthService) checkUserRevocation(ctx context.Context, claims *jwt.Claims) error {
+	// 用户级别的吊销（如重置密码），吊销时间点之前签发的Token全部失效
+	revokedAt, err := uc.tokenRepo.UserTokensRevokedAt(ctx, claims.UserID)
+	if err != nil {
+		return apperrors.NewAppError(500, "检查Token状态失败", err)
+	}
+	if !revokedAt.IsZero() && claims.IssuedAt != nil && claims.IssuedAt.Before(revokedAt) {
+		return apperrors.NewAppError(401, "Token已失效", nil)
+	}
+	return nil
+}
+
+// RevokeUserSessions 吊销用户当前全部登录会话，返回之后签发的Token不受影响
+// 吊销截止到当前这一个签发时间精度单位结束，等这一单位过去再返回，调用方随后签发的新Token才有效
+func (uc *AuthService) RevokeUserSessions(ctx context.Context, userID uint64) error {
+	revokedAt, err := uc.revokeUserSessions(ctx, userID)
+	if err != nil {
+		return err
+	}
+	time.Sleep(time.Until(revokedAt))
+	return nil
+}
 
-	// // 检查用户状态
-	// if !user.IsActive() {
-	// 	return nil, apperrors.NewAppError(403, "用户已被禁用", nil)
-	// }
+// LockOutUser 吊销用户全部登录会话，用于禁用和删除用户
+// 此时用户已无法重新登录，不需要像 RevokeUserSessions 那样等待
+func (uc *AuthService) LockOutUser(ctx context.Context, userID uint64) error {
+	_, err := uc.revokeUserSessions(ctx, userID)
+	return err
+}
 
-	// // 验证密码
-	// if !utils.CheckPassword(req.Password, user.Password) {
-	// 	return nil, apperrors.NewAppError(401, "用户名或密码错误", nil)
-	// }
+// revokeUserSessions 吊销用户截至目前签发的全部Token，包括当前这一毫秒内签发的，返回吊销时间点
+func (uc *AuthService) revokeUserSessions(ctx context.Context, userID uint64) (time.Time, error) {
+	revokedAt := time.Now().Truncate(jwt.IssuedAtPrecision).Add(jwt.IssuedAtPrecision)
+	expiresAt := time.Now().Add(max(jwt.GetTokenExpiration(), jwt.GetRefreshTokenExpiration()))
+	return revokedAt, uc.tokenRepo.RevokeUserTokens(ctx, userID, revokedAt, expiresAt)
+}
 
-	// 生成JWT Token
-	token, err := jwt.GenerateToken(1, "test")
+// issueTokens 为用户签发访问Token和刷新Token
+func (uc *AuthService) issueTokens(user *entity.User) (*dto.LoginResponse, error) {
+	role := string(permission.ParseRole(user.Role))
+	token, err := jwt.GenerateToken(user.ID, user.Name, role)
+	if err != nil {
+		return nil, apperrors.NewAppError(500, "生成Token失败", err)
+	}
+	refreshToken, err := jwt.GenerateRefreshToken(user.ID, user.Name, role)
 	if err != nil {
 		return nil, apperrors.NewAppError(500, "生成Token失败", err)
 	}
 
-	// 返回登录响应
 	return &dto.LoginResponse{
-		Token: token,
-		User: dto.UserResponse{
-			ID:     1,
-			Name:   "test",
-			Email:  "test@test.com",
-			Status: 1,
+		Token:        token,
+		RefreshToken: refreshToken,
+		User: &dto.UserResponse{
+			ID:          user.ID,
+			Name:        user.Name,
+			DisplayName: user.DisplayName,
+			Email:       user.Email,
+			Avatar:      user.Avatar,
+			TeamID:      user.TeamID,
+			Role:        role,
+			Status:      user.Status,
+
+			EmailVerified:    user.EmailVerified,
+			TwoFactorEnabled: user.TwoFactorEnabled,
 		},
-		ExpiresIn: int64(24 * time.Hour.Seconds()), // 24小时
+		ExpiresIn:        int64(jwt.GetTokenExpiration().Seconds()),
+		RefreshExpiresIn: int64(jwt.GetRefreshTokenExpiration().Seconds()),
 	}, nil
 }
+
+// twoFactorChallenge 为开启两步验证的用户签发挑战Token
+func (uc *AuthService) twoFactorChallenge(user *entity.User) (*dto.LoginResponse, error) {
+	challenge, err := jwt.GenerateTwoFactorToken(user.ID, user.Name)
+	if err != nil {
+		return nil, apperrors.NewAppError(500, "生成Token失败", err)
+	}
+	return &dto.LoginResponse{
+		TwoFactorRequired: true,
+		ChallengeToken:    challenge,
+		ExpiresIn:         int64(jwt.TwoFactorTokenExpiration.Seconds()),
+	}, nil
+}
+
+// findLoginUser 根据登录名查找用户，登录名可以是用户名或邮箱
+func (uc *AuthService) findLoginUser(ctx context.Context, login string) (*entity.User, error) {
+	login = strings.TrimSpace(login)
+	user, err := uc.userRepo.FindByName(ctx, login)
+	if err != nil || user != nil {
+		return user, err
+	}
+	if !strings.Contains(login, "@") {
+		return nil, nil
+	}
+	return uc.userRepo.FindByEmail(ctx, login)
+}
@@ -0,0 +1,325 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"FLOWGO/internal/application/dto"
+	"FLOWGO/internal/domain/entity"
+	"FLOWGO/internal/domain/repository"
+	apperrors "FLOWGO/pkg/errors"
+	"FLOWGO/pkg/rank"
+	"FLOWGO/pkg/utils"
+)
+
+// BoardService 看板服务：看板和列的配置、卡片拖拽移动
+// 查看看板需要是项目成员，配置看板需要 maintainer 及以上，移动卡片需要 member 及以上
+type BoardService struct {
+	boardRepo   repository.BoardRepository
+	taskRepo    repository.TaskRepository
+	projectRepo repository.ProjectsRepository
+}
+
+// NewBoardService 创建看板服务实例
+func NewBoardService(boardRepo repository.BoardRepository, taskRepo repository.TaskRepository, projectRepo repository.ProjectsRepository) *BoardService {
+	return &BoardService{
+		boardRepo:   boardRepo,
+		taskRepo:    taskRepo,
+		projectRepo: projectRepo,
+	}
+}
+
+// CreateBoard 创建看板
+func (s *BoardService) CreateBoard(ctx context.Context, projectID uint64, req dto.CreateBoardRequest) (*dto.BoardResponse, error) {
+	project, err := s.findProject(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := checkProjectRole(ctx, s.projectRepo, project, entity.ProjectRoleMaintainer); err != nil {
+		return nil, err
+	}
+	if err := checkProjectWritable(project); err != nil {
+		return nil, err
+	}
+	columns, err := toBoardColumns(req.Columns, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	board := entity.NewBoard(project.ID, req.Name, columns)
+	if err := s.boardRepo.Create(ctx, board); err != nil {
+		return nil, apperrors.NewAppError(500, "创建看板失败", err)
+	}
+	return toBoardResponse(board), nil
+}
+
+// ListBoards 获取项目的全部看板（不含卡片）
+func (s *BoardService) ListBoards(ctx context.Context, projectID uint64) ([]*dto.BoardResponse, error) {
+	project, err := s.findProject(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := checkProjectRole(ctx, s.projectRepo, project, entity.ProjectRoleViewer); err != nil {
+		return nil, err
+	}
+
+	boards, err := s.boardRepo.ListByProject(ctx, project.ID)
+	if err != nil {
+		return nil, apperrors.NewAppError(500, "获取看板列表失败", err)
+	}
+	list := make([]*dto.BoardResponse, 0, len(boards))
+	for _, board := range boards {
+		list = append(list, toBoardResponse(board))
+	}
+	return list, nil
+}
+
+// GetBoard 获取看板详情，包含每一列中按顺序排列的卡片
+func (s *BoardService) GetBoard(ctx context.Context, projectID, boardID uint64) (*dto.BoardDetailResponse, error) {
+	project, err := s.findProject(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := checkProjectRole(ctx, s.projectRepo, project, entity.ProjectRoleViewer); err != nil {
+		return nil, err
+	}
+	board, err := s.findBoard(ctx, project.ID, boardID)
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := make([]entity.TaskStatus, 0, len(board.Columns))
+	for _, col := range board.Columns {
+		statuses = append(statuses, col.Status)
+	}
+	tasks, err := s.taskRepo.ListByStatuses(ctx, project.ID, statuses)
+	if err != nil {
+		return nil, apperrors.NewAppError(500, "获取看板卡片失败", err)
+	}
+
+	now := time.Now()
+	cards := make(map[entity.TaskStatus][]*dto.TaskResponse, len(statuses))
+	for _, task := range tasks {
+		cards[task.Status] = append(cards[task.Status], toTaskResponse(task, now))
+	}
+	columns := make([]*dto.BoardColumnDetailResponse, 0, len(board.Columns))
+	for _, col := range board.Columns {
+		column := &dto.BoardColumnDetailResponse{
+			BoardColumnResponse: *toBoardColumnResponse(col),
+			Cards:               cards[col.Status],
+		}
+		if column.Cards == nil {
+			column.Cards = []*dto.TaskResponse{}
+		}
+		columns = append(columns, column)
+	}
+	return &dto.BoardDetailResponse{
+		ID:        board.ID,
+		ProjectID: board.ProjectID,
+		Name:      board.Name,
+		Columns:   columns,
+		CreatedAt: utils.NewTime(board.CreatedAt),
+		UpdatedAt: utils.NewTime(board.UpdatedAt),
+	}, nil
+}
+
+// UpdateBoard 修改看板名称和列，列的顺序以请求中的顺序为准
+func (s *BoardService) UpdateBoard(ctx context.Context, projectID, boardID uint64, req dto.UpdateBoardRequest) (*dto.BoardResponse, error) {
+	project, err := s.findProject(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := checkProjectRole(ctx, s.projectRepo, project, entity.ProjectRoleMaintainer); err != nil {
+		return nil, err
+	}
+	if err := checkProjectWritable(project); err != nil {
+		return nil, err
+	}
+	board, err := s.findBoard(ctx, project.ID, boardID)
+	if err != nil {
+		return nil, err
+	}
+	columns, err := toBoardColumns(req.Columns, board)
+	if err != nil {
+		return nil, err
+	}
+
+	board.Name = req.Name
+	board.SetColumns(columns)
+	if err := s.boardRepo.Update(ctx, board); err != nil {
+		return nil, apperrors.NewAppError(500, "更新看板失败", err)
+	}
+	return toBoardResponse(board), nil
+}
+
+// DeleteBoard 删除看板，看板中的任务不受影响
+func (s *BoardService) DeleteBoard(ctx context.Context, projectID, boardID uint64) error {
+	project, err := s.findProject(ctx, projectID)
+	if err != nil {
+		return err
+	}
+	if _, err := checkProjectRole(ctx, s.projectRepo, project, entity.ProjectRoleMaintainer); err != nil {
+		return err
+	}
+	if err := checkProjectWritable(project); err != nil {
+		return err
+	}
+	board, err := s.findBoard(ctx, project.ID, boardID)
+	if err != nil {
+		return err
+	}
+
+	if err := s.boardRepo.Delete(ctx, board.ID); err != nil {
+		return apperrors.NewAppError(500, "删除看板失败", err)
+	}
+	return nil
+}
+
+// MoveCard 将卡片移动到指定列中某张卡片之后，任务状态随之变为该列对应的状态
+// 移入的列达到在制品上限时拒绝移动
+func (s *BoardService) MoveCard(ctx context.Context, projectID, boardID uint64, req dto.MoveCardRequest) (*dto.TaskResponse, error) {
+	project, err := s.findProject(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := checkProjectRole(ctx, s.projectRepo, project, entity.ProjectRoleMember); err != nil {
+		return nil, err
+	}
+	if err := checkProjectWritable(project); err != nil {
+		return nil, err
+	}
+	board, err := s.findBoard(ctx, project.ID, boardID)
+	if err != nil {
+		return nil, err
+	}
+	column := board.Column(req.ColumnID)
+	if column == nil {
+		return nil, apperrors.NewAppError(400, "看板列不存在", nil)
+	}
+	task, err := s.findCard(ctx, project.ID, req.TaskID)
+	if err != nil {
+		return nil, err
+	}
+
+	// 在前一张卡片与其后一张卡片之间生成新的排序键
+	var prev string
+	if req.AfterTaskID != 0 {
+		if req.AfterTaskID == task.ID {
+			return nil, apperrors.NewAppError(400, "不能将卡片放在自身之后", nil)
+		}
+		after, err := s.findCard(ctx, project.ID, req.AfterTaskID)
+		if err != nil {
+			return nil, err
+		}
+		if after.Status != column.Status {
+			return nil, apperrors.NewAppError(400, "目标位置的卡片不在该列中", nil)
+		}
+		prev = after.Rank
+	}
+	next, err := s.taskRepo.NextRank(ctx, project.ID, column.Status, prev, task.ID)
+	if err != nil {
+		return nil, apperrors.NewAppError(500, "查询卡片位置失败", err)
+	}
+	newRank, err := rank.Between(prev, next)
+	if err != nil {
+		// 并发移动可能产生相同的排序键，刷新后重新拖拽即可
+		return nil, apperrors.NewAppError(409, "卡片位置已变化，请刷新后重试", err)
+	}
+
+	now := time.Now()
+	task.MoveTo(column.Status, newRank, now)
+	if err := s.taskRepo.Move(ctx, task); err != nil {
+		if errors.Is(err, entity.ErrWIPLimitReached) {
+			return nil, errWIPLimitReached
+		}
+		if errors.Is(err, entity.ErrTaskNotFound) {
+			return nil, apperrors.ErrNotFound
+		}
+		return nil, apperrors.NewAppError(500, "移动卡片失败", err)
+	}
+	return toTaskResponse(task, now), nil
+}
+
+func (s *BoardService) findProject(ctx context.Context, projectID uint64) (*entity.Project, error) {
+	project, err := s.projectRepo.FindByID(ctx, projectID)
+	if err != nil {
+		return nil, apperrors.NewAppError(500, "查找项目失败", err)
+	}
+	if project == nil {
+		return nil, apperrors.NewAppError(404, "项目不存在", nil)
+	}
+	return project, nil
+}
+
+// findBoard 查找项目内的看板，看板不属于该项目时同样视为不存在
+func (s *BoardService) findBoard(ctx context.Context, projectID, boardID uint64) (*entity.Board, error) {
+	board, err := s.boardRepo.FindByID(ctx, boardID)
+	if err != nil {
+		return nil, apperrors.NewAppError(500, "查找看板失败", err)
+	}
+	if board == nil || board.ProjectID != projectID {
+		return nil, apperrors.NewAppError(404, "看板不存在", nil)
+	}
+	return board, nil
+}
+
+func (s *BoardService) findCard(ctx context.Context, projectID, taskID uint64) (*entity.Task, error) {
+	task, err := s.taskRepo.FindByID(ctx, taskID)
+	if err != nil {
+		return nil, apperrors.NewAppError(500, "查找任务失败", err)
+	}
+	if task == nil || task.ProjectID != projectID {
+		return nil, apperrors.NewAppError(404, "任务不存在", nil)
+	}
+	return task, nil
+}
+
+// toBoardColumns 校验并转换请求中的列，board 不为空时列ID必须属于该看板
+func toBoardColumns(reqs []*dto.BoardColumnRequest, board *entity.Board) ([]*entity.BoardColumn, error) {
+	columns := make([]*entity.BoardColumn, 0, len(reqs))
+	statuses := make(map[entity.TaskStatus]bool, len(reqs))
+	for _, req := range reqs {
+		status := entity.TaskStatus(req.Status)
+		if statuses[status] {
+			return nil, apperrors.NewAppError(400, "同一看板中每个状态只能对应一列", nil)
+		}
+		statuses[status] = true
+
+		if req.ID != 0 && (board == nil || board.Column(req.ID) == nil) {
+			return nil, apperrors.NewAppError(400, "看板列不存在", nil)
+		}
+		columns = append(columns, &entity.BoardColumn{
+			ID:       req.ID,
+			Name:     req.Name,
+			Status:   status,
+			WIPLimit: req.WIPLimit,
+		})
+	}
+	return columns, nil
+}
+
+func toBoardColumnResponse(col *entity.BoardColumn) *dto.BoardColumnResponse {
+	return &dto.BoardColumnResponse{
+		ID:       col.ID,
+		Name:     col.Name,
+		Status:   string(col.Status),
+		Position: col.Position,
+		WIPLimit: col.WIPLimit,
+	}
+}
+
+func toBoardResponse(board *entity.Board) *dto.BoardResponse {
+	columns := make([]*dto.BoardColumnResponse, 0, len(board.Columns))
+	for _, col := range board.Columns {
+		columns = append(columns, toBoardColumnResponse(col))
+	}
+	return &dto.BoardResponse{
+		ID:        board.ID,
+		ProjectID: board.ProjectID,
+		Name:      board.Name,
+		Columns:   columns,
+		CreatedAt: utils.NewTime(board.CreatedAt),
+		UpdatedAt: utils.NewTime(board.UpdatedAt),
+	}
+}
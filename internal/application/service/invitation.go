@@ -0,0 +1,402 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/url"
+	"strings"
+	"time"
+
+	"FLOWGO/internal/application/dto"
+	"FLOWGO/internal/domain/entity"
+	"FLOWGO/internal/domain/permission"
+	"FLOWGO/internal/domain/repository"
+	"FLOWGO/pkg/contextutil"
+	apperrors "FLOWGO/pkg/errors"
+	"FLOWGO/pkg/mailer"
+	"FLOWGO/pkg/utils"
+)
+
+const invitationTTL = 7 * 24 * time.Hour
+
+// InvitationService 邀请服务：管理员或项目负责人通过邮件邀请新用户，受邀人设置密码后加入团队和项目
+type InvitationService struct {
+	invitationRepo repository.InvitationRepository
+	userRepo       repository.UserRepository
+	teamRepo       repository.TeamRepository
+	projectRepo    repository.ProjectsRepository
+	authService    *AuthService
+	mailer         mailer.Mailer
+	publicURL      string
+}
+
+// NewInvitationService 创建邀请服务实例，publicURL 为邮件链接指向的前端地址
+func NewInvitationService(
+	invitationRepo repository.InvitationRepository,
+	userRepo repository.UserRepository,
+	teamRepo repository.TeamRepository,
+	projectRepo repository.ProjectsRepository,
+	authService *AuthService,
+	mailer mailer.Mailer,
+	publicURL string,
+) *InvitationService {
+	return &InvitationService{
+		invitationRepo: invitationRepo,
+		userRepo:       userRepo,
+		teamRepo:       teamRepo,
+		projectRepo:    projectRepo,
+		authService:    authService,
+		mailer:         mailer,
+		publicURL:      strings.TrimRight(publicURL, "/"),
+	}
+}
+
+// CreateInvitation 创建邀请并发送邮件，返回的邀请中包含链接
+// 管理员可以邀请任意角色；项目负责人只能邀请 member、guest 加入自己的项目
+func (s *InvitationService) CreateInvitation(ctx context.Context, req dto.CreateInvitationRequest) (*dto.InvitationResponse, error) {
+	userID, err := contextutil.GetUserID(ctx)
+	if err != nil {
+		return nil, apperrors.ErrUnauthorized
+	}
+	if contextutil.GetScopes(ctx) != nil {
+		return nil, apperrors.ErrForbidden
+	}
+
+	invitation := &entity.Invitation{
+		Email:       strings.TrimSpace(req.Email),
+		Role:        string(permission.ParseRole(req.Role)),
+		TeamID:      req.TeamID,
+		ProjectID:   req.ProjectID,
+		ProjectRole: entity.ProjectRoleMember,
+		InvitedBy:   userID,
+	}
+	if req.TeamID != 0 {
+		invitation.TeamRole = entity.TeamRoleMember
+		if req.TeamRole != "" {
+			invitation.TeamRole = entity.TeamRole(req.TeamRole)
+		}
+	}
+	if req.ProjectID == 0 {
+		invitation.ProjectRole = ""
+	} else if req.ProjectRole != "" {
+		invitation.ProjectRole = entity.ProjectRole(req.ProjectRole)
+	}
+
+	if err := s.checkInviter(ctx, userID, invitation); err != nil {
+		return nil, err
+	}
+
+	exists, err := s.userRepo.ExistsByEmail(ctx, invitation.Email)
+	if err != nil {
+		return nil, apperrors.NewAppError(500, "检查邮箱失败", err)
+	}
+	if exists {
+		return nil, apperrors.NewAppError(400, "该邮箱已注册", nil)
+	}
+	pending, err := s.invitationRepo.FindPendingByEmail(ctx, invitation.Email, time.Now())
+	if err != nil {
+		return nil, apperrors.NewAppError(500, "查询邀请失败", err)
+	}
+	if pending != nil {
+		return nil, apperrors.NewAppError(409, "该邮箱已有待接受的邀请，可以重新发送", nil)
+	}
+
+	token, err := s.renew(invitation)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.invitationRepo.Create(ctx, invitation); err != nil {
+		return nil, apperrors.NewAppError(500, "创建邀请失败", err)
+	}
+
+	return s.deliver(ctx, invitation, token), nil
+}
+
+// ListInvitations 分页获取邀请列表
+func (s *InvitationService) ListInvitations(ctx context.Context, req dto.InvitationListRequest) (*dto.InvitationListResponse, error) {
+	now := time.Now()
+	invitations, total, err := s.invitationRepo.List(ctx, entity.InvitationStatus(req.Status), now, req.Page, req.GetPageSize())
+	if err != nil {
+		return nil, apperrors.NewAppError(500, "查询邀请列表失败", err)
+	}
+
+	list := make([]*dto.InvitationResponse, 0, len(invitations))
+	for _, invitation := range invitations {
+		list = append(list, toInvitationResponse(invitation, now))
+	}
+	return &dto.InvitationListResponse{
+		List: list,
+		Page: dto.PageResponse{
+			Page:     req.Page,
+			PageSize: req.GetPageSize(),
+			Total:    total,
+		},
+	}, nil
+}
+
+// ResendInvitation 重新生成链接并发送邮件，旧链接失效，已过期的邀请重新计算有效期
+func (s *InvitationService) ResendInvitation(ctx context.Context, id uint64) (*dto.InvitationResponse, error) {
+	invitation, err := s.findInvitation(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	status := invitation.Status(time.Now())
+	if status != entity.InvitationPending && status != entity.InvitationExpired {
+		return nil, apperrors.NewAppError(400, "邀请已被接受或已撤销", nil)
+	}
+
+	token, err := s.renew(invitation)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.invitationRepo.Update(ctx, invitation); err != nil {
+		return nil, apperrors.NewAppError(500, "更新邀请失败", err)
+	}
+	return s.deliver(ctx, invitation, token), nil
+}
+
+// RevokeInvitation 撤销尚未接受的邀请
+func (s *InvitationService) RevokeInvitation(ctx context.Context, id uint64) (*dto.InvitationResponse, error) {
+	invitation, err := s.findInvitation(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	if status := invitation.Status(now); status == entity.InvitationAccepted || status == entity.InvitationRevoked {
+		return nil, apperrors.NewAppError(400, "邀请已被接受或已撤销", nil)
+	}
+
+	invitation.Revoke(now)
+	if err := s.invitationRepo.Update(ctx, invitation); err != nil {
+		return nil, apperrors.NewAppError(500, "撤销邀请失败", err)
+	}
+	return toInvitationResponse(invitation, now), nil
+}
+
+// PreviewInvitation 根据链接令牌获取邀请信息，供接受页面展示
+func (s *InvitationService) PreviewInvitation(ctx context.Context, token string) (*dto.InvitationPreviewResponse, error) {
+	invitation, err := s.findPending(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &dto.InvitationPreviewResponse{
+		Email:     invitation.Email,
+		ExpiresAt: utils.NewTime(invitation.ExpiresAt),
+	}
+	if invitation.TeamID != 0 {
+		if team, err := s.teamRepo.FindByID(ctx, invitation.TeamID); err == nil && team != nil {
+			resp.TeamName = team.Name
+		}
+	}
+	if invitation.ProjectID != 0 {
+		if project, err := s.projectRepo.FindByID(ctx, invitation.ProjectID); err == nil && project != nil {
+			resp.ProjectName = project.Name
+		}
+	}
+	return resp, nil
+}
+
+// AcceptInvitation 接受邀请：创建用户、加入邀请中的团队和项目，并直接登录
+func (s *InvitationService) AcceptInvitation(ctx context.Context, req dto.AcceptInvitationRequest) (*dto.LoginResponse, error) {
+	invitation, err := s.findPending(ctx, req.Token)
+	if err != nil {
+		return nil, err
+	}
+
+	exists, err := s.userRepo.ExistsByEmail(ctx, invitation.Email)
+	if err != nil {
+		return nil, apperrors.NewAppError(500, "检查邮箱失败", err)
+	}
+	if exists {
+		return nil, apperrors.NewAppError(409, "该邮箱已注册", nil)
+	}
+	exists, err = s.userRepo.ExistsByName(ctx, req.Name)
+	if err != nil {
+		return nil, apperrors.NewAppError(500, "检查用户名失败", err)
+	}
+	if exists {
+		return nil, apperrors.NewAppError(400, "用户名已存在", nil)
+	}
+
+	hashedPassword, err := utils.HashPassword(req.Password)
+	if err != nil {
+		return nil, apperrors.NewAppError(500, "密码加密失败", err)
+	}
+	now := time.Now()
+	// ID 由数据库分配
+	user := &entity.User{
+		Name:     req.Name,
+		Email:    invitation.Email,
+		Password: hashedPassword,
+		Status:   entity.UserStatusActive,
+		Role:     string(permission.ParseRole(invitation.Role)),
+	}
+	// 能打开邀请邮件中的链接即证明拥有该邮箱
+	user.MarkEmailVerified(now)
+
+	teamID, projectID, err := s.joinTargets(ctx, invitation)
+	if err != nil {
+		return nil, err
+	}
+	// 创建用户、占用邀请和加入团队、项目在同一个事务中完成，同一链接只能创建一个用户
+	ok, err := s.invitationRepo.Accept(ctx, invitation, user, teamID, projectID, now)
+	if err != nil {
+		return nil, apperrors.NewAppError(500, "接受邀请失败", err)
+	}
+	if !ok {
+		return nil, apperrors.NewAppError(400, "邀请链接无效或已过期", nil)
+	}
+
+	return s.authService.issueTokens(user)
+}
+
+// joinTargets 确定新用户要加入的团队和项目，团队或项目已被删除、项目已归档时跳过（返回 0）
+func (s *InvitationService) joinTargets(ctx context.Context, invitation *entity.Invitation) (uint64, uint64, error) {
+	var teamID, projectID uint64
+	if invitation.TeamID != 0 {
+		team, err := s.teamRepo.FindByID(ctx, invitation.TeamID)
+		if err != nil {
+			return 0, 0, apperrors.NewAppError(500, "查询团队失败", err)
+		}
+		if team != nil {
+			teamID = team.ID
+		}
+	}
+	if invitation.ProjectID != 0 {
+		project, err := s.projectRepo.FindByID(ctx, invitation.ProjectID)
+		if err != nil {
+			return 0, 0, apperrors.NewAppError(500, "查询项目失败", err)
+		}
+		if project != nil && !project.IsArchived() {
+			projectID = project.ID
+		}
+	}
+	return teamID, projectID, nil
+}
+
+// checkInviter 校验当前用户能否发出该邀请
+func (s *InvitationService) checkInviter(ctx context.Context, userID uint64, invitation *entity.Invitation) error {
+	isAdmin := permission.ParseRole(contextutil.GetRole(ctx)) == permission.RoleAdmin
+
+	if invitation.ProjectID != 0 {
+		project, err := s.projectRepo.FindByID(ctx, invitation.ProjectID)
+		if err != nil {
+			return apperrors.NewAppError(500, "查询项目失败", err)
+		}
+		if project == nil {
+			return apperrors.NewAppError(400, "项目不存在", nil)
+		}
+		if err := checkProjectWritable(project); err != nil {
+			return err
+		}
+		if !isAdmin && project.OwnerID != userID {
+			role, err := s.projectRepo.FindUserRole(ctx, project.ID, userID)
+			if err != nil {
+				return apperrors.NewAppError(500, "查询项目角色失败", err)
+			}
+			if role != entity.ProjectRoleOwner {
+				return apperrors.ErrForbidden
+			}
+		}
+	} else if !isAdmin {
+		// 非管理员只能邀请用户加入自己负责的项目
+		return apperrors.ErrForbidden
+	}
+
+	if invitation.TeamID != 0 {
+		team, err := s.teamRepo.FindByID(ctx, invitation.TeamID)
+		if err != nil {
+			return apperrors.NewAppError(500, "查询团队失败", err)
+		}
+		if team == nil {
+			return apperrors.NewAppError(400, "团队不存在", nil)
+		}
+		if !isAdmin && !team.IsOwner(userID) {
+			role, err := s.teamRepo.FindMemberRole(ctx, team.ID, userID)
+			if err != nil {
+				return apperrors.NewAppError(500, "查询团队成员失败", err)
+			}
+			if role != entity.TeamRoleLead {
+				return apperrors.ErrForbidden
+			}
+		}
+	}
+
+	if !isAdmin {
+		role := permission.Role(invitation.Role)
+		if role != permission.RoleMember && role != permission.RoleGuest {
+			return apperrors.ErrForbidden
+		}
+	}
+	return nil
+}
+
+// renew 为邀请生成新的链接令牌，数据库只保存摘要，返回明文
+func (s *InvitationService) renew(invitation *entity.Invitation) (string, error) {
+	plain, err := utils.GenerateRandomToken(32)
+	if err != nil {
+		return "", apperrors.NewAppError(500, "生成邀请链接失败", err)
+	}
+	invitation.Renew(utils.HashToken(plain), time.Now().Add(invitationTTL))
+	return plain, nil
+}
+
+// deliver 发送邀请邮件并返回带链接的邀请，发送失败只记录日志，邀请人仍可以手动转发链接
+func (s *InvitationService) deliver(ctx context.Context, invitation *entity.Invitation, token string) *dto.InvitationResponse {
+	link := s.publicURL + "/accept-invitation?token=" + url.QueryEscape(token)
+	body := fmt.Sprintf("您好：\n\n您被邀请加入 FlowGo，请在 %d 天内打开以下链接设置密码并完成注册：\n%s\n\n如果您不认识邀请人，请忽略此邮件。\n",
+		int(invitationTTL.Hours()/24), link)
+	msg := mailer.Message{To: []string{invitation.Email}, Subject: "FlowGo 邀请", Body: body}
+	if err := s.mailer.Send(ctx, msg); err != nil {
+		log.Printf("Failed to send invitation mail to %s: %v", invitation.Email, err)
+	}
+
+	resp := toInvitationResponse(invitation, time.Now())
+	resp.Link = link
+	return resp
+}
+
+func (s *InvitationService) findInvitation(ctx context.Context, id uint64) (*entity.Invitation, error) {
+	invitation, err := s.invitationRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, apperrors.NewAppError(500, "查询邀请失败", err)
+	}
+	if invitation == nil {
+		return nil, apperrors.NewAppError(404, "邀请不存在", nil)
+	}
+	return invitation, nil
+}
+
+// findPending 根据链接令牌查找仍有效的邀请
+func (s *InvitationService) findPending(ctx context.Context, token string) (*entity.Invitation, error) {
+	invitation, err := s.invitationRepo.FindByTokenHash(ctx, utils.HashToken(token))
+	if err != nil {
+		return nil, apperrors.NewAppError(500, "查询邀请失败", err)
+	}
+	if invitation == nil || invitation.Status(time.Now()) != entity.InvitationPending {
+		return nil, apperrors.NewAppError(400, "邀请链接无效或已过期", nil)
+	}
+	return invitation, nil
+}
+
+func toInvitationResponse(invitation *entity.Invitation, now time.Time) *dto.InvitationResponse {
+	resp := &dto.InvitationResponse{
+		ID:          invitation.ID,
+		Email:       invitation.Email,
+		Role:        invitation.Role,
+		TeamID:      invitation.TeamID,
+		TeamRole:    string(invitation.TeamRole),
+		ProjectID:   invitation.ProjectID,
+		ProjectRole: string(invitation.ProjectRole),
+		InvitedBy:   invitation.InvitedBy,
+		Status:      string(invitation.Status(now)),
+		ExpiresAt:   utils.NewTime(invitation.ExpiresAt),
+		CreatedAt:   utils.NewTime(invitation.CreatedAt),
+	}
+	if invitation.AcceptedAt != nil {
+		resp.AcceptedAt = utils.NewTime(*invitation.AcceptedAt)
+	}
+	return resp
+}
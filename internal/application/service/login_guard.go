@@ -0,0 +1,171 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"FLOWGO/internal/domain/entity"
+	"FLOWGO/internal/domain/repository"
+	apperrors "FLOWGO/pkg/errors"
+)
+
+// LoginPolicy 登录防暴力破解策略
+type LoginPolicy struct {
+	Window          time.Duration // 失败计数的滑动窗口
+	BackoffAfter    int           // 同一账号失败次数达到该值后开始指数退避
+	MaxBackoff      time.Duration // 退避等待时间上限
+	LockoutAfter    int           // 同一账号失败次数达到该值后临时锁定
+	LockoutDuration time.Duration // 锁定时长
+	MaxIPAttempts   int           // 同一IP失败次数达到该值后开始指数退避
+}
+
+// LoginGuard 登录防护：按账号和客户端IP统计失败次数，实施退避和临时锁定
+// 账号锁定返回 423，退避和IP限流返回 429，均带有重试等待时间
+type LoginGuard struct {
+	attempts repository.LoginAttemptRepository
+	events   repository.SecurityEventRepository
+	policy   LoginPolicy
+}
+
+// NewLoginGuard 创建登录防护实例
+func NewLoginGuard(
+	attempts repository.LoginAttemptRepository,
+	events repository.SecurityEventRepository,
+	policy LoginPolicy,
+) *LoginGuard {
+	return &LoginGuard{
+		attempts: attempts,
+		events:   events,
+		policy:   policy,
+	}
+}
+
+// Check 在校验凭据之前检查账号和IP是否允许尝试登录
+func (g *LoginGuard) Check(ctx context.Context, username, ip string) error {
+	now := time.Now()
+	user := userAttemptKey(username)
+
+	until, err := g.attempts.BlockedUntil(ctx, lockKey(user))
+	if err != nil {
+		return apperrors.NewAppError(500, "检查登录状态失败", err)
+	}
+	if until.After(now) {
+		return apperrors.NewRetryAfterError(423, "账号已被临时锁定，请稍后再试", until.Sub(now))
+	}
+
+	for _, key := range []string{user, ipAttemptKey(ip)} {
+		until, err := g.attempts.BlockedUntil(ctx, key)
+		if err != nil {
+			return apperrors.NewAppError(500, "检查登录状态失败", err)
+		}
+		if until.After(now) {
+			return apperrors.NewRetryAfterError(429, "登录尝试过于频繁，请稍后再试", until.Sub(now))
+		}
+	}
+	return nil
+}
+
+// Fail 记录一次失败的登录，userID 为0表示账号不存在
+// 计数失败只记录日志，不影响本次登录的返回结果
+func (g *LoginGuard) Fail(ctx context.Context, username string, userID uint64, ip string) {
+	now := time.Now()
+	user := userAttemptKey(username)
+
+	count, err := g.attempts.AddFailure(ctx, user, now, g.policy.Window)
+	if err != nil {
+		log.Printf("Warning: failed to record login failure for %s: %v", user, err)
+	} else {
+		switch {
+		case count >= g.policy.LockoutAfter:
+			g.block(ctx, lockKey(user), now.Add(g.policy.LockoutDuration))
+			g.record(ctx, &entity.SecurityEvent{
+				Type:     entity.SecurityEventAccountLocked,
+				UserID:   userID,
+				Username: username,
+				IP:       ip,
+				Detail:   fmt.Sprintf("%d failed attempts, locked for %s", count, g.policy.LockoutDuration),
+			})
+		case count >= g.policy.BackoffAfter:
+			g.block(ctx, user, now.Add(g.backoff(count-g.policy.BackoffAfter)))
+		}
+	}
+
+	if ip == "" {
+		return
+	}
+	count, err = g.attempts.AddFailure(ctx, ipAttemptKey(ip), now, g.policy.Window)
+	if err != nil {
+		log.Printf("Warning: failed to record login failure for ip %s: %v", ip, err)
+		return
+	}
+	if count >= g.policy.MaxIPAttempts {
+		g.block(ctx, ipAttemptKey(ip), now.Add(g.backoff(count-g.policy.MaxIPAttempts)))
+		// 只在首次触发时记录，避免持续攻击时产生大量事件
+		if count == g.policy.MaxIPAttempts {
+			g.record(ctx, &entity.SecurityEvent{
+				Type:     entity.SecurityEventIPThrottled,
+				Username: username,
+				IP:       ip,
+				Detail:   fmt.Sprintf("%d failed attempts within %s", count, g.policy.Window),
+			})
+		}
+	}
+}
+
+// Succeed 登录成功后清除账号的失败记录，IP 维度的计数保持不变
+func (g *LoginGuard) Succeed(ctx context.Context, username string) {
+	user := userAttemptKey(username)
+	if err := g.attempts.ClearFailures(ctx, user); err != nil {
+		log.Printf("Warning: failed to clear login failures for %s: %v", user, err)
+	}
+	if err := g.attempts.Unblock(ctx, user); err != nil {
+		log.Printf("Warning: failed to clear login backoff for %s: %v", user, err)
+	}
+}
+
+// Unlock 解除账号锁定并清除失败记录
+func (g *LoginGuard) Unlock(ctx context.Context, username string) error {
+	user := userAttemptKey(username)
+	if err := g.attempts.ClearFailures(ctx, user); err != nil {
+		return err
+	}
+	if err := g.attempts.Unblock(ctx, user); err != nil {
+		return err
+	}
+	return g.attempts.Unblock(ctx, lockKey(user))
+}
+
+// backoff 计算第 n 次（从0开始）退避的等待时间：1s, 2s, 4s ... 不超过上限
+func (g *LoginGuard) backoff(n int) time.Duration {
+	if n > 30 {
+		return g.policy.MaxBackoff
+	}
+	return min(time.Second<<n, g.policy.MaxBackoff)
+}
+
+func (g *LoginGuard) block(ctx context.Context, key string, until time.Time) {
+	if err := g.attempts.Block(ctx, key, until); err != nil {
+		log.Printf("Warning: failed to block %s: %v", key, err)
+	}
+}
+
+func (g *LoginGuard) record(ctx context.Context, event *entity.SecurityEvent) {
+	if err := g.events.Create(ctx, event); err != nil {
+		log.Printf("Warning: failed to record security event %s: %v", event.Type, err)
+	}
+}
+
+func userAttemptKey(username string) string {
+	return "user:" + strings.ToLower(strings.TrimSpace(username))
+}
+
+func ipAttemptKey(ip string) string {
+	return "ip:" + ip
+}
+
+func lockKey(key string) string {
+	return "lock:" + key
+}
@@ -0,0 +1,215 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"FLOWGO/internal/application/dto"
+	"FLOWGO/internal/domain/entity"
+	"FLOWGO/internal/domain/repository"
+	apperrors "FLOWGO/pkg/errors"
+	"FLOWGO/pkg/utils"
+)
+
+// MilestoneService 里程碑服务：项目内里程碑的增删改查和排序
+// 查看需要是项目成员（含 viewer），修改需要 maintainer 及以上
+type MilestoneService struct {
+	milestoneRepo repository.MilestoneRepository
+	projectRepo   repository.ProjectsRepository
+}
+
+// NewMilestoneService 创建里程碑服务实例
+func NewMilestoneService(milestoneRepo repository.MilestoneRepository, projectRepo repository.ProjectsRepository) *MilestoneService {
+	return &MilestoneService{
+		milestoneRepo: milestoneRepo,
+		projectRepo:   projectRepo,
+	}
+}
+
+// CreateMilestone 在项目中创建里程碑，排在现有里程碑之后
+func (s *MilestoneService) CreateMilestone(ctx context.Context, projectID uint64, req dto.CreateMilestoneRequest) (*dto.MilestoneResponse, error) {
+	if req.DueDate.IsZero() {
+		return nil, apperrors.NewAppError(400, "请设置里程碑的目标日期", nil)
+	}
+	project, err := s.findWritableProject(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	milestone := entity.NewMilestone(project.ID, req.Name, req.Description, req.DueDate.Time)
+	if err := s.milestoneRepo.Create(ctx, milestone); err != nil {
+		return nil, apperrors.NewAppError(500, "创建里程碑失败", err)
+	}
+	return toMilestoneResponse(milestone, time.Now()), nil
+}
+
+// GetMilestone 获取里程碑详情
+func (s *MilestoneService) GetMilestone(ctx context.Context, projectID, milestoneID uint64) (*dto.MilestoneResponse, error) {
+	project, err := s.findReadableProject(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+	milestone, err := s.findMilestone(ctx, project.ID, milestoneID)
+	if err != nil {
+		return nil, err
+	}
+	return toMilestoneResponse(milestone, time.Now()), nil
+}
+
+// ListMilestones 获取项目的全部里程碑，按顺序排列
+func (s *MilestoneService) ListMilestones(ctx context.Context, projectID uint64) ([]*dto.MilestoneResponse, error) {
+	project, err := s.findReadableProject(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+	milestones, err := s.milestoneRepo.ListByProject(ctx, project.ID)
+	if err != nil {
+		return nil, apperrors.NewAppError(500, "获取里程碑列表失败", err)
+	}
+	now := time.Now()
+	list := make([]*dto.MilestoneResponse, len(milestones))
+	for i, milestone := range milestones {
+		list[i] = toMilestoneResponse(milestone, now)
+	}
+	return list, nil
+}
+
+// UpdateMilestone 更新里程碑的名称、描述、目标日期和状态
+func (s *MilestoneService) UpdateMilestone(ctx context.Context, projectID, milestoneID uint64, req dto.UpdateMilestoneRequest) (*dto.MilestoneResponse, error) {
+	if req.DueDate.IsZero() {
+		return nil, apperrors.NewAppError(400, "请设置里程碑的目标日期", nil)
+	}
+	project, err := s.findWritableProject(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+	milestone, err := s.findMilestone(ctx, project.ID, milestoneID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	milestone.UpdateBasicInfo(req.Name, req.Description, req.DueDate.Time)
+	milestone.SetState(entity.MilestoneState(req.State), now)
+	if err := s.milestoneRepo.Update(ctx, milestone); err != nil {
+		return nil, apperrors.NewAppError(500, "更新里程碑失败", err)
+	}
+	return toMilestoneResponse(milestone, now), nil
+}
+
+// DeleteMilestone 删除里程碑
+func (s *MilestoneService) DeleteMilestone(ctx context.Context, projectID, milestoneID uint64) error {
+	project, err := s.findWritableProject(ctx, projectID)
+	if err != nil {
+		return err
+	}
+	milestone, err := s.findMilestone(ctx, project.ID, milestoneID)
+	if err != nil {
+		return err
+	}
+	if err := s.milestoneRepo.Delete(ctx, milestone.ID); err != nil {
+		return apperrors.NewAppError(500, "删除里程碑失败", err)
+	}
+	return nil
+}
+
+// ReorderMilestones 调整里程碑顺序，ids 必须恰好包含项目的全部里程碑
+func (s *MilestoneService) ReorderMilestones(ctx context.Context, projectID uint64, req dto.ReorderMilestonesRequest) ([]*dto.MilestoneResponse, error) {
+	project, err := s.findWritableProject(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+	milestones, err := s.milestoneRepo.ListByProject(ctx, project.ID)
+	if err != nil {
+		return nil, apperrors.NewAppError(500, "获取里程碑列表失败", err)
+	}
+
+	existing := make(map[uint64]bool, len(milestones))
+	for _, milestone := range milestones {
+		existing[milestone.ID] = true
+	}
+	seen := make(map[uint64]bool, len(req.IDs))
+	for _, id := range req.IDs {
+		if !existing[id] || seen[id] {
+			return nil, apperrors.NewAppError(400, "里程碑列表与项目不一致", nil)
+		}
+		seen[id] = true
+	}
+	if len(seen) != len(existing) {
+		return nil, apperrors.NewAppError(400, "里程碑列表与项目不一致", nil)
+	}
+
+	if err := s.milestoneRepo.Reorder(ctx, project.ID, req.IDs); err != nil {
+		return nil, apperrors.NewAppError(500, "调整里程碑顺序失败", err)
+	}
+	return s.ListMilestones(ctx, project.ID)
+}
+
+// findReadableProject 查找项目并校验当前用户是项目成员
+func (s *MilestoneService) findReadableProject(ctx context.Context, projectID uint64) (*entity.Project, error) {
+	project, err := s.findProject(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := checkProjectRole(ctx, s.projectRepo, project, entity.ProjectRoleViewer); err != nil {
+		return nil, err
+	}
+	return project, nil
+}
+
+// findWritableProject 查找项目并校验当前用户可以修改里程碑，已归档的项目不能修改
+func (s *MilestoneService) findWritableProject(ctx context.Context, projectID uint64) (*entity.Project, error) {
+	project, err := s.findProject(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := checkProjectRole(ctx, s.projectRepo, project, entity.ProjectRoleMaintainer); err != nil {
+		return nil, err
+	}
+	if err := checkProjectWritable(project); err != nil {
+		return nil, err
+	}
+	return project, nil
+}
+
+func (s *MilestoneService) findProject(ctx context.Context, projectID uint64) (*entity.Project, error) {
+	project, err := s.projectRepo.FindByID(ctx, projectID)
+	if err != nil {
+		return nil, apperrors.NewAppError(500, "查找项目失败", err)
+	}
+	if project == nil {
+		return nil, apperrors.NewAppError(404, "项目不存在", nil)
+	}
+	return project, nil
+}
+
+// findMilestone 查找项目内的里程碑，里程碑不属于该项目时同样视为不存在
+func (s *MilestoneService) findMilestone(ctx context.Context, projectID, milestoneID uint64) (*entity.Milestone, error) {
+	milestone, err := s.milestoneRepo.FindByID(ctx, milestoneID)
+	if err != nil {
+		return nil, apperrors.NewAppError(500, "查找里程碑失败", err)
+	}
+	if milestone == nil || milestone.ProjectID != projectID {
+		return nil, apperrors.NewAppError(404, "里程碑不存在", nil)
+	}
+	return milestone, nil
+}
+
+func toMilestoneResponse(milestone *entity.Milestone, now time.Time) *dto.MilestoneResponse {
+	resp := &dto.MilestoneResponse{
+		ID:          milestone.ID,
+		ProjectID:   milestone.ProjectID,
+		Name:        milestone.Name,
+		Description: milestone.Description,
+		DueDate:     utils.NewTime(milestone.DueDate),
+		State:       string(milestone.State),
+		Position:    milestone.Position,
+		Overdue:     milestone.IsOverdue(now),
+		CreatedAt:   utils.NewTime(milestone.CreatedAt),
+		UpdatedAt:   utils.NewTime(milestone.UpdatedAt),
+	}
+	if milestone.ClosedAt != nil {
+		resp.ClosedAt = utils.NewTime(*milestone.ClosedAt)
+	}
+	return resp
+}
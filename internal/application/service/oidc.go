@@ -0,0 +1,322 @@
+package service
+
+import (
+	"context"
+	"crypto/subtle"
+	"fmt"
+	"log"
+	"net/url"
+	"strings"
+	"time"
+
+	"FLOWGO/internal/application/dto"
+	"FLOWGO/internal/domain/entity"
+	"FLOWGO/internal/domain/permission"
+	"FLOWGO/internal/domain/repository"
+	apperrors "FLOWGO/pkg/errors"
+	"FLOWGO/pkg/oidc"
+	"FLOWGO/pkg/utils"
+)
+
+const (
+	// oidcStateTTL 从跳转到身份提供方到回调之间允许的最长时间
+	oidcStateTTL = 10 * time.Minute
+
+	// oidcResultTTL 回调后前端用一次性登录代码换取 Token 的有效期
+	oidcResultTTL = time.Minute
+)
+
+// OIDCProvider 单点登录提供方及其本地策略
+type OIDCProvider struct {
+	Provider    *oidc.Provider
+	DisplayName string
+	DefaultRole permission.Role // 首次登录自动创建用户时的角色
+	TrustEmail  bool            // 提供方不返回 email_verified 时是否视为已验证
+}
+
+// OIDCService 单点登录服务：授权码流程（PKCE）、身份绑定和用户自动创建
+type OIDCService struct {
+	providers    map[string]*OIDCProvider
+	names        []string
+	userRepo     repository.UserRepository
+	identityRepo repository.UserIdentityRepository
+	stateRepo    repository.OIDCStateRepository
+	authService  *AuthService
+	redirectURL  string // 登录成功后携带一次性登录代码跳转的前端地址
+}
+
+// NewOIDCService 创建单点登录服务实例
+func NewOIDCService(
+	providers []*OIDCProvider,
+	userRepo repository.UserRepository,
+	identityRepo repository.UserIdentityRepository,
+	stateRepo repository.OIDCStateRepository,
+	authService *AuthService,
+	redirectURL string,
+) (*OIDCService, error) {
+	s := &OIDCService{
+		providers:    make(map[string]*OIDCProvider, len(providers)),
+		userRepo:     userRepo,
+		identityRepo: identityRepo,
+		stateRepo:    stateRepo,
+		authService:  authService,
+		redirectURL:  redirectURL,
+	}
+	for _, p := range providers {
+		name := p.Provider.Name()
+		if name == "" {
+			return nil, fmt.Errorf("oidc provider name is required")
+		}
+		if _, ok := s.providers[name]; ok {
+			return nil, fmt.Errorf("duplicate oidc provider: %s", name)
+		}
+		if !p.DefaultRole.IsValid() {
+			return nil, fmt.Errorf("oidc provider %s: invalid default role %q", name, p.DefaultRole)
+		}
+		s.providers[name] = p
+		s.names = append(s.names, name)
+	}
+	return s, nil
+}
+
+// ListProviders 获取已启用的提供方
+func (s *OIDCService) ListProviders() []*dto.OIDCProviderResponse {
+	list := make([]*dto.OIDCProviderResponse, 0, len(s.names))
+	for _, name := range s.names {
+		list = append(list, &dto.OIDCProviderResponse{
+			Name:        name,
+			DisplayName: s.providers[name].DisplayName,
+			LoginURL:    "/api/v1/auth/oidc/" + name + "/login",
+		})
+	}
+	return list
+}
+
+// AuthorizationURL 生成跳转到身份提供方的授权链接，并保存 state、nonce 和 PKCE 校验值
+// 返回的 state 需要由调用方写入浏览器 Cookie，回调时与参数中的 state 比对
+func (s *OIDCService) AuthorizationURL(ctx context.Context, name string) (authURL, state string, err error) {
+	p, ok := s.providers[name]
+	if !ok {
+		return "", "", apperrors.ErrNotFound
+	}
+
+	state, err = oidc.NewState()
+	if err != nil {
+		return "", "", apperrors.NewAppError(500, "生成登录状态失败", err)
+	}
+	nonce, err := oidc.NewState()
+	if err != nil {
+		return "", "", apperrors.NewAppError(500, "生成登录状态失败", err)
+	}
+	verifier, err := oidc.NewCodeVerifier()
+	if err != nil {
+		return "", "", apperrors.NewAppError(500, "生成登录状态失败", err)
+	}
+
+	authURL, err = p.Provider.AuthCodeURL(ctx, state, nonce, oidc.CodeChallengeS256(verifier))
+	if err != nil {
+		return "", "", apperrors.NewAppError(502, "身份提供方不可用", err)
+	}
+
+	data := &entity.OIDCLoginState{Provider: name, CodeVerifier: verifier, Nonce: nonce}
+	if err := s.stateRepo.Save(ctx, state, data, oidcStateTTL); err != nil {
+		return "", "", apperrors.NewAppError(500, "保存登录状态失败", err)
+	}
+	return authURL, state, nil
+}
+
+// Callback 处理身份提供方回调：校验 state、换取并校验 ID Token、找到或创建本地用户
+// cookieState 为发起登录时写入浏览器 Cookie 的 state，与参数不一致时拒绝，防止登录 CSRF
+// 成功时返回携带一次性登录代码的前端地址，Token 不出现在回调响应和地址中
+func (s *OIDCService) Callback(ctx context.Context, name string, req dto.OIDCCallbackRequest, cookieState string) (string, error) {
+	p, ok := s.providers[name]
+	if !ok {
+		return "", apperrors.ErrNotFound
+	}
+	if cookieState == "" || subtle.ConstantTimeCompare([]byte(cookieState), []byte(req.State)) != 1 {
+		return "", apperrors.NewAppError(400, "登录状态无效或已过期", nil)
+	}
+
+	// state 一次性使用，无论后续是否成功都已失效
+	state, err := s.stateRepo.Take(ctx, req.State)
+	if err != nil {
+		return "", apperrors.NewAppError(500, "读取登录状态失败", err)
+	}
+	if state == nil || state.Provider != name {
+		return "", apperrors.NewAppError(400, "登录状态无效或已过期", nil)
+	}
+
+	if req.Error != "" {
+		msg := "身份提供方拒绝了登录请求"
+		if req.ErrorDescription != "" {
+			msg += "：" + req.ErrorDescription
+		}
+		return "", apperrors.NewAppError(401, msg, nil)
+	}
+	if req.Code == "" {
+		return "", apperrors.NewAppError(400, "缺少授权码", nil)
+	}
+
+	token, err := p.Provider.Exchange(ctx, req.Code, state.CodeVerifier)
+	if err != nil {
+		return "", apperrors.NewAppError(502, "授权码换取Token失败", err)
+	}
+	claims, err := p.Provider.VerifyIDToken(ctx, token.IDToken, state.Nonce)
+	if err != nil {
+		return "", apperrors.NewAppError(401, "ID Token校验失败", err)
+	}
+
+	user, err := s.resolveUser(ctx, p, claims)
+	if err != nil {
+		return "", err
+	}
+	if !user.IsActive() {
+		return "", apperrors.NewAppError(403, "用户已被禁用", nil)
+	}
+
+	code, err := oidc.NewState()
+	if err != nil {
+		return "", apperrors.NewAppError(500, "生成登录代码失败", err)
+	}
+	result := &entity.OIDCLoginResult{Provider: name, UserID: user.ID}
+	if err := s.stateRepo.SaveResult(ctx, code, result, oidcResultTTL); err != nil {
+		return "", apperrors.NewAppError(500, "保存登录结果失败", err)
+	}
+	// 登录代码放在 fragment 中，不会随请求发送到前端服务器或出现在 Referer 中
+	return s.redirectURL + "#" + url.Values{"code": {code}}.Encode(), nil
+}
+
+// ExchangeLoginCode 用回调得到的一次性登录代码换取 FlowGo Token
+func (s *OIDCService) ExchangeLoginCode(ctx context.Context, req dto.OIDCExchangeRequest) (*dto.LoginResponse, error) {
+	result, err := s.stateRepo.TakeResult(ctx, req.Code)
+	if err != nil {
+		return nil, apperrors.NewAppError(500, "读取登录结果失败", err)
+	}
+	if result == nil {
+		return nil, apperrors.NewAppError(400, "登录代码无效或已过期", nil)
+	}
+
+	user, err := s.userRepo.FindByID(ctx, result.UserID)
+	if err != nil {
+		return nil, apperrors.NewAppError(500, "查询用户失败", err)
+	}
+	if user == nil {
+		return nil, apperrors.NewAppError(403, "用户已被删除", nil)
+	}
+	if !user.IsActive() {
+		return nil, apperrors.NewAppError(403, "用户已被禁用", nil)
+	}
+
+	// 本地开启了两步验证的账号同样需要完成第二步
+	if user.TwoFactorEnabled {
+		return s.authService.twoFactorChallenge(user)
+	}
+	return s.authService.issueTokens(user)
+}
+
+// resolveUser 根据已绑定身份、已验证邮箱依次查找本地用户，都找不到时自动创建
+func (s *OIDCService) resolveUser(ctx context.Context, p *OIDCProvider, claims *oidc.IDTokenClaims) (*entity.User, error) {
+	name := p.Provider.Name()
+	identity, err := s.identityRepo.FindBySubject(ctx, name, claims.Subject)
+	if err != nil {
+		return nil, apperrors.NewAppError(500, "查询身份绑定失败", err)
+	}
+	if identity != nil {
+		user, err := s.userRepo.FindByID(ctx, identity.UserID)
+		if err != nil {
+			return nil, apperrors.NewAppError(500, "查询用户失败", err)
+		}
+		if user == nil {
+			return nil, apperrors.NewAppError(403, "用户已被删除", nil)
+		}
+		return user, nil
+	}
+
+	email := strings.TrimSpace(claims.Email)
+	if email == "" {
+		return nil, apperrors.NewAppError(400, "身份提供方未返回邮箱", nil)
+	}
+	emailVerified := claims.EmailVerified || p.TrustEmail
+
+	user, err := s.userRepo.FindByEmail(ctx, email)
+	if err != nil {
+		return nil, apperrors.NewAppError(500, "查询用户失败", err)
+	}
+	if user != nil && !emailVerified {
+		// 未经验证的邮箱不能用于绑定已有账号，否则可以冒用他人账号
+		return nil, apperrors.NewAppError(409, "邮箱已被其他账号使用", nil)
+	}
+	if user == nil {
+		if user, err = s.createUser(ctx, p, claims, email, emailVerified); err != nil {
+			return nil, err
+		}
+	}
+
+	identity = &entity.UserIdentity{
+		UserID:   user.ID,
+		Provider: name,
+		Subject:  claims.Subject,
+		Email:    email,
+	}
+	if err := s.identityRepo.Create(ctx, identity); err != nil {
+		return nil, apperrors.NewAppError(500, "保存身份绑定失败", err)
+	}
+	return user, nil
+}
+
+// createUser 首次登录时自动创建用户，密码为随机值，只能通过单点登录或找回密码登录
+func (s *OIDCService) createUser(ctx context.Context, p *OIDCProvider, claims *oidc.IDTokenClaims, email string, emailVerified bool) (*entity.User, error) {
+	username, err := s.uniqueUsername(ctx, claims.PreferredUsername, email)
+	if err != nil {
+		return nil, err
+	}
+
+	random, err := utils.GenerateRandomToken(32)
+	if err != nil {
+		return nil, apperrors.NewAppError(500, "生成密码失败", err)
+	}
+	hashedPassword, err := utils.HashPassword(random)
+	if err != nil {
+		return nil, apperrors.NewAppError(500, "密码加密失败", err)
+	}
+
+	// ID 由数据库分配
+	user := &entity.User{
+		Name:     username,
+		Email:    email,
+		Password: hashedPassword,
+		Status:   1,
+		Role:     string(p.DefaultRole),
+	}
+	if emailVerified {
+		user.MarkEmailVerified(time.Now())
+	}
+	if err := s.userRepo.Create(ctx, user); err != nil {
+		return nil, apperrors.NewAppError(500, "创建用户失败", err)
+	}
+	log.Printf("Created user %s (id=%d) from oidc provider %s", user.Name, user.ID, p.Provider.Name())
+	return user, nil
+}
+
+// uniqueUsername 优先使用 preferred_username，其次邮箱前缀，重名时追加数字
+func (s *OIDCService) uniqueUsername(ctx context.Context, preferred, email string) (string, error) {
+	base := strings.TrimSpace(preferred)
+	if base == "" {
+		base, _, _ = strings.Cut(email, "@")
+	}
+
+	for i := 1; i <= 100; i++ {
+		candidate := base
+		if i > 1 {
+			candidate = fmt.Sprintf("%s%d", base, i)
+		}
+		exists, err := s.userRepo.ExistsByName(ctx, candidate)
+		if err != nil {
+			return "", apperrors.NewAppError(500, "检查用户名失败", err)
+		}
+		if !exists {
+			return candidate, nil
+		}
+	}
+	return "", apperrors.NewAppError(409, "无法生成可用的用户名", nil)
+}
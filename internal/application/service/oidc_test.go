@@ -0,0 +1,422 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	gojwt "github.com/golang-jwt/jwt/v5"
+
+	"FLOWGO/internal/application/dto"
+	"FLOWGO/internal/domain/entity"
+	"FLOWGO/internal/domain/permission"
+	"FLOWGO/internal/domain/repository"
+	apperrors "FLOWGO/pkg/errors"
+	"FLOWGO/pkg/jwt"
+	"FLOWGO/pkg/oidc"
+)
+
+const (
+	testClientID    = "flowgo"
+	testRedirectURL = "https://flowgo.test/login/oidc"
+)
+
+// testIdP 基于 httptest 的身份提供方：发现文档、JWKS 和校验 PKCE 的 Token 端点
+type testIdP struct {
+	t      *testing.T
+	server *httptest.Server
+	key    *rsa.PrivateKey
+
+	mu     sync.Mutex
+	grants map[string]testGrant // 授权码 -> 授权结果
+}
+
+// testGrant 用户在身份提供方完成授权后的结果
+type testGrant struct {
+	challenge string
+	claims    gojwt.MapClaims
+}
+
+func newTestIdP(t *testing.T) *testIdP {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("generate key: %v", err)
+	}
+	idp := &testIdP{t: t, key: key, grants: make(map[string]testGrant)}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]string{
+			"issuer":                 idp.server.URL,
+			"authorization_endpoint": idp.server.URL + "/authorize",
+			"token_endpoint":         idp.server.URL + "/token",
+			"jwks_uri":               idp.server.URL + "/jwks",
+		})
+	})
+	mux.HandleFunc("/jwks", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"keys": []map[string]string{{
+				"kty": "RSA",
+				"kid": "idp-1",
+				"use": "sig",
+				"n":   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+				"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+			}},
+		})
+	})
+	mux.HandleFunc("/token", idp.token)
+	idp.server = httptest.NewServer(mux)
+	t.Cleanup(idp.server.Close)
+	return idp
+}
+
+// authorize 模拟用户在授权页同意登录，返回回调参数中的授权码
+// claims 中未设置的 iss、aud、nonce、exp、iat 使用与授权请求一致的值
+func (idp *testIdP) authorize(authURL string, claims gojwt.MapClaims) string {
+	idp.t.Helper()
+	u, err := url.Parse(authURL)
+	if err != nil {
+		idp.t.Fatalf("parse authorization url: %v", err)
+	}
+	q := u.Query()
+	if q.Get("client_id") != testClientID || q.Get("code_challenge_method") != "S256" {
+		idp.t.Fatalf("unexpected authorization request: %s", authURL)
+	}
+
+	defaults := gojwt.MapClaims{
+		"iss":   idp.server.URL,
+		"aud":   testClientID,
+		"nonce": q.Get("nonce"),
+		"exp":   time.Now().Add(5 * time.Minute).Unix(),
+		"iat":   time.Now().Unix(),
+	}
+	for k, v := range claims {
+		defaults[k] = v
+	}
+
+	code, err := oidc.NewState()
+	if err != nil {
+		idp.t.Fatalf("generate code: %v", err)
+	}
+	idp.mu.Lock()
+	idp.grants[code] = testGrant{challenge: q.Get("code_challenge"), claims: defaults}
+	idp.mu.Unlock()
+	return code
+}
+
+// token 授权码换取 Token，授权码只能使用一次且 code_verifier 必须与挑战值匹配
+func (idp *testIdP) token(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, "bad request", http.StatusBadRequest)
+		return
+	}
+	idp.mu.Lock()
+	grant, ok := idp.grants[r.PostForm.Get("code")]
+	delete(idp.grants, r.PostForm.Get("code"))
+	idp.mu.Unlock()
+	if !ok || oidc.CodeChallengeS256(r.PostForm.Get("code_verifier")) != grant.challenge {
+		http.Error(w, `{"error":"invalid_grant"}`, http.StatusBadRequest)
+		return
+	}
+
+	token := gojwt.NewWithClaims(gojwt.SigningMethodRS256, grant.claims)
+	token.Header["kid"] = "idp-1"
+	idToken, err := token.SignedString(idp.key)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"access_token": "idp-access-token",
+		"token_type":   "Bearer",
+		"id_token":     idToken,
+		"expires_in":   300,
+	})
+}
+
+// fakeOIDCUserRepo 只实现单点登录用到的方法
+type fakeOIDCUserRepo struct {
+	repository.UserRepository
+	users  map[uint64]*entity.User
+	nextID uint64
+}
+
+func (r *fakeOIDCUserRepo) FindByID(ctx context.Context, id uint64) (*entity.User, error) {
+	return r.users[id], nil
+}
+
+func (r *fakeOIDCUserRepo) FindByEmail(ctx context.Context, email string) (*entity.User, error) {
+	for _, u := range r.users {
+		if u.Email == email {
+			return u, nil
+		}
+	}
+	return nil, nil
+}
+
+func (r *fakeOIDCUserRepo) ExistsByName(ctx context.Context, name string) (bool, error) {
+	for _, u := range r.users {
+		if u.Name == name {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (r *fakeOIDCUserRepo) Create(ctx context.Context, user *entity.User) error {
+	r.nextID++
+	user.ID = r.nextID
+	r.users[user.ID] = user
+	return nil
+}
+
+type fakeIdentityRepo struct {
+	identities []*entity.UserIdentity
+}
+
+func (r *fakeIdentityRepo) Create(ctx context.Context, identity *entity.UserIdentity) error {
+	r.identities = append(r.identities, identity)
+	return nil
+}
+
+func (r *fakeIdentityRepo) FindBySubject(ctx context.Context, provider, subject string) (*entity.UserIdentity, error) {
+	for _, i := range r.identities {
+		if i.Provider == provider && i.Subject == subject {
+			return i, nil
+		}
+	}
+	return nil, nil
+}
+
+// fakeOIDCStateRepo 进程内的状态存储，忽略过期时间
+type fakeOIDCStateRepo struct {
+	states  map[string]*entity.OIDCLoginState
+	results map[string]*entity.OIDCLoginResult
+}
+
+func (r *fakeOIDCStateRepo) Save(ctx context.Context, state string, data *entity.OIDCLoginState, ttl time.Duration) error {
+	r.states[state] = data
+	return nil
+}
+
+func (r *fakeOIDCStateRepo) Take(ctx context.Context, state string) (*entity.OIDCLoginState, error) {
+	data := r.states[state]
+	delete(r.states, state)
+	return data, nil
+}
+
+func (r *fakeOIDCStateRepo) SaveResult(ctx context.Context, code string, result *entity.OIDCLoginResult, ttl time.Duration) error {
+	r.results[code] = result
+	return nil
+}
+
+func (r *fakeOIDCStateRepo) TakeResult(ctx context.Context, code string) (*entity.OIDCLoginResult, error) {
+	result := r.results[code]
+	delete(r.results, code)
+	return result, nil
+}
+
+// newTestOIDCService 创建连接到测试身份提供方的单点登录服务
+func newTestOIDCService(t *testing.T, idp *testIdP) (*OIDCService, *fakeOIDCUserRepo) {
+	t.Helper()
+	jwt.SetSecretKey("oidc-test-secret")
+
+	users := &fakeOIDCUserRepo{users: make(map[uint64]*entity.User)}
+	provider := &OIDCProvider{
+		Provider: oidc.NewProvider(oidc.Config{
+			Name:        "test",
+			IssuerURL:   idp.server.URL,
+			ClientID:    testClientID,
+			RedirectURL: "https://flowgo.test/api/v1/auth/oidc/test/callback",
+		}, idp.server.Client()),
+		DisplayName: "Test",
+		DefaultRole: permission.RoleMember,
+	}
+	states := &fakeOIDCStateRepo{
+		states:  make(map[string]*entity.OIDCLoginState),
+		results: make(map[string]*entity.OIDCLoginResult),
+	}
+	auth := NewAuthService(users, nil, nil, nil, nil)
+	s, err := NewOIDCService([]*OIDCProvider{provider}, users, &fakeIdentityRepo{}, states, auth, testRedirectURL)
+	if err != nil {
+		t.Fatalf("new oidc service: %v", err)
+	}
+	return s, users
+}
+
+// loginCode 从回调重定向地址的 fragment 中取出一次性登录代码
+func loginCode(t *testing.T, redirect string) string {
+	t.Helper()
+	base, fragment, ok := strings.Cut(redirect, "#")
+	if !ok || base != testRedirectURL {
+		t.Fatalf("unexpected redirect %q", redirect)
+	}
+	values, err := url.ParseQuery(fragment)
+	if err != nil || values.Get("code") == "" {
+		t.Fatalf("redirect has no login code: %q", redirect)
+	}
+	return values.Get("code")
+}
+
+func assertAppError(t *testing.T, err error, code int) {
+	t.Helper()
+	appErr, ok := err.(*apperrors.AppError)
+	if !ok || appErr.Code != code {
+		t.Fatalf("expected app error %d, got %v", code, err)
+	}
+}
+
+// TestOIDCCallback 完整的授权码流程：回调重定向携带一次性登录代码，换取 Token 后自动创建用户
+func TestOIDCCallback(t *testing.T) {
+	idp := newTestIdP(t)
+	s, users := newTestOIDCService(t, idp)
+	ctx := context.Background()
+
+	authURL, state, err := s.AuthorizationURL(ctx, "test")
+	if err != nil {
+		t.Fatalf("authorization url: %v", err)
+	}
+	code := idp.authorize(authURL, gojwt.MapClaims{
+		"sub":                "subject-1",
+		"email":              "alice@example.com",
+		"email_verified":     true,
+		"preferred_username": "alice",
+	})
+
+	redirect, err := s.Callback(ctx, "test", dto.OIDCCallbackRequest{Code: code, State: state}, state)
+	if err != nil {
+		t.Fatalf("callback: %v", err)
+	}
+	if strings.Contains(redirect, "token") {
+		t.Fatalf("redirect must not carry tokens: %q", redirect)
+	}
+	handoff := loginCode(t, redirect)
+
+	resp, err := s.ExchangeLoginCode(ctx, dto.OIDCExchangeRequest{Code: handoff})
+	if err != nil {
+		t.Fatalf("exchange: %v", err)
+	}
+	if resp.Token == "" || resp.RefreshToken == "" || resp.User == nil || resp.User.Name != "alice" {
+		t.Fatalf("unexpected login response: %+v", resp)
+	}
+	if len(users.users) != 1 || !users.users[resp.User.ID].EmailVerified {
+		t.Fatalf("expected one verified user, got %+v", users.users)
+	}
+
+	// 登录代码和 state 都只能使用一次
+	_, err = s.ExchangeLoginCode(ctx, dto.OIDCExchangeRequest{Code: handoff})
+	assertAppError(t, err, 400)
+	_, err = s.Callback(ctx, "test", dto.OIDCCallbackRequest{Code: code, State: state}, state)
+	assertAppError(t, err, 400)
+}
+
+// TestOIDCCallbackRejects state 与 Cookie 不一致、nonce 或受众不匹配时拒绝登录
+func TestOIDCCallbackRejects(t *testing.T) {
+	tests := []struct {
+		name   string
+		claims gojwt.MapClaims
+		cookie func(state string) string
+		code   int
+	}{
+		{
+			name:   "missing state cookie",
+			cookie: func(string) string { return "" },
+			code:   400,
+		},
+		{
+			// 攻击者把自己的授权回调链接发给受害者，受害者浏览器中没有对应的 Cookie
+			name:   "state from another browser",
+			cookie: func(string) string { return "attacker-state" },
+			code:   400,
+		},
+		{
+			name:   "nonce mismatch",
+			claims: gojwt.MapClaims{"nonce": "other-nonce"},
+			code:   401,
+		},
+		{
+			name:   "wrong audience",
+			claims: gojwt.MapClaims{"aud": "another-client"},
+			code:   401,
+		},
+		{
+			name:   "wrong issuer",
+			claims: gojwt.MapClaims{"iss": "https://evil.example.com"},
+			code:   401,
+		},
+		{
+			name:   "expired id token",
+			claims: gojwt.MapClaims{"exp": time.Now().Add(-time.Hour).Unix()},
+			code:   401,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			idp := newTestIdP(t)
+			s, users := newTestOIDCService(t, idp)
+			ctx := context.Background()
+
+			authURL, state, err := s.AuthorizationURL(ctx, "test")
+			if err != nil {
+				t.Fatalf("authorization url: %v", err)
+			}
+			claims := gojwt.MapClaims{"sub": "subject-1", "email": "alice@example.com", "email_verified": true}
+			for k, v := range tt.claims {
+				claims[k] = v
+			}
+			code := idp.authorize(authURL, claims)
+
+			cookie := state
+			if tt.cookie != nil {
+				cookie = tt.cookie(state)
+			}
+			_, err = s.Callback(ctx, "test", dto.OIDCCallbackRequest{Code: code, State: state}, cookie)
+			assertAppError(t, err, tt.code)
+			if len(users.users) != 0 {
+				t.Fatalf("user created on rejected callback")
+			}
+		})
+	}
+}
+
+// TestOIDCExchangeTwoFactor 开启两步验证的用户换取到的是挑战Token
+func TestOIDCExchangeTwoFactor(t *testing.T) {
+	idp := newTestIdP(t)
+	s, users := newTestOIDCService(t, idp)
+	ctx := context.Background()
+
+	users.Create(ctx, &entity.User{
+		Name: "bob", Email: "bob@example.com", Status: entity.UserStatusActive,
+		EmailVerified: true, TwoFactorEnabled: true,
+	})
+
+	authURL, state, err := s.AuthorizationURL(ctx, "test")
+	if err != nil {
+		t.Fatalf("authorization url: %v", err)
+	}
+	code := idp.authorize(authURL, gojwt.MapClaims{"sub": "subject-2", "email": "bob@example.com", "email_verified": true})
+	redirect, err := s.Callback(ctx, "test", dto.OIDCCallbackRequest{Code: code, State: state}, state)
+	if err != nil {
+		t.Fatalf("callback: %v", err)
+	}
+
+	resp, err := s.ExchangeLoginCode(ctx, dto.OIDCExchangeRequest{Code: loginCode(t, redirect)})
+	if err != nil {
+		t.Fatalf("exchange: %v", err)
+	}
+	if !resp.TwoFactorRequired || resp.ChallengeToken == "" || resp.Token != "" {
+		t.Fatalf("expected two-factor challenge, got %+v", resp)
+	}
+}
@@ -0,0 +1,27 @@
+package service
+
+import (
+	"errors"
+
+	"github.com/gin-gonic/gin/binding"
+
+	apperrors "FLOWGO/pkg/errors"
+	"FLOWGO/pkg/jsonpatch"
+)
+
+// applyPatch 把补丁应用到 current 对应的文档上，结果解码到 target 并按 binding 标签校验
+func applyPatch(patch jsonpatch.Patch, current, target interface{}) error {
+	err := jsonpatch.ApplyTo(patch, current, target)
+	switch {
+	case errors.Is(err, jsonpatch.ErrTestFailed):
+		return apperrors.NewAppError(409, "补丁中的 test 操作未通过", err)
+	case errors.Is(err, jsonpatch.ErrInvalidPatch), errors.Is(err, jsonpatch.ErrInvalidDocument):
+		return apperrors.NewAppError(400, "补丁无法应用："+err.Error(), err)
+	case err != nil:
+		return apperrors.NewAppError(500, "应用补丁失败", err)
+	}
+	if err := binding.Validator.ValidateStruct(target); err != nil {
+		return apperrors.NewAppError(400, err.Error(), err)
+	}
+	return nil
+}
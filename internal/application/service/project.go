@@ -3,27 +3,44 @@ package service
 import (
 	"context"
 	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
 
 	"FLOWGO/internal/application/dto"
 	"FLOWGO/internal/domain/entity"
+	"FLOWGO/internal/domain/permission"
 	"FLOWGO/internal/domain/repository"
+	"FLOWGO/pkg/contextutil"
+	apperrors "FLOWGO/pkg/errors"
+	"FLOWGO/pkg/jsonpatch"
 	"FLOWGO/pkg/utils"
 )
 
 type ProjectService struct {
-	projectRepo repository.ProjectsRepository
+	projectRepo   repository.ProjectsRepository
+	tagRepo       repository.TagRepository
+	milestoneRepo repository.MilestoneRepository
+	userRepo      repository.UserRepository
 }
 
-func NewProjectService(projectRepo repository.ProjectsRepository) *ProjectService {
+func NewProjectService(projectRepo repository.ProjectsRepository, tagRepo repository.TagRepository, milestoneRepo repository.MilestoneRepository, userRepo repository.UserRepository) *ProjectService {
 	return &ProjectService{
-		projectRepo: projectRepo,
+		projectRepo:   projectRepo,
+		tagRepo:       tagRepo,
+		milestoneRepo: milestoneRepo,
+		userRepo:      userRepo,
 	}
 }
 
 func (s *ProjectService) CreateProject(ctx context.Context, req dto.CreateProjectRequest) (*dto.CreateProjectResponse, error) {
 	project := entity.NewProject(req.Name, req.Description, req.OwnerID)
-	err := s.projectRepo.Create(ctx, project)
-	if err != nil {
+	if req.ProgressMode != "" {
+		project.SetProgressMode(entity.ProgressMode(req.ProgressMode))
+	}
+	// 创建者自动成为项目 owner，与项目在同一事务中写入
+	if err := s.projectRepo.CreateWithOwner(ctx, project); err != nil {
 		return nil, errors.New("创建项目失败")
 	}
 	return &dto.CreateProjectResponse{
@@ -31,56 +48,202 @@ func (s *ProjectService) CreateProject(ctx context.Context, req dto.CreateProjec
 	}, nil
 }
 
-func (s *ProjectService) UpdateProject(ctx context.Context, req dto.UpdateProjectRequest) (*dto.UpdateProjectResponse, error) {
+// UpdateProject 更新项目，version 为客户端读取时的版本号（If-Match），为 0 时不检查
+func (s *ProjectService) UpdateProject(ctx context.Context, req dto.UpdateProjectRequest, version uint64) (*dto.UpdateProjectResponse, error) {
 	// 先查找现有项目
 	project, err := s.projectRepo.FindByID(ctx, req.ID)
 	if err != nil {
-		return nil, errors.New("查找项目失败")
+		return nil, apperrors.NewAppError(500, "查找项目失败", err)
 	}
+	// If-Match 必须携带，项目不存在时前置条件不成立（包括 "*"）
 	if project == nil {
-		return nil, errors.New("项目不存在")
+		return nil, apperrors.NewAppError(412, "项目不存在", nil)
+	}
+	if _, err := s.requireProjectRole(ctx, project, entity.ProjectRoleMaintainer); err != nil {
+		return nil, err
+	}
+	if err := checkProjectWritable(project); err != nil {
+		return nil, err
+	}
+	// version 为 0 表示 If-Match: *，仍然按读取到的版本条件更新，并发修改时返回 412
+	if version != 0 && version != project.Version {
+		return nil, apperrors.NewAppError(412, "项目已被其他人修改，请刷新后重试", nil)
+	}
+	if req.Status != 0 && entity.ProjectStatus(req.Status) != project.Status {
+		return nil, apperrors.NewAppError(400, "项目状态需要通过状态变更接口修改", nil)
 	}
 
 	// 更新项目信息 - 使用充血模型方法
 	project.UpdateBasicInfo(req.Name, req.Description, req.CoverImage)
-	project.SetStatus(entity.ProjectStatus(req.Status))
 	project.SetSchedule(req.StartDate.Time, req.Deadline.Time)
 	project.SetPriorities(entity.ProjectPriority(req.Priority))
+	if req.ProgressMode != "" {
+		project.SetProgressMode(entity.ProgressMode(req.ProgressMode))
+	}
+	if req.Progress != nil {
+		// 自动计算的进度不能手动修改，需要先切换到手动模式
+		if !project.IsManualProgress() {
+			return nil, apperrors.NewAppError(400, "项目进度为自动计算，不能手动设置", nil)
+		}
+		project.SetProgress(*req.Progress)
+	}
 
-	// OwnerId check or update usually requires permission check, ignoring for now as per previous logic
-
-	// 保存更新
-	err = s.projectRepo.Update(ctx, project)
+	// 标签按名称保存，不存在的标签自动创建
+	tags, err := s.tagRepo.FindOrCreate(ctx, normalizeTagNames(req.Tags))
 	if err != nil {
-		return nil, errors.New("更新项目失败")
+		return nil, errors.New("保存项目标签失败")
 	}
-	//先删除原来团队
-	err = s.projectRepo.DeleteTeamsByProjectId(ctx, req.ID)
-	if err != nil {
-		return nil, errors.New("删除项目团队失败")
+	tagIds := make([]uint64, len(tags))
+	tagNames := make([]string, len(tags))
+	for i, tag := range tags {
+		tagIds[i] = tag.ID
+		tagNames[i] = tag.Name
 	}
-	//再保存
-	if len(req.TeamIds) > 0 {
-		err = s.projectRepo.AddTeams(ctx, project.ID, req.TeamIds)
-		if err != nil {
-			return nil, errors.New("保存项目团队失败")
-		}
+	teamIds := req.TeamIds
+	if teamIds == nil {
+		teamIds = []uint64{}
+	}
+
+	// 项目、团队和标签在同一事务中保存，自动模式下会按任务重新计算进度
+	err = s.projectRepo.UpdateWithLinks(ctx, project, teamIds, tagIds)
+	if errors.Is(err, entity.ErrProjectVersionConflict) {
+		return nil, apperrors.NewAppError(412, "项目已被其他人修改，请刷新后重试", nil)
+	}
+	if err != nil {
+		return nil, errors.New("更新项目失败")
 	}
 	return &dto.UpdateProjectResponse{
 		ID:          project.ID,
 		TeamIds:     req.TeamIds,
-		Tags:        req.Tags,
+		Tags:        tagNames,
 		Priority:    int(project.Priority),
 		CoverImage:  req.CoverImage,
 		Deadline:    req.Deadline,
 		StartDate:   req.StartDate,
 		Name:        req.Name,
 		Description: req.Description,
-		OwnerID:     req.OwnerID,
+		OwnerID:     project.OwnerID,
 		Status:      int(project.Status),
+
+		Progress:     project.Progress,
+		ProgressMode: string(project.ProgressMode),
+
+		Version: project.Version,
 	}, nil
 }
 
+// PatchProject 按 JSON Merge Patch 或 JSON Patch 部分更新项目，只修改补丁改动的字段
+// version 为客户端读取时的版本号（If-Match），为 0 时不检查；有改动时版本号加一
+func (s *ProjectService) PatchProject(ctx context.Context, id uint64, patch jsonpatch.Patch, version uint64) (*dto.GetProjectResponse, error) {
+	project, err := s.projectRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, apperrors.NewAppError(500, "查找项目失败", err)
+	}
+	if project == nil {
+		return nil, apperrors.NewAppError(404, "项目不存在", nil)
+	}
+	if _, err := s.requireProjectRole(ctx, project, entity.ProjectRoleMaintainer); err != nil {
+		return nil, err
+	}
+	if err := checkProjectWritable(project); err != nil {
+		return nil, err
+	}
+	if version != 0 && version != project.Version {
+		return nil, apperrors.NewAppError(412, "项目已被其他人修改，请刷新后重试", nil)
+	}
+
+	currentTeamIds, err := s.projectRepo.ListTeamIdsByProjectId(ctx, id)
+	if err != nil {
+		return nil, apperrors.NewAppError(500, "获取项目团队失败", err)
+	}
+	tags, err := s.projectRepo.ListTagsByProjectId(ctx, id)
+	if err != nil {
+		return nil, apperrors.NewAppError(500, "获取项目标签失败", err)
+	}
+	tagNames := make([]string, len(tags))
+	for i, tag := range tags {
+		tagNames[i] = tag.Name
+	}
+	current := dto.ProjectPatchDocument{
+		Name:         project.Name,
+		Description:  project.Description,
+		Deadline:     utils.NewTime(project.Deadline),
+		StartDate:    utils.NewTime(project.StartDate),
+		Priority:     int(project.Priority),
+		CoverImage:   project.CoverImage,
+		TeamIds:      currentTeamIds,
+		Tags:         tagNames,
+		ProgressMode: string(project.ProgressMode),
+		Progress:     project.Progress,
+	}
+	var patched dto.ProjectPatchDocument
+	if err := applyPatch(patch, current, &patched); err != nil {
+		return nil, err
+	}
+
+	// 只对改动的字段调用对应的实体方法
+	changed := false
+	if patched.Name != current.Name || patched.Description != current.Description || patched.CoverImage != current.CoverImage {
+		project.UpdateBasicInfo(patched.Name, patched.Description, patched.CoverImage)
+		changed = true
+	}
+	if patched.StartDate.String() != current.StartDate.String() || patched.Deadline.String() != current.Deadline.String() {
+		project.SetSchedule(patched.StartDate.Time, patched.Deadline.Time)
+		changed = true
+	}
+	if patched.Priority != current.Priority {
+		project.SetPriorities(entity.ProjectPriority(patched.Priority))
+		changed = true
+	}
+	if patched.ProgressMode != current.ProgressMode {
+		project.SetProgressMode(entity.ProgressMode(patched.ProgressMode))
+		changed = true
+	}
+	if patched.Progress != current.Progress {
+		// 自动计算的进度不能手动修改，需要在同一个补丁中切换到手动模式
+		if !project.IsManualProgress() {
+			return nil, apperrors.NewAppError(400, "项目进度为自动计算，不能手动设置", nil)
+		}
+		project.SetProgress(patched.Progress)
+		changed = true
+	}
+	patched.Tags = normalizeTagNames(patched.Tags)
+	teamsChanged := !slices.Equal(patched.TeamIds, current.TeamIds)
+	tagsChanged := !slices.Equal(patched.Tags, current.Tags)
+	if !changed && !teamsChanged && !tagsChanged {
+		return s.GetProject(ctx, dto.GetProjectRequest{ID: id})
+	}
+
+	// 未改动的团队和标签传 nil，保持不变
+	var teamIds, tagIds []uint64
+	if teamsChanged {
+		teamIds = patched.TeamIds
+		if teamIds == nil {
+			teamIds = []uint64{}
+		}
+	}
+	if tagsChanged {
+		tags, err := s.tagRepo.FindOrCreate(ctx, patched.Tags)
+		if err != nil {
+			return nil, apperrors.NewAppError(500, "保存项目标签失败", err)
+		}
+		tagIds = make([]uint64, len(tags))
+		for i, tag := range tags {
+			tagIds[i] = tag.ID
+		}
+	}
+
+	// 项目、团队和标签在同一事务中保存，团队和标签的改动也会让版本号加一，版本冲突时不做任何修改
+	err = s.projectRepo.UpdateWithLinks(ctx, project, teamIds, tagIds)
+	if errors.Is(err, entity.ErrProjectVersionConflict) {
+		return nil, apperrors.NewAppError(412, "项目已被其他人修改，请刷新后重试", nil)
+	}
+	if err != nil {
+		return nil, apperrors.NewAppError(500, "更新项目失败", err)
+	}
+	return s.GetProject(ctx, dto.GetProjectRequest{ID: id})
+}
+
 func (s *ProjectService) DeleteProject(ctx context.Context, req dto.DeleteProjectRequest) (*dto.DeleteProjectResponse, error) {
 	project, err := s.projectRepo.FindByID(ctx, req.ID)
 	if err != nil {
@@ -89,6 +252,12 @@ func (s *ProjectService) DeleteProject(ctx context.Context, req dto.DeleteProjec
 	if project == nil {
 		return nil, errors.New("项目不存在")
 	}
+	if _, err := s.requireProjectRole(ctx, project, entity.ProjectRoleOwner); err != nil {
+		return nil, err
+	}
+	if err := checkProjectWritable(project); err != nil {
+		return nil, err
+	}
 	err = s.projectRepo.Delete(ctx, req.ID)
 	if err != nil {
 		return nil, errors.New("删除项目失败")
@@ -111,21 +280,36 @@ func (s *ProjectService) GetProject(ctx context.Context, req dto.GetProjectReque
 		return nil, errors.New("获取项目团队失败")
 	}
 
-	users, err := s.projectRepo.ListUsersByProjectId(ctx, req.ID)
+	members, err := s.ProjectUsers(ctx, req.ID)
 	if err != nil {
-		return nil, errors.New("获取项目成员失败")
+		return nil, err
 	}
-	userResponses := make([]*dto.UserResponse, 0, len(users))
-	for _, user := range users {
-		userResponses = append(userResponses, &dto.UserResponse{
-			ID:     user.ID,
-			Name:   user.Name,
-			Email:  user.Email,
-			Avatar: user.Avatar,
-			TeamID: user.TeamID,
-			Role:   user.Role,
-			Status: user.Status,
-		})
+
+	tags, err := s.projectRepo.ListTagsByProjectId(ctx, req.ID)
+	if err != nil {
+		return nil, errors.New("获取项目标签失败")
+	}
+	tagNames := make([]string, len(tags))
+	tagList := make([]*dto.TagResponse, len(tags))
+	for i, tag := range tags {
+		tagNames[i] = tag.Name
+		tagList[i] = toTagResponse(tag)
+	}
+
+	milestones, err := s.milestoneRepo.ListByProject(ctx, req.ID)
+	if err != nil {
+		return nil, errors.New("获取项目里程碑失败")
+	}
+	now := time.Now()
+	milestoneList := make([]*dto.MilestoneSummaryResponse, len(milestones))
+	for i, milestone := range milestones {
+		milestoneList[i] = &dto.MilestoneSummaryResponse{
+			ID:      milestone.ID,
+			Name:    milestone.Name,
+			DueDate: utils.NewTime(milestone.DueDate),
+			State:   string(milestone.State),
+			Overdue: milestone.IsOverdue(now),
+		}
 	}
 
 	return &dto.GetProjectResponse{
@@ -139,14 +323,49 @@ func (s *ProjectService) GetProject(ctx context.Context, req dto.GetProjectReque
 		Progress:    project.Progress,
 		Priority:    int(project.Priority),
 		CoverImage:  project.CoverImage,
+		Tags:        tagNames,
 		TeamIds:     teamIds,
-		Users:       userResponses,
+		Users:       members.Users,
 		CreatedAt:   utils.NewTime(project.CreatedAt),
+
+		ProgressMode: string(project.ProgressMode),
+		TagList:      tagList,
+
+		Milestones: milestoneList,
+
+		Version: project.Version,
 	}, nil
 }
 
-func (s *ProjectService) ListProjects(ctx context.Context, req dto.PageRequest) (*dto.ProjectListResponse, error) {
-	projects, total, err := s.projectRepo.List(ctx, req.Page, req.GetPageSize())
+// ListProjects 分页获取项目列表，可按团队（可包含下级团队）、标签、状态、优先级、负责人、成员和截止日期过滤，支持关键字搜索和排序
+func (s *ProjectService) ListProjects(ctx context.Context, req dto.ListProjectsRequest) (*dto.ProjectListResponse, error) {
+	filter := repository.ProjectFilter{
+		TeamID:             req.TeamID,
+		IncludeDescendants: req.IncludeDescendants,
+		Tags:               normalizeTagNames(splitTagNames(req.Tags)),
+		MatchAllTags:       req.TagMode == "all",
+		OwnerID:            req.OwnerID,
+		MemberID:           req.MemberID,
+		DeadlineFrom:       req.DeadlineFrom,
+	}
+	sort, err := repository.ParseSort(req.Sort, repository.ProjectSortFields)
+	if err != nil {
+		return nil, apperrors.NewAppError(400, "不支持的排序字段", err)
+	}
+	filter.QuerySpec = repository.QuerySpec{Keyword: req.Keyword, Sort: sort}
+	if req.Status != nil {
+		status := entity.ProjectStatus(*req.Status)
+		filter.Status = &status
+	}
+	if req.Priority != nil {
+		priority := entity.ProjectPriority(*req.Priority)
+		filter.Priority = &priority
+	}
+	if !req.DeadlineTo.IsZero() {
+		// 截止日期包含当天
+		filter.DeadlineTo = req.DeadlineTo.AddDate(0, 0, 1)
+	}
+	projects, total, err := s.projectRepo.ListByFilter(ctx, filter, req.Page, req.GetPageSize())
 	if err != nil {
 		return nil, errors.New("获取项目列表失败")
 	}
@@ -162,6 +381,10 @@ func (s *ProjectService) ListProjects(ctx context.Context, req dto.PageRequest)
 			StartDate:   utils.NewTime(project.StartDate),
 			Progress:    project.Progress,
 			Priority:    int(project.Priority),
+
+			ProgressMode: string(project.ProgressMode),
+
+			Version: project.Version,
 		})
 	}
 	return &dto.ProjectListResponse{
@@ -196,16 +419,22 @@ func (s *ProjectService) ProjectUsers(ctx context.Context, projectID uint64) (*d
 	if err != nil {
 		return nil, errors.New("获取项目成员失败")
 	}
+	roles, err := s.projectRepo.ListUserRoles(ctx, projectID)
+	if err != nil {
+		return nil, errors.New("获取项目成员失败")
+	}
 	userResponses := make([]*dto.UserResponse, 0, len(users))
 	for _, user := range users {
 		userResponses = append(userResponses, &dto.UserResponse{
-			ID:     user.ID,
-			Name:   user.Name,
-			Email:  user.Email,
-			Avatar: user.Avatar,
-			TeamID: user.TeamID,
-			Role:   user.Role,
-			Status: user.Status,
+			ID:          user.ID,
+			Name:        user.Name,
+			DisplayName: user.DisplayName,
+			Email:       user.Email,
+			Avatar:      user.Avatar,
+			TeamID:      user.TeamID,
+			Role:        user.Role,
+			ProjectRole: string(roles[user.ID]),
+			Status:      user.Status,
 		})
 	}
 	return &dto.ProjectUsersResponse{
@@ -213,22 +442,23 @@ func (s *ProjectService) ProjectUsers(ctx context.Context, projectID uint64) (*d
 	}, nil
 }
 
-func (s *ProjectService) GetProjectAvailableUsers(ctx context.Context, projectID uint64) (*dto.ProjectUsersResponse, error) {
-	// 查询项目关联团队下的所有用户
-	users, err := s.projectRepo.ListUsersInProjectTeams(ctx, projectID)
+func (s *ProjectService) GetProjectAvailableUsers(ctx context.Context, projectID uint64, includeDescendants bool) (*dto.ProjectUsersResponse, error) {
+	// 查询项目关联团队（可包含下级团队）下的所有用户
+	users, err := s.projectRepo.ListUsersInProjectTeams(ctx, projectID, includeDescendants)
 	if err != nil {
 		return nil, errors.New("获取候选用户失败")
 	}
 	userResponses := make([]*dto.UserResponse, 0, len(users))
 	for _, user := range users {
 		userResponses = append(userResponses, &dto.UserResponse{
-			ID:     user.ID,
-			Name:   user.Name,
-			Email:  user.Email,
-			Avatar: user.Avatar,
-			TeamID: user.TeamID,
-			Role:   user.Role,
-			Status: user.Status,
+			ID:          user.ID,
+			Name:        user.Name,
+			DisplayName: user.DisplayName,
+			Email:       user.Email,
+			Avatar:      user.Avatar,
+			TeamID:      user.TeamID,
+			Role:        user.Role,
+			Status:      user.Status,
 		})
 	}
 	return &dto.ProjectUsersResponse{
@@ -240,16 +470,41 @@ func (s *ProjectService) AddProjectUsers(ctx context.Context, req dto.AddProject
 	// 简单校验项目是否存在
 	project, err := s.projectRepo.FindByID(ctx, projectID)
 	if err != nil {
-		return nil, errors.New("查找项目失败")
+		return nil, apperrors.NewAppError(500, "查找项目失败", err)
 	}
 	if project == nil {
-		return nil, errors.New("项目不存在")
+		return nil, apperrors.NewAppError(404, "项目不存在", nil)
+	}
+
+	role := entity.ProjectRoleMember
+	if req.Role != "" {
+		role = entity.ProjectRole(req.Role)
+	}
+	callerRole, err := s.requireProjectRole(ctx, project, entity.ProjectRoleMaintainer)
+	if err != nil {
+		return nil, err
+	}
+	if !callerRole.CanManage(role) {
+		return nil, apperrors.ErrForbidden
+	}
+	if err := checkProjectWritable(project); err != nil {
+		return nil, err
+	}
+	// 已删除、已禁用或不存在的用户不能加入项目
+	for _, userID := range req.Users {
+		user, err := s.userRepo.FindByID(ctx, userID)
+		if err != nil {
+			return nil, apperrors.NewAppError(500, "查询用户失败", err)
+		}
+		if user == nil || !user.IsActive() {
+			return nil, apperrors.NewAppError(400, fmt.Sprintf("用户 %d 不存在或已被禁用", userID), nil)
+		}
 	}
 
 	// 添加用户
-	err = s.projectRepo.AddUsers(ctx, projectID, req.Users)
+	err = s.projectRepo.AddUsers(ctx, projectID, req.Users, role)
 	if err != nil {
-		return nil, errors.New("添加项目成员失败")
+		return nil, apperrors.NewAppError(500, "添加项目成员失败", err)
 	}
 
 	// 返回最新的成员列表
@@ -263,7 +518,13 @@ func (s *ProjectService) RemoveProjectUser(ctx context.Context, projectID uint64
 		return errors.New("查找项目失败")
 	}
 	if project == nil {
-		return errors.New("项目不存在")
+		return apperrors.NewAppError(404, "项目不存在", nil)
+	}
+	if err := s.checkManageMember(ctx, project, userID); err != nil {
+		return err
+	}
+	if err := checkProjectWritable(project); err != nil {
+		return err
 	}
 
 	// 移除用户
@@ -274,3 +535,164 @@ func (s *ProjectService) RemoveProjectUser(ctx context.Context, projectID uint64
 
 	return nil
 }
+
+// UpdateProjectUserRole 修改项目成员角色
+func (s *ProjectService) UpdateProjectUserRole(ctx context.Context, req dto.UpdateProjectUserRoleRequest, projectID uint64, userID uint64) (*dto.ProjectUsersResponse, error) {
+	project, err := s.projectRepo.FindByID(ctx, projectID)
+	if err != nil {
+		return nil, errors.New("查找项目失败")
+	}
+	if project == nil {
+		return nil, apperrors.NewAppError(404, "项目不存在", nil)
+	}
+	if err := s.checkManageMember(ctx, project, userID); err != nil {
+		return nil, err
+	}
+
+	role := entity.ProjectRole(req.Role)
+	callerRole, err := s.requireProjectRole(ctx, project, entity.ProjectRoleMaintainer)
+	if err != nil {
+		return nil, err
+	}
+	if !callerRole.CanManage(role) {
+		return nil, apperrors.ErrForbidden
+	}
+	if err := checkProjectWritable(project); err != nil {
+		return nil, err
+	}
+
+	err = s.projectRepo.UpdateUserRole(ctx, projectID, userID, role)
+	if err != nil {
+		return nil, errors.New("修改项目成员角色失败")
+	}
+	return s.ProjectUsers(ctx, projectID)
+}
+
+// ChangeProjectStatus 按状态机变更项目状态并记录历史：进行中 → 已完成 → 已归档，重新打开需要填写原因
+func (s *ProjectService) ChangeProjectStatus(ctx context.Context, projectID uint64, req dto.ChangeProjectStatusRequest) (*dto.ChangeProjectStatusResponse, error) {
+	project, err := s.projectRepo.FindByID(ctx, projectID)
+	if err != nil {
+		return nil, errors.New("查找项目失败")
+	}
+	if project == nil {
+		return nil, apperrors.NewAppError(404, "项目不存在", nil)
+	}
+	if _, err := s.requireProjectRole(ctx, project, entity.ProjectRoleMaintainer); err != nil {
+		return nil, err
+	}
+	userID, _ := contextutil.GetUserID(ctx)
+
+	change, err := project.TransitionTo(entity.ProjectStatus(req.Status), strings.TrimSpace(req.Reason), userID, time.Now())
+	switch {
+	case errors.Is(err, entity.ErrProjectStatusTransition):
+		return nil, apperrors.NewAppError(409, "不允许的项目状态变更", err)
+	case errors.Is(err, entity.ErrProjectReopenReason):
+		return nil, apperrors.NewAppError(400, "重新打开项目需要填写原因", err)
+	case err != nil:
+		return nil, err
+	}
+
+	changed, err := s.projectRepo.ChangeStatus(ctx, project, change)
+	if err != nil {
+		return nil, errors.New("变更项目状态失败")
+	}
+	if !changed {
+		return nil, apperrors.NewAppError(409, "项目状态已被修改，请刷新后重试", nil)
+	}
+	return &dto.ChangeProjectStatusResponse{
+		ProjectStatusChangeResponse: toProjectStatusChangeResponse(change),
+		Version:                     project.Version,
+	}, nil
+}
+
+// ListProjectStatusChanges 获取项目状态变更历史，最近的在前
+func (s *ProjectService) ListProjectStatusChanges(ctx context.Context, projectID uint64) ([]*dto.ProjectStatusChangeResponse, error) {
+	project, err := s.projectRepo.FindByID(ctx, projectID)
+	if err != nil {
+		return nil, errors.New("查找项目失败")
+	}
+	if project == nil {
+		return nil, apperrors.NewAppError(404, "项目不存在", nil)
+	}
+	changes, err := s.projectRepo.ListStatusChanges(ctx, projectID)
+	if err != nil {
+		return nil, errors.New("获取项目状态历史失败")
+	}
+	list := make([]*dto.ProjectStatusChangeResponse, len(changes))
+	for i, change := range changes {
+		list[i] = toProjectStatusChangeResponse(change)
+	}
+	return list, nil
+}
+
+func toProjectStatusChangeResponse(change *entity.ProjectStatusChange) *dto.ProjectStatusChangeResponse {
+	return &dto.ProjectStatusChangeResponse{
+		ID:         change.ID,
+		ProjectID:  change.ProjectID,
+		FromStatus: int(change.FromStatus),
+		ToStatus:   int(change.ToStatus),
+		Reason:     change.Reason,
+		OperatorID: change.OperatorID,
+		CreatedAt:  utils.NewTime(change.CreatedAt),
+	}
+}
+
+// checkManageMember 校验当前用户能否管理（移除、改角色）指定的项目成员
+// 项目负责人（Project.OwnerID）不能被移除或降级
+func (s *ProjectService) checkManageMember(ctx context.Context, project *entity.Project, targetUserID uint64) error {
+	if targetUserID == project.OwnerID {
+		return apperrors.NewAppError(400, "不能变更项目负责人的成员身份", nil)
+	}
+	callerRole, err := s.requireProjectRole(ctx, project, entity.ProjectRoleMaintainer)
+	if err != nil {
+		return err
+	}
+	targetRole, err := s.projectRepo.FindUserRole(ctx, project.ID, targetUserID)
+	if err != nil {
+		return errors.New("查找项目成员失败")
+	}
+	if targetRole == "" {
+		return apperrors.NewAppError(404, "用户不是项目成员", nil)
+	}
+	if !callerRole.CanManage(targetRole) {
+		return apperrors.ErrForbidden
+	}
+	return nil
+}
+
+// requireProjectRole 校验当前用户在项目中的角色不低于 min，返回当前用户的项目角色
+func (s *ProjectService) requireProjectRole(ctx context.Context, project *entity.Project, min entity.ProjectRole) (entity.ProjectRole, error) {
+	return checkProjectRole(ctx, s.projectRepo, project, min)
+}
+
+// checkProjectWritable 已归档的项目只读，只能通过状态变更重新打开
+func checkProjectWritable(project *entity.Project) error {
+	if project.IsArchived() {
+		return apperrors.NewAppError(409, "项目已归档，重新打开后才能修改", nil)
+	}
+	return nil
+}
+
+// checkProjectRole 校验当前用户在项目中的角色不低于 min，返回当前用户的项目角色
+// 项目负责人视为 owner，全局管理员视为 owner
+func checkProjectRole(ctx context.Context, projectRepo repository.ProjectsRepository, project *entity.Project, min entity.ProjectRole) (entity.ProjectRole, error) {
+	userID, err := contextutil.GetUserID(ctx)
+	if err != nil {
+		return "", apperrors.ErrUnauthorized
+	}
+
+	var role entity.ProjectRole
+	if project.OwnerID == userID || permission.ParseRole(contextutil.GetRole(ctx)) == permission.RoleAdmin {
+		role = entity.ProjectRoleOwner
+	} else {
+		role, err = projectRepo.FindUserRole(ctx, project.ID, userID)
+		if err != nil {
+			return "", errors.New("查询项目角色失败")
+		}
+	}
+
+	if !role.AtLeast(min) {
+		return "", apperrors.ErrForbidden
+	}
+	return role, nil
+}
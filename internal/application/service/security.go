@@ -0,0 +1,95 @@
+package service
+
+import (
+	"context"
+	"log"
+
+	"FLOWGO/internal/application/dto"
+	"FLOWGO/internal/domain/entity"
+	"FLOWGO/internal/domain/repository"
+	"FLOWGO/pkg/contextutil"
+	apperrors "FLOWGO/pkg/errors"
+	"FLOWGO/pkg/utils"
+)
+
+// SecurityService 安全管理服务：解除账号锁定、查看安全事件
+type SecurityService struct {
+	userRepo   repository.UserRepository
+	eventRepo  repository.SecurityEventRepository
+	loginGuard *LoginGuard
+}
+
+// NewSecurityService 创建安全管理服务实例
+func NewSecurityService(
+	userRepo repository.UserRepository,
+	eventRepo repository.SecurityEventRepository,
+	loginGuard *LoginGuard,
+) *SecurityService {
+	return &SecurityService{
+		userRepo:   userRepo,
+		eventRepo:  eventRepo,
+		loginGuard: loginGuard,
+	}
+}
+
+// UnlockUser 解除账号的登录锁定和退避
+func (s *SecurityService) UnlockUser(ctx context.Context, userID uint64) error {
+	actorID, err := contextutil.GetUserID(ctx)
+	if err != nil {
+		return apperrors.ErrUnauthorized
+	}
+
+	user, err := s.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		return apperrors.NewAppError(500, "查询用户失败", err)
+	}
+	if user == nil {
+		return apperrors.ErrNotFound
+	}
+
+	if err := s.loginGuard.Unlock(ctx, user.Name); err != nil {
+		return apperrors.NewAppError(500, "解除锁定失败", err)
+	}
+
+	event := &entity.SecurityEvent{
+		Type:     entity.SecurityEventAccountUnlocked,
+		UserID:   user.ID,
+		Username: user.Name,
+		ActorID:  actorID,
+	}
+	if err := s.eventRepo.Create(ctx, event); err != nil {
+		log.Printf("Warning: failed to record security event %s: %v", event.Type, err)
+	}
+	return nil
+}
+
+// ListEvents 分页查询安全事件
+func (s *SecurityService) ListEvents(ctx context.Context, req dto.SecurityEventListRequest) (*dto.SecurityEventListResponse, error) {
+	events, total, err := s.eventRepo.List(ctx, req.Type, req.Page, req.GetPageSize())
+	if err != nil {
+		return nil, apperrors.NewAppError(500, "查询安全事件失败", err)
+	}
+
+	list := make([]*dto.SecurityEventResponse, 0, len(events))
+	for _, event := range events {
+		list = append(list, &dto.SecurityEventResponse{
+			ID:        event.ID,
+			Type:      string(event.Type),
+			UserID:    event.UserID,
+			Username:  event.Username,
+			IP:        event.IP,
+			ActorID:   event.ActorID,
+			Detail:    event.Detail,
+			CreatedAt: utils.NewTime(event.CreatedAt),
+		})
+	}
+
+	return &dto.SecurityEventListResponse{
+		List: list,
+		Page: dto.PageResponse{
+			Page:     req.Page,
+			PageSize: req.GetPageSize(),
+			Total:    total,
+		},
+	}, nil
+}
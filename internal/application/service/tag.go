@@ -0,0 +1,143 @@
+package service
+
+import (
+	"context"
+	"strings"
+
+	"FLOWGO/internal/application/dto"
+	"FLOWGO/internal/domain/entity"
+	"FLOWGO/internal/domain/repository"
+	apperrors "FLOWGO/pkg/errors"
+)
+
+// defaultTagSuggestLimit 自动补全默认返回的标签数
+const defaultTagSuggestLimit = 10
+
+// TagService 标签服务：标签的增删改和自动补全，项目与标签的关联由 ProjectService 维护
+type TagService struct {
+	tagRepo repository.TagRepository
+}
+
+// NewTagService 创建标签服务实例
+func NewTagService(tagRepo repository.TagRepository) *TagService {
+	return &TagService{
+		tagRepo: tagRepo,
+	}
+}
+
+// SuggestTags 按名称前缀查询标签，使用次数多的排在前面
+func (s *TagService) SuggestTags(ctx context.Context, req dto.SuggestTagsRequest) ([]*dto.TagResponse, error) {
+	limit := req.Limit
+	if limit <= 0 {
+		limit = defaultTagSuggestLimit
+	}
+	tags, err := s.tagRepo.Suggest(ctx, entity.NormalizeTagName(req.Q), limit)
+	if err != nil {
+		return nil, apperrors.NewAppError(500, "查询标签失败", err)
+	}
+	list := make([]*dto.TagResponse, len(tags))
+	for i, tag := range tags {
+		list[i] = toTagResponse(tag)
+	}
+	return list, nil
+}
+
+// CreateTag 创建标签
+func (s *TagService) CreateTag(ctx context.Context, req dto.CreateTagRequest) (*dto.TagResponse, error) {
+	tag := entity.NewTag(req.Name, req.Color)
+	if err := s.checkNameAvailable(ctx, tag.Name, 0); err != nil {
+		return nil, err
+	}
+	if err := s.tagRepo.Create(ctx, tag); err != nil {
+		return nil, apperrors.NewAppError(500, "创建标签失败", err)
+	}
+	return toTagResponse(tag), nil
+}
+
+// UpdateTag 修改标签名称和颜色，使用该标签的项目随之变化
+func (s *TagService) UpdateTag(ctx context.Context, id uint64, req dto.UpdateTagRequest) (*dto.TagResponse, error) {
+	tag, err := s.findTag(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	tag.Rename(req.Name)
+	tag.SetColor(req.Color)
+	if err := s.checkNameAvailable(ctx, tag.Name, tag.ID); err != nil {
+		return nil, err
+	}
+	if err := s.tagRepo.Update(ctx, tag); err != nil {
+		return nil, apperrors.NewAppError(500, "更新标签失败", err)
+	}
+	return toTagResponse(tag), nil
+}
+
+// DeleteTag 删除标签，同时从全部项目中移除
+func (s *TagService) DeleteTag(ctx context.Context, id uint64) error {
+	tag, err := s.findTag(ctx, id)
+	if err != nil {
+		return err
+	}
+	if err := s.tagRepo.Delete(ctx, tag.ID); err != nil {
+		return apperrors.NewAppError(500, "删除标签失败", err)
+	}
+	return nil
+}
+
+func (s *TagService) findTag(ctx context.Context, id uint64) (*entity.Tag, error) {
+	tag, err := s.tagRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, apperrors.NewAppError(500, "查询标签失败", err)
+	}
+	if tag == nil {
+		return nil, apperrors.NewAppError(404, "标签不存在", nil)
+	}
+	return tag, nil
+}
+
+// checkNameAvailable 检查标签名称未被其他标签使用
+func (s *TagService) checkNameAvailable(ctx context.Context, name string, excludeID uint64) error {
+	if name == "" {
+		return apperrors.NewAppError(400, "标签名称不能为空", nil)
+	}
+	existing, err := s.tagRepo.FindByName(ctx, name)
+	if err != nil {
+		return apperrors.NewAppError(500, "查询标签失败", err)
+	}
+	if existing != nil && existing.ID != excludeID {
+		return apperrors.NewAppError(409, "标签名称已存在", nil)
+	}
+	return nil
+}
+
+// splitTagNames 拆分查询参数中用逗号分隔的标签
+func splitTagNames(values []string) []string {
+	names := make([]string, 0, len(values))
+	for _, value := range values {
+		names = append(names, strings.Split(value, ",")...)
+	}
+	return names
+}
+
+// normalizeTagNames 规范化标签名称，去掉空值和重复值，保持原有顺序
+func normalizeTagNames(names []string) []string {
+	seen := make(map[string]bool, len(names))
+	result := make([]string, 0, len(names))
+	for _, name := range names {
+		name = entity.NormalizeTagName(name)
+		if name == "" || seen[name] {
+			continue
+		}
+		seen[name] = true
+		result = append(result, name)
+	}
+	return result
+}
+
+func toTagResponse(tag *entity.Tag) *dto.TagResponse {
+	return &dto.TagResponse{
+		ID:         tag.ID,
+		Name:       tag.Name,
+		Color:      tag.Color,
+		UsageCount: tag.UsageCount,
+	}
+}
@@ -0,0 +1,274 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"FLOWGO/internal/application/dto"
+	"FLOWGO/internal/domain/entity"
+	"FLOWGO/internal/domain/repository"
+	"FLOWGO/pkg/contextutil"
+	apperrors "FLOWGO/pkg/errors"
+	"FLOWGO/pkg/rank"
+	"FLOWGO/pkg/utils"
+)
+
+// errWIPLimitReached 任务的目标状态对应的看板列已满，新建、修改和移动任务时共用
+var errWIPLimitReached = apperrors.NewAppError(409, "该列已达到在制品上限", nil)
+
+// TaskService 任务服务：项目内任务的增删改查
+// 查看任务需要是项目成员（含 viewer），创建和修改需要 member 及以上，删除需要 maintainer 或任务创建人
+type TaskService struct {
+	taskRepo    repository.TaskRepository
+	projectRepo repository.ProjectsRepository
+}
+
+// NewTaskService 创建任务服务实例
+func NewTaskService(taskRepo repository.TaskRepository, projectRepo repository.ProjectsRepository) *TaskService {
+	return &TaskService{
+		taskRepo:    taskRepo,
+		projectRepo: projectRepo,
+	}
+}
+
+// CreateTask 在项目中创建任务，当前用户为任务创建人
+func (s *TaskService) CreateTask(ctx context.Context, projectID uint64, req dto.CreateTaskRequest) (*dto.TaskResponse, error) {
+	userID, err := contextutil.GetUserID(ctx)
+	if err != nil {
+		return nil, apperrors.ErrUnauthorized
+	}
+	project, err := s.findProject(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := checkProjectRole(ctx, s.projectRepo, project, entity.ProjectRoleMember); err != nil {
+		return nil, err
+	}
+	if err := checkProjectWritable(project); err != nil {
+		return nil, err
+	}
+	if err := s.checkAssignee(ctx, project, req.AssigneeID); err != nil {
+		return nil, err
+	}
+
+	task := entity.NewTask(project.ID, req.Title, req.Description, userID)
+	task.Assign(req.AssigneeID)
+	priority := task.Priority
+	if req.Priority != nil {
+		priority = entity.TaskPriority(*req.Priority)
+	}
+	task.SetPlan(priority, req.DueDate.Time, req.Estimate)
+	if req.Status != "" {
+		task.SetStatus(entity.TaskStatus(req.Status), time.Now())
+	}
+	// 新任务排在看板各列的末尾
+	last, err := s.taskRepo.MaxRank(ctx, project.ID)
+	if err != nil {
+		return nil, apperrors.NewAppError(500, "创建任务失败", err)
+	}
+	if task.Rank, err = rank.After(last); err != nil {
+		return nil, apperrors.NewAppError(500, "创建任务失败", err)
+	}
+
+	if err := s.taskRepo.Create(ctx, task); err != nil {
+		if errors.Is(err, entity.ErrWIPLimitReached) {
+			return nil, errWIPLimitReached
+		}
+		return nil, apperrors.NewAppError(500, "创建任务失败", err)
+	}
+	return toTaskResponse(task, time.Now()), nil
+}
+
+// GetTask 获取任务详情
+func (s *TaskService) GetTask(ctx context.Context, projectID, taskID uint64) (*dto.TaskResponse, error) {
+	project, err := s.findProject(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := checkProjectRole(ctx, s.projectRepo, project, entity.ProjectRoleViewer); err != nil {
+		return nil, err
+	}
+	task, err := s.findTask(ctx, project.ID, taskID)
+	if err != nil {
+		return nil, err
+	}
+	return toTaskResponse(task, time.Now()), nil
+}
+
+// ListTasks 分页获取项目内的任务
+func (s *TaskService) ListTasks(ctx context.Context, projectID uint64, req dto.ListTasksRequest) (*dto.TaskListResponse, error) {
+	project, err := s.findProject(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := checkProjectRole(ctx, s.projectRepo, project, entity.ProjectRoleViewer); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	filter := repository.TaskFilter{
+		ProjectID:  project.ID,
+		Status:     entity.TaskStatus(req.Status),
+		AssigneeID: req.AssigneeID,
+		ReporterID: req.ReporterID,
+		Unassigned: req.Unassigned,
+		Keyword:    req.Keyword,
+	}
+	if req.Priority != nil {
+		priority := entity.TaskPriority(*req.Priority)
+		filter.Priority = &priority
+	}
+	if req.Overdue {
+		filter.OverdueAt = now
+	}
+
+	tasks, total, err := s.taskRepo.List(ctx, filter, req.Page, req.GetPageSize())
+	if err != nil {
+		return nil, apperrors.NewAppError(500, "获取任务列表失败", err)
+	}
+	list := make([]*dto.TaskResponse, 0, len(tasks))
+	for _, task := range tasks {
+		list = append(list, toTaskResponse(task, now))
+	}
+	return &dto.TaskListResponse{
+		List: list,
+		Page: dto.PageResponse{
+			Page:     req.Page,
+			PageSize: req.GetPageSize(),
+			Total:    total,
+		},
+	}, nil
+}
+
+// UpdateTask 更新任务
+func (s *TaskService) UpdateTask(ctx context.Context, projectID, taskID uint64, req dto.UpdateTaskRequest) (*dto.TaskResponse, error) {
+	project, err := s.findProject(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := checkProjectRole(ctx, s.projectRepo, project, entity.ProjectRoleMember); err != nil {
+		return nil, err
+	}
+	if err := checkProjectWritable(project); err != nil {
+		return nil, err
+	}
+	task, err := s.findTask(ctx, project.ID, taskID)
+	if err != nil {
+		return nil, err
+	}
+	if req.AssigneeID != task.AssigneeID {
+		if err := s.checkAssignee(ctx, project, req.AssigneeID); err != nil {
+			return nil, err
+		}
+	}
+
+	now := time.Now()
+	task.UpdateBasicInfo(req.Title, req.Description)
+	task.Assign(req.AssigneeID)
+	task.SetStatus(entity.TaskStatus(req.Status), now)
+	task.SetPlan(entity.TaskPriority(req.Priority), req.DueDate.Time, req.Estimate)
+
+	if err := s.taskRepo.Update(ctx, task); err != nil {
+		if errors.Is(err, entity.ErrWIPLimitReached) {
+			return nil, errWIPLimitReached
+		}
+		if errors.Is(err, entity.ErrTaskNotFound) {
+			return nil, apperrors.ErrNotFound
+		}
+		return nil, apperrors.NewAppError(500, "更新任务失败", err)
+	}
+	return toTaskResponse(task, now), nil
+}
+
+// DeleteTask 删除任务，maintainer 及以上或任务创建人可以删除
+func (s *TaskService) DeleteTask(ctx context.Context, projectID, taskID uint64) error {
+	userID, err := contextutil.GetUserID(ctx)
+	if err != nil {
+		return apperrors.ErrUnauthorized
+	}
+	project, err := s.findProject(ctx, projectID)
+	if err != nil {
+		return err
+	}
+	role, err := checkProjectRole(ctx, s.projectRepo, project, entity.ProjectRoleMember)
+	if err != nil {
+		return err
+	}
+	if err := checkProjectWritable(project); err != nil {
+		return err
+	}
+	task, err := s.findTask(ctx, project.ID, taskID)
+	if err != nil {
+		return err
+	}
+	if !role.AtLeast(entity.ProjectRoleMaintainer) && task.ReporterID != userID {
+		return apperrors.ErrForbidden
+	}
+
+	if err := s.taskRepo.Delete(ctx, task.ID); err != nil {
+		return apperrors.NewAppError(500, "删除任务失败", err)
+	}
+	return nil
+}
+
+// checkAssignee 校验处理人是项目成员，assigneeID 为 0 表示不指派
+func (s *TaskService) checkAssignee(ctx context.Context, project *entity.Project, assigneeID uint64) error {
+	if assigneeID == 0 || assigneeID == project.OwnerID {
+		return nil
+	}
+	role, err := s.projectRepo.FindUserRole(ctx, project.ID, assigneeID)
+	if err != nil {
+		return apperrors.NewAppError(500, "查询项目成员失败", err)
+	}
+	if role == "" {
+		return apperrors.NewAppError(400, "处理人不是项目成员", nil)
+	}
+	return nil
+}
+
+func (s *TaskService) findProject(ctx context.Context, projectID uint64) (*entity.Project, error) {
+	project, err := s.projectRepo.FindByID(ctx, projectID)
+	if err != nil {
+		return nil, apperrors.NewAppError(500, "查找项目失败", err)
+	}
+	if project == nil {
+		return nil, apperrors.NewAppError(404, "项目不存在", nil)
+	}
+	return project, nil
+}
+
+// findTask 查找项目内的任务，任务不属于该项目时同样视为不存在
+func (s *TaskService) findTask(ctx context.Context, projectID, taskID uint64) (*entity.Task, error) {
+	task, err := s.taskRepo.FindByID(ctx, taskID)
+	if err != nil {
+		return nil, apperrors.NewAppError(500, "查找任务失败", err)
+	}
+	if task == nil || task.ProjectID != projectID {
+		return nil, apperrors.NewAppError(404, "任务不存在", nil)
+	}
+	return task, nil
+}
+
+func toTaskResponse(task *entity.Task, now time.Time) *dto.TaskResponse {
+	resp := &dto.TaskResponse{
+		ID:          task.ID,
+		ProjectID:   task.ProjectID,
+		Title:       task.Title,
+		Description: task.Description,
+		AssigneeID:  task.AssigneeID,
+		ReporterID:  task.ReporterID,
+		Status:      string(task.Status),
+		Priority:    int(task.Priority),
+		DueDate:     utils.NewTime(task.DueDate),
+		Estimate:    task.Estimate,
+		Rank:        task.Rank,
+		Overdue:     task.IsOverdue(now),
+		CreatedAt:   utils.NewTime(task.CreatedAt),
+		UpdatedAt:   utils.NewTime(task.UpdatedAt),
+	}
+	if task.CompletedAt != nil {
+		resp.CompletedAt = utils.NewTime(*task.CompletedAt)
+	}
+	return resp
+}
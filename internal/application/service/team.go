@@ -0,0 +1,404 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"FLOWGO/internal/application/dto"
+	"FLOWGO/internal/domain/entity"
+	"FLOWGO/internal/domain/permission"
+	"FLOWGO/internal/domain/repository"
+	"FLOWGO/pkg/contextutil"
+	apperrors "FLOWGO/pkg/errors"
+	"FLOWGO/pkg/utils"
+)
+
+// TeamService 团队服务
+type TeamService struct {
+	teamRepo repository.TeamRepository
+	userRepo repository.UserRepository
+}
+
+// NewTeamService 创建团队服务实例
+func NewTeamService(teamRepo repository.TeamRepository, userRepo repository.UserRepository) *TeamService {
+	return &TeamService{
+		teamRepo: teamRepo,
+		userRepo: userRepo,
+	}
+}
+
+// CreateTeam 创建团队，创建者成为团队负责人
+func (s *TeamService) CreateTeam(ctx context.Context, req dto.CreateTeamRequest) (*dto.TeamDetailResponse, error) {
+	userID, err := contextutil.GetUserID(ctx)
+	if err != nil {
+		return nil, apperrors.ErrUnauthorized
+	}
+	if err := s.checkNameAvailable(ctx, req.Name, 0); err != nil {
+		return nil, err
+	}
+
+	team := entity.NewTeam(req.Name, req.Description, userID)
+	if req.ParentID != 0 {
+		if err := s.checkParent(ctx, team, req.ParentID); err != nil {
+			return nil, err
+		}
+		team.MoveTo(req.ParentID)
+	}
+	if err := s.teamRepo.Create(ctx, team); err != nil {
+		return nil, apperrors.NewAppError(500, "创建团队失败", err)
+	}
+	return toTeamResponse(team), nil
+}
+
+// GetTeam 获取团队详情
+func (s *TeamService) GetTeam(ctx context.Context, id uint64) (*dto.TeamDetailResponse, error) {
+	team, err := s.findTeam(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return toTeamResponse(team), nil
+}
+
+// ListTeams 分页获取团队列表
+func (s *TeamService) ListTeams(ctx context.Context, req dto.PageRequest) (*dto.TeamListResponse, error) {
+	teams, total, err := s.teamRepo.List(ctx, req.Page, req.GetPageSize())
+	if err != nil {
+		return nil, apperrors.NewAppError(500, "查询团队列表失败", err)
+	}
+
+	list := make([]*dto.TeamDetailResponse, 0, len(teams))
+	for _, team := range teams {
+		list = append(list, toTeamResponse(team))
+	}
+	return &dto.TeamListResponse{
+		List: list,
+		Page: dto.PageResponse{
+			Page:     req.Page,
+			PageSize: req.GetPageSize(),
+			Total:    total,
+		},
+	}, nil
+}
+
+// GetTeamTree 获取完整的团队树，上级团队已删除的团队作为顶级团队返回
+func (s *TeamService) GetTeamTree(ctx context.Context) (*dto.TeamTreeResponse, error) {
+	teams, err := s.teamRepo.ListAvailableTeams(ctx)
+	if err != nil {
+		return nil, apperrors.NewAppError(500, "查询团队列表失败", err)
+	}
+	sort.Slice(teams, func(i, j int) bool { return teams[i].ID < teams[j].ID })
+
+	nodes := make(map[uint64]*dto.TeamTreeNode, len(teams))
+	for _, team := range teams {
+		nodes[team.ID] = &dto.TeamTreeNode{
+			ID:          team.ID,
+			Name:        team.Name,
+			Description: team.Description,
+			OwnerID:     team.OwnerId,
+			ParentID:    team.ParentId,
+			Children:    []*dto.TeamTreeNode{},
+		}
+	}
+	roots := make([]*dto.TeamTreeNode, 0)
+	for _, team := range teams {
+		node := nodes[team.ID]
+		if parent, ok := nodes[team.ParentId]; ok && team.ParentId != team.ID {
+			parent.Children = append(parent.Children, node)
+		} else {
+			roots = append(roots, node)
+		}
+	}
+	return &dto.TeamTreeResponse{Teams: roots}, nil
+}
+
+// UpdateTeam 更新团队信息，仅团队负责人和管理员可操作
+func (s *TeamService) UpdateTeam(ctx context.Context, id uint64, req dto.UpdateTeamRequest) (*dto.TeamDetailResponse, error) {
+	team, err := s.findTeam(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.requireTeamOwner(ctx, team); err != nil {
+		return nil, err
+	}
+	if err := s.checkNameAvailable(ctx, req.Name, team.ID); err != nil {
+		return nil, err
+	}
+
+	if req.ParentID != nil && *req.ParentID != team.ParentId {
+		if *req.ParentID != 0 {
+			if err := s.checkParent(ctx, team, *req.ParentID); err != nil {
+				return nil, err
+			}
+		}
+		team.MoveTo(*req.ParentID)
+	}
+
+	team.UpdateBasicInfo(req.Name, req.Description)
+	if err := s.teamRepo.Update(ctx, team); err != nil {
+		return nil, apperrors.NewAppError(500, "更新团队失败", err)
+	}
+	return toTeamResponse(team), nil
+}
+
+// DeleteTeam 删除团队，同时解除与项目的关联，仅团队负责人和管理员可操作
+func (s *TeamService) DeleteTeam(ctx context.Context, id uint64) error {
+	team, err := s.findTeam(ctx, id)
+	if err != nil {
+		return err
+	}
+	if err := s.requireTeamOwner(ctx, team); err != nil {
+		return err
+	}
+
+	if err := s.teamRepo.Delete(ctx, team.ID); err != nil {
+		return apperrors.NewAppError(500, "删除团队失败", err)
+	}
+	return nil
+}
+
+// TransferOwner 转移团队负责人，仅团队负责人和管理员可操作
+func (s *TeamService) TransferOwner(ctx context.Context, id uint64, req dto.TransferTeamOwnerRequest) (*dto.TeamDetailResponse, error) {
+	team, err := s.findTeam(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.requireTeamOwner(ctx, team); err != nil {
+		return nil, err
+	}
+	if team.IsOwner(req.OwnerID) {
+		return nil, apperrors.NewAppError(400, "该用户已是团队负责人", nil)
+	}
+
+	owner, err := s.userRepo.FindByID(ctx, req.OwnerID)
+	if err != nil {
+		return nil, apperrors.NewAppError(500, "查询用户失败", err)
+	}
+	if owner == nil || !owner.IsActive() {
+		return nil, apperrors.NewAppError(400, "新负责人不存在或已被禁用", nil)
+	}
+	// 团队负责人需要能够管理团队，否则转移后无人可以维护
+	if !permission.ParseRole(owner.Role).Can(permission.TeamManage) {
+		return nil, apperrors.NewAppError(400, "新负责人没有团队管理权限", nil)
+	}
+
+	team.TransferOwnership(owner.ID)
+	if err := s.teamRepo.TransferOwner(ctx, team); err != nil {
+		return nil, apperrors.NewAppError(500, "转移团队负责人失败", err)
+	}
+	return toTeamResponse(team), nil
+}
+
+// ListMembers 获取团队成员及其团队角色
+func (s *TeamService) ListMembers(ctx context.Context, id uint64) (*dto.TeamMembersResponse, error) {
+	team, err := s.findTeam(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return s.members(ctx, team.ID)
+}
+
+// AddMembers 添加团队成员，仅团队负责人、lead 和管理员可操作
+func (s *TeamService) AddMembers(ctx context.Context, id uint64, req dto.AddTeamMembersRequest) (*dto.TeamMembersResponse, error) {
+	team, err := s.findTeam(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.requireTeamLead(ctx, team); err != nil {
+		return nil, err
+	}
+
+	role := entity.TeamRoleMember
+	if req.Role != "" {
+		role = entity.TeamRole(req.Role)
+	}
+	for _, userID := range req.Users {
+		user, err := s.userRepo.FindByID(ctx, userID)
+		if err != nil {
+			return nil, apperrors.NewAppError(500, "查询用户失败", err)
+		}
+		if user == nil || !user.IsActive() {
+			return nil, apperrors.NewAppError(400, fmt.Sprintf("用户 %d 不存在或已被禁用", userID), nil)
+		}
+	}
+
+	if err := s.teamRepo.AddMembers(ctx, team.ID, req.Users, role); err != nil {
+		return nil, apperrors.NewAppError(500, "添加团队成员失败", err)
+	}
+	return s.members(ctx, team.ID)
+}
+
+// UpdateMemberRole 修改团队成员角色，团队负责人始终为 lead
+func (s *TeamService) UpdateMemberRole(ctx context.Context, id, userID uint64, req dto.UpdateTeamMemberRoleRequest) (*dto.TeamMembersResponse, error) {
+	team, err := s.findTeam(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.requireTeamLead(ctx, team); err != nil {
+		return nil, err
+	}
+	if err := s.requireMember(ctx, team.ID, userID); err != nil {
+		return nil, err
+	}
+
+	role := entity.TeamRole(req.Role)
+	if team.IsOwner(userID) && role != entity.TeamRoleLead {
+		return nil, apperrors.NewAppError(400, "团队负责人的角色必须为 lead", nil)
+	}
+	if err := s.teamRepo.SetMemberRole(ctx, team.ID, userID, role); err != nil {
+		return nil, apperrors.NewAppError(500, "修改团队成员角色失败", err)
+	}
+	return s.members(ctx, team.ID)
+}
+
+// RemoveMember 移除团队成员，团队负责人需要先转移负责人才能移除
+func (s *TeamService) RemoveMember(ctx context.Context, id, userID uint64) error {
+	team, err := s.findTeam(ctx, id)
+	if err != nil {
+		return err
+	}
+	if err := s.requireTeamLead(ctx, team); err != nil {
+		return err
+	}
+	if team.IsOwner(userID) {
+		return apperrors.NewAppError(400, "不能移除团队负责人", nil)
+	}
+	if err := s.requireMember(ctx, team.ID, userID); err != nil {
+		return err
+	}
+
+	if err := s.teamRepo.RemoveMember(ctx, team.ID, userID); err != nil {
+		return apperrors.NewAppError(500, "移除团队成员失败", err)
+	}
+	return nil
+}
+
+// members 查询团队成员列表
+func (s *TeamService) members(ctx context.Context, teamID uint64) (*dto.TeamMembersResponse, error) {
+	users, err := s.teamRepo.ListMembers(ctx, teamID)
+	if err != nil {
+		return nil, apperrors.NewAppError(500, "查询团队成员失败", err)
+	}
+	roles, err := s.teamRepo.ListMemberRoles(ctx, teamID)
+	if err != nil {
+		return nil, apperrors.NewAppError(500, "查询团队成员失败", err)
+	}
+
+	userResponses := make([]*dto.UserResponse, 0, len(users))
+	for _, user := range users {
+		userResponses = append(userResponses, &dto.UserResponse{
+			ID:          user.ID,
+			Name:        user.Name,
+			DisplayName: user.DisplayName,
+			Email:       user.Email,
+			Avatar:      user.Avatar,
+			TeamID:      user.TeamID,
+			Role:        user.Role,
+			TeamRole:    string(roles[user.ID]),
+			Status:      user.Status,
+
+			EmailVerified:    user.EmailVerified,
+			TwoFactorEnabled: user.TwoFactorEnabled,
+		})
+	}
+	return &dto.TeamMembersResponse{Users: userResponses}, nil
+}
+
+// findTeam 查找团队，不存在时返回404
+func (s *TeamService) findTeam(ctx context.Context, id uint64) (*entity.Team, error) {
+	team, err := s.teamRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, apperrors.NewAppError(500, "查询团队失败", err)
+	}
+	if team == nil {
+		return nil, apperrors.NewAppError(404, "团队不存在", nil)
+	}
+	return team, nil
+}
+
+// requireTeamOwner 校验当前用户是团队负责人或全局管理员
+func (s *TeamService) requireTeamOwner(ctx context.Context, team *entity.Team) error {
+	userID, err := contextutil.GetUserID(ctx)
+	if err != nil {
+		return apperrors.ErrUnauthorized
+	}
+	if team.IsOwner(userID) || permission.ParseRole(contextutil.GetRole(ctx)) == permission.RoleAdmin {
+		return nil
+	}
+	return apperrors.ErrForbidden
+}
+
+// requireTeamLead 校验当前用户是团队负责人、团队 lead 或全局管理员
+func (s *TeamService) requireTeamLead(ctx context.Context, team *entity.Team) error {
+	if err := s.requireTeamOwner(ctx, team); err != apperrors.ErrForbidden {
+		return err
+	}
+	userID, _ := contextutil.GetUserID(ctx)
+	role, err := s.teamRepo.FindMemberRole(ctx, team.ID, userID)
+	if err != nil {
+		return apperrors.NewAppError(500, "查询团队成员失败", err)
+	}
+	if role != entity.TeamRoleLead {
+		return apperrors.ErrForbidden
+	}
+	return nil
+}
+
+// requireMember 校验用户是团队成员
+func (s *TeamService) requireMember(ctx context.Context, teamID, userID uint64) error {
+	role, err := s.teamRepo.FindMemberRole(ctx, teamID, userID)
+	if err != nil {
+		return apperrors.NewAppError(500, "查询团队成员失败", err)
+	}
+	if role == "" {
+		return apperrors.NewAppError(404, "该用户不是团队成员", nil)
+	}
+	return nil
+}
+
+// checkParent 校验上级团队存在、不会形成环，且当前用户能管理上级团队
+func (s *TeamService) checkParent(ctx context.Context, team *entity.Team, parentID uint64) error {
+	parent, err := s.teamRepo.FindByID(ctx, parentID)
+	if err != nil {
+		return apperrors.NewAppError(500, "查询团队失败", err)
+	}
+	if parent == nil {
+		return apperrors.NewAppError(400, "上级团队不存在", nil)
+	}
+	if team.ID != 0 {
+		subtree, err := s.teamRepo.ListSubtreeIDs(ctx, team.ID)
+		if err != nil {
+			return apperrors.NewAppError(500, "查询下级团队失败", err)
+		}
+		for _, id := range subtree {
+			if id == parentID {
+				return apperrors.NewAppError(400, "不能将团队移动到自身或其下级团队下", nil)
+			}
+		}
+	}
+	return s.requireTeamLead(ctx, parent)
+}
+
+// checkNameAvailable 检查团队名称是否可用
+func (s *TeamService) checkNameAvailable(ctx context.Context, name string, excludeID uint64) error {
+	exists, err := s.teamRepo.ExistsByName(ctx, name, excludeID)
+	if err != nil {
+		return apperrors.NewAppError(500, "检查团队名称失败", err)
+	}
+	if exists {
+		return apperrors.NewAppError(400, "团队名称已存在", nil)
+	}
+	return nil
+}
+
+func toTeamResponse(team *entity.Team) *dto.TeamDetailResponse {
+	return &dto.TeamDetailResponse{
+		ID:          team.ID,
+		Name:        team.Name,
+		Description: team.Description,
+		OwnerID:     team.OwnerId,
+		ParentID:    team.ParentId,
+		CreatedAt:   utils.NewTime(team.CreatedAt),
+		UpdatedAt:   utils.NewTime(team.UpdatedAt),
+	}
+}
@@ -0,0 +1,162 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"FLOWGO/internal/application/dto"
+	"FLOWGO/internal/domain/entity"
+	"FLOWGO/internal/domain/permission"
+	"FLOWGO/internal/domain/repository"
+	"FLOWGO/pkg/contextutil"
+	apperrors "FLOWGO/pkg/errors"
+	"FLOWGO/pkg/utils"
+)
+
+// TrashService 回收站服务：查看和恢复已删除的项目和用户，超过保留期的由后台任务永久清除
+type TrashService struct {
+	projectRepo repository.ProjectsRepository
+	userRepo    repository.UserRepository
+	retention   time.Duration
+}
+
+// NewTrashService 创建回收站服务实例，retention 为删除后保留的时长
+func NewTrashService(projectRepo repository.ProjectsRepository, userRepo repository.UserRepository, retention time.Duration) *TrashService {
+	return &TrashService{
+		projectRepo: projectRepo,
+		userRepo:    userRepo,
+		retention:   retention,
+	}
+}
+
+// ListProjects 查询回收站中的项目，管理员可以看到全部，其他用户只能看到自己负责的项目
+func (s *TrashService) ListProjects(ctx context.Context, req dto.ListTrashRequest) (*dto.TrashProjectListResponse, error) {
+	userID, err := contextutil.GetUserID(ctx)
+	if err != nil {
+		return nil, apperrors.ErrUnauthorized
+	}
+	var ownerID uint64
+	if permission.ParseRole(contextutil.GetRole(ctx)) != permission.RoleAdmin {
+		ownerID = userID
+	}
+
+	projects, total, err := s.projectRepo.ListDeleted(ctx, ownerID, req.Page, req.GetPageSize())
+	if err != nil {
+		return nil, apperrors.NewAppError(500, "查询回收站失败", err)
+	}
+	list := make([]*dto.TrashProjectResponse, len(projects))
+	for i, project := range projects {
+		list[i] = &dto.TrashProjectResponse{
+			ID:        project.ID,
+			Name:      project.Name,
+			OwnerID:   project.OwnerID,
+			Status:    int(project.Status),
+			DeletedAt: utils.NewTime(*project.DeletedAt),
+			PurgeAt:   utils.NewTime(s.purgeAt(*project.DeletedAt)),
+		}
+	}
+	return &dto.TrashProjectListResponse{
+		List: list,
+		Page: dto.PageResponse{
+			Page:     req.Page,
+			PageSize: req.GetPageSize(),
+			Total:    total,
+		},
+	}, nil
+}
+
+// RestoreProject 恢复回收站中的项目，连同删除时一起移除的团队和成员关联；需要是项目负责人或管理员
+func (s *TrashService) RestoreProject(ctx context.Context, id uint64) error {
+	project, err := s.projectRepo.FindDeletedByID(ctx, id)
+	if err != nil {
+		return apperrors.NewAppError(500, "查询回收站失败", err)
+	}
+	if project == nil {
+		return apperrors.NewAppError(404, "回收站中没有该项目", nil)
+	}
+	if _, err := checkProjectRole(ctx, s.projectRepo, project, entity.ProjectRoleOwner); err != nil {
+		return err
+	}
+	if err := s.projectRepo.Restore(ctx, project.ID); err != nil {
+		return apperrors.NewAppError(500, "恢复项目失败", err)
+	}
+	return nil
+}
+
+// ListUsers 查询回收站中的用户
+func (s *TrashService) ListUsers(ctx context.Context, req dto.ListTrashRequest) (*dto.TrashUserListResponse, error) {
+	users, total, err := s.userRepo.ListDeleted(ctx, req.Page, req.GetPageSize())
+	if err != nil {
+		return nil, apperrors.NewAppError(500, "查询回收站失败", err)
+	}
+	list := make([]*dto.TrashUserResponse, len(users))
+	for i, user := range users {
+		list[i] = &dto.TrashUserResponse{
+			ID:          user.ID,
+			Name:        user.Name,
+			DisplayName: user.DisplayName,
+			Email:       user.Email,
+			DeletedAt:   utils.NewTime(*user.DeletedAt),
+			PurgeAt:     utils.NewTime(s.purgeAt(*user.DeletedAt)),
+		}
+	}
+	return &dto.TrashUserListResponse{
+		List: list,
+		Page: dto.PageResponse{
+			Page:     req.Page,
+			PageSize: req.GetPageSize(),
+			Total:    total,
+		},
+	}, nil
+}
+
+// RestoreUser 恢复回收站中的用户，恢复后需要重新登录
+func (s *TrashService) RestoreUser(ctx context.Context, id uint64) error {
+	user, err := s.userRepo.FindByID(ctx, id)
+	if err != nil {
+		return apperrors.NewAppError(500, "查询回收站失败", err)
+	}
+	if user == nil || !user.IsDeleted() {
+		return apperrors.NewAppError(404, "回收站中没有该用户", nil)
+	}
+	// 删除后用户名和邮箱可能已被新用户使用
+	nameTaken, err := s.userRepo.ExistsByName(ctx, user.Name)
+	if err != nil {
+		return apperrors.NewAppError(500, "检查用户名失败", err)
+	}
+	emailTaken, err := s.userRepo.ExistsByEmail(ctx, user.Email)
+	if err != nil {
+		return apperrors.NewAppError(500, "检查邮箱失败", err)
+	}
+	if nameTaken || emailTaken {
+		return apperrors.NewAppError(409, "用户名或邮箱已被其他用户使用，无法恢复", nil)
+	}
+	if err := s.userRepo.Restore(ctx, user.ID); err != nil {
+		return apperrors.NewAppError(500, "恢复用户失败", err)
+	}
+	return nil
+}
+
+// PurgeExpired 永久删除超过保留期的项目和用户，由定时任务调用
+// 先清理项目，这样随项目一起过期的负责人也能在同一次清理中删除
+func (s *TrashService) PurgeExpired(ctx context.Context) (*dto.PurgeTrashResult, error) {
+	cutoff := time.Now().Add(-s.retention)
+	result := &dto.PurgeTrashResult{}
+
+	projects, err := s.projectRepo.PurgeDeletedBefore(ctx, cutoff)
+	result.Projects = projects
+	if err != nil {
+		return result, apperrors.NewAppError(500, "清理回收站项目失败", err)
+	}
+	users, err := s.userRepo.PurgeDeletedBefore(ctx, cutoff)
+	result.Users = users
+	if err != nil {
+		return result, apperrors.NewAppError(500, "清理回收站用户失败", err)
+	}
+	return result, nil
+}
+
+// purgeAt 计算回收站中的条目被永久删除的时间
+func (s *TrashService) purgeAt(deletedAt time.Time) time.Time {
+	return deletedAt.Add(s.retention)
+}
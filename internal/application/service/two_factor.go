@@ -0,0 +1,243 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"FLOWGO/internal/application/dto"
+	"FLOWGO/internal/domain/entity"
+	"FLOWGO/internal/domain/repository"
+	"FLOWGO/pkg/contextutil"
+	apperrors "FLOWGO/pkg/errors"
+	"FLOWGO/pkg/totp"
+	"FLOWGO/pkg/utils"
+)
+
+const (
+	totpIssuer        = "FlowGo"
+	recoveryCodeCount = 10
+)
+
+// TwoFactorService 两步验证服务：TOTP 绑定、解绑和恢复码
+// TOTP 密钥加密后保存
+type TwoFactorService struct {
+	userRepo         repository.UserRepository
+	recoveryCodeRepo repository.RecoveryCodeRepository
+	secrets          *utils.SecretBox
+}
+
+// NewTwoFactorService 创建两步验证服务实例
+func NewTwoFactorService(
+	userRepo repository.UserRepository,
+	recoveryCodeRepo repository.RecoveryCodeRepository,
+	secrets *utils.SecretBox,
+) *TwoFactorService {
+	return &TwoFactorService{
+		userRepo:         userRepo,
+		recoveryCodeRepo: recoveryCodeRepo,
+		secrets:          secrets,
+	}
+}
+
+// Setup 生成新的TOTP密钥，需要调用 Confirm 校验一次验证码后才会生效
+func (s *TwoFactorService) Setup(ctx context.Context) (*dto.TwoFactorSetupResponse, error) {
+	user, err := s.currentUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if user.TwoFactorEnabled {
+		return nil, apperrors.NewAppError(400, "两步验证已开启", nil)
+	}
+
+	secret, err := totp.GenerateSecret()
+	if err != nil {
+		return nil, apperrors.NewAppError(500, "生成密钥失败", err)
+	}
+	sealed, err := s.secrets.Seal(secret)
+	if err != nil {
+		return nil, apperrors.NewAppError(500, "加密密钥失败", err)
+	}
+	user.TwoFactorSecret = sealed
+	user.TwoFactorLastStep = 0
+	if err := s.userRepo.UpdateTwoFactor(ctx, user, nil); err != nil {
+		return nil, apperrors.NewAppError(500, "保存密钥失败", err)
+	}
+
+	return &dto.TwoFactorSetupResponse{
+		Secret: secret,
+		URI:    totp.URI(totpIssuer, user.Name, secret),
+	}, nil
+}
+
+// Confirm 校验验证码并开启两步验证，返回一组恢复码
+func (s *TwoFactorService) Confirm(ctx context.Context, req dto.TwoFactorConfirmRequest) (*dto.RecoveryCodesResponse, error) {
+	user, err := s.currentUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if user.TwoFactorEnabled {
+		return nil, apperrors.NewAppError(400, "两步验证已开启", nil)
+	}
+	if user.TwoFactorSecret == "" {
+		return nil, apperrors.NewAppError(400, "请先设置两步验证", nil)
+	}
+
+	secret, err := s.openSecret(user)
+	if err != nil {
+		return nil, err
+	}
+	step, ok := totp.Validate(secret, req.Code, time.Now())
+	if !ok {
+		return nil, apperrors.NewAppError(400, "验证码错误", nil)
+	}
+
+	codes, hashes, err := generateRecoveryCodes()
+	if err != nil {
+		return nil, err
+	}
+
+	// 开启状态和恢复码在同一事务中写入
+	user.TwoFactorEnabled = true
+	user.TwoFactorLastStep = step
+	if err := s.userRepo.UpdateTwoFactor(ctx, user, hashes); err != nil {
+		return nil, apperrors.NewAppError(500, "开启两步验证失败", err)
+	}
+	return &dto.RecoveryCodesResponse{RecoveryCodes: codes}, nil
+}
+
+// Disable 关闭两步验证，需要同时提供密码和验证码（或恢复码）
+func (s *TwoFactorService) Disable(ctx context.Context, req dto.TwoFactorDisableRequest) error {
+	user, err := s.currentUser(ctx)
+	if err != nil {
+		return err
+	}
+	if !user.TwoFactorEnabled {
+		return apperrors.NewAppError(400, "两步验证未开启", nil)
+	}
+	if !utils.CheckPassword(req.Password, user.Password) {
+		return apperrors.NewAppError(400, "密码错误", nil)
+	}
+	if err := s.Verify(ctx, user, req.Code); err != nil {
+		return err
+	}
+
+	// 清除密钥的同时删除全部恢复码
+	user.ClearTwoFactor()
+	if err := s.userRepo.UpdateTwoFactor(ctx, user, []string{}); err != nil {
+		return apperrors.NewAppError(500, "关闭两步验证失败", err)
+	}
+	return nil
+}
+
+// RegenerateRecoveryCodes 重新生成恢复码，原有恢复码全部失效
+func (s *TwoFactorService) RegenerateRecoveryCodes(ctx context.Context, req dto.RecoveryCodesRequest) (*dto.RecoveryCodesResponse, error) {
+	user, err := s.currentUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if !user.TwoFactorEnabled {
+		return nil, apperrors.NewAppError(400, "两步验证未开启", nil)
+	}
+	if err := s.verifyTOTP(ctx, user, req.Code); err != nil {
+		return nil, err
+	}
+
+	codes, hashes, err := generateRecoveryCodes()
+	if err != nil {
+		return nil, err
+	}
+	if err := s.recoveryCodeRepo.Replace(ctx, user.ID, hashes); err != nil {
+		return nil, apperrors.NewAppError(500, "保存恢复码失败", err)
+	}
+	return &dto.RecoveryCodesResponse{RecoveryCodes: codes}, nil
+}
+
+// Verify 校验第二因素，code 可以是TOTP验证码或恢复码
+func (s *TwoFactorService) Verify(ctx context.Context, user *entity.User, code string) error {
+	code = strings.TrimSpace(code)
+	if len(code) == totp.Digits {
+		return s.verifyTOTP(ctx, user, code)
+	}
+
+	used, err := s.recoveryCodeRepo.Use(ctx, user.ID, utils.HashToken(normalizeRecoveryCode(code)), time.Now())
+	if err != nil {
+		return apperrors.NewAppError(500, "校验恢复码失败", err)
+	}
+	if !used {
+		return apperrors.NewAppError(401, "验证码错误", nil)
+	}
+	return nil
+}
+
+// verifyTOTP 校验TOTP验证码，同一时间步的验证码只能使用一次
+func (s *TwoFactorService) verifyTOTP(ctx context.Context, user *entity.User, code string) error {
+	secret, err := s.openSecret(user)
+	if err != nil {
+		return err
+	}
+	step, ok := totp.Validate(secret, code, time.Now())
+	if !ok || step <= user.TwoFactorLastStep {
+		return apperrors.NewAppError(401, "验证码错误", nil)
+	}
+
+	advanced, err := s.userRepo.AdvanceTwoFactorStep(ctx, user.ID, step)
+	if err != nil {
+		return apperrors.NewAppError(500, "更新用户失败", err)
+	}
+	if !advanced {
+		return apperrors.NewAppError(401, "验证码错误", nil)
+	}
+	user.TwoFactorLastStep = step
+	return nil
+}
+
+// openSecret 解密用户保存的TOTP密钥
+func (s *TwoFactorService) openSecret(user *entity.User) (string, error) {
+	secret, err := s.secrets.Open(user.TwoFactorSecret)
+	if err != nil {
+		return "", apperrors.NewAppError(500, "解密两步验证密钥失败", err)
+	}
+	return secret, nil
+}
+
+// generateRecoveryCodes 生成一组新的恢复码，返回明文和对应的摘要
+func generateRecoveryCodes() (codes, hashes []string, err error) {
+	codes = make([]string, 0, recoveryCodeCount)
+	hashes = make([]string, 0, recoveryCodeCount)
+	for i := 0; i < recoveryCodeCount; i++ {
+		raw, err := utils.GenerateRandomToken(5)
+		if err != nil {
+			return nil, nil, apperrors.NewAppError(500, "生成恢复码失败", err)
+		}
+		codes = append(codes, raw[:5]+"-"+raw[5:])
+		hashes = append(hashes, utils.HashToken(raw))
+	}
+	return codes, hashes, nil
+}
+
+// currentUser 获取当前登录用户，个人访问令牌不能管理两步验证
+func (s *TwoFactorService) currentUser(ctx context.Context) (*entity.User, error) {
+	userID, err := contextutil.GetUserID(ctx)
+	if err != nil {
+		return nil, apperrors.ErrUnauthorized
+	}
+	if contextutil.GetScopes(ctx) != nil {
+		return nil, apperrors.ErrForbidden
+	}
+
+	user, err := s.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		return nil, apperrors.NewAppError(500, "查询用户失败", err)
+	}
+	if user == nil {
+		return nil, apperrors.ErrNotFound
+	}
+	return user, nil
+}
+
+// normalizeRecoveryCode 去掉恢复码中的分隔符和空白并转为小写
+func normalizeRecoveryCode(code string) string {
+	code = strings.ToLower(code)
+	return strings.NewReplacer("-", "", " ", "").Replace(code)
+}
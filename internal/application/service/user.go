@@ -2,23 +2,31 @@ package service
 
 import (
 	"context"
+	"time"
 
 	"FLOWGO/internal/application/dto"
 	"FLOWGO/internal/domain/entity"
+	"FLOWGO/internal/domain/permission"
 	"FLOWGO/internal/domain/repository"
+	"FLOWGO/pkg/contextutil"
 	apperrors "FLOWGO/pkg/errors"
+	"FLOWGO/pkg/jsonpatch"
 	"FLOWGO/pkg/utils"
 )
 
 // UserService 用户服务
 type UserService struct {
-	userRepo repository.UserRepository
+	userRepo    repository.UserRepository
+	oneTimeRepo repository.OneTimeTokenRepository
+	authService *AuthService
 }
 
 // 创建用户服务实例
-func NewUserService(userRepo repository.UserRepository) *UserService {
+func NewUserService(userRepo repository.UserRepository, oneTimeRepo repository.OneTimeTokenRepository, authService *AuthService) *UserService {
 	return &UserService{
-		userRepo: userRepo,
+		userRepo:    userRepo,
+		oneTimeRepo: oneTimeRepo,
+		authService: authService,
 	}
 }
 
@@ -48,6 +56,7 @@ func (uc *UserService) CreateUser(ctx context.Context, req dto.CreateUserRequest
 		Email:    req.Email,
 		Password: hashedPassword,
 		Status:   1,
+		Role:     string(permission.ParseRole(req.Role)),
 	}
 
 	// 保存用户
@@ -55,12 +64,7 @@ func (uc *UserService) CreateUser(ctx context.Context, req dto.CreateUserRequest
 		return nil, apperrors.NewAppError(500, "创建用户失败", err)
 	}
 
-	return &dto.UserResponse{
-		ID:     user.ID,
-		Name:   user.Name,
-		Email:  user.Email,
-		Status: user.Status,
-	}, nil
+	return toUserResponse(user), nil
 }
 
 // GetUser 获取用户
@@ -69,33 +73,33 @@ func (uc *UserService) GetUser(ctx context.Context, id uint64) (*dto.UserRespons
 	if err != nil {
 		return nil, apperrors.NewAppError(500, "查询用户失败", err)
 	}
-	if user == nil {
+	if user == nil || user.IsDeleted() {
 		return nil, apperrors.ErrNotFound
 	}
 
-	return &dto.UserResponse{
-		ID:     user.ID,
-		Name:   user.Name,
-		Email:  user.Email,
-		Status: user.Status,
-	}, nil
+	return toUserResponse(user), nil
 }
 
-// ListUsers 获取用户列表
-func (uc *UserService) ListUsers(ctx context.Context, req dto.PageRequest) (*dto.UserListResponse, error) {
-	users, total, err := uc.userRepo.List(ctx, req.Page, req.GetPageSize())
+// ListUsers 分页获取用户列表，可按状态、角色和团队过滤，支持关键字搜索和排序
+func (uc *UserService) ListUsers(ctx context.Context, req dto.ListUsersRequest) (*dto.UserListResponse, error) {
+	sort, err := repository.ParseSort(req.Sort, repository.UserSortFields)
+	if err != nil {
+		return nil, apperrors.NewAppError(400, "不支持的排序字段", err)
+	}
+	filter := repository.UserFilter{
+		QuerySpec: repository.QuerySpec{Keyword: req.Keyword, Sort: sort},
+		Status:    req.Status,
+		Role:      req.Role,
+		TeamID:    req.TeamID,
+	}
+	users, total, err := uc.userRepo.ListByFilter(ctx, filter, req.Page, req.GetPageSize())
 	if err != nil {
 		return nil, apperrors.NewAppError(500, "查询用户列表失败", err)
 	}
 
 	userResponses := make([]*dto.UserResponse, 0, len(users))
 	for _, user := range users {
-		userResponses = append(userResponses, &dto.UserResponse{
-			ID:     user.ID,
-			Name:   user.Name,
-			Email:  user.Email,
-			Status: user.Status,
-		})
+		userResponses = append(userResponses, toUserResponse(user))
 	}
 
 	return &dto.UserListResponse{
@@ -107,3 +111,280 @@ func (uc *UserService) ListUsers(ctx context.Context, req dto.PageRequest) (*dto
 		},
 	}, nil
 }
+
+// GetProfile 获取当前用户的个人资料
+func (uc *UserService) GetProfile(ctx context.Context) (*dto.UserResponse, error) {
+	userID, err := contextutil.GetUserID(ctx)
+	if err != nil {
+		return nil, apperrors.ErrUnauthorized
+	}
+	return uc.GetUser(ctx, userID)
+}
+
+// UpdateProfile 更新当前用户的显示名称和头像
+func (uc *UserService) UpdateProfile(ctx context.Context, req dto.UpdateProfileRequest) (*dto.UserResponse, error) {
+	user, err := uc.currentUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	user.UpdateProfile(req.DisplayName, req.Avatar)
+	if err := uc.userRepo.Update(ctx, user); err != nil {
+		return nil, apperrors.NewAppError(500, "更新个人资料失败", err)
+	}
+	return toUserResponse(user), nil
+}
+
+// ChangePassword 校验当前密码后修改密码，吊销全部已有会话并为当前客户端签发新的Token
+func (uc *UserService) ChangePassword(ctx context.Context, req dto.ChangePasswordRequest) (*dto.LoginResponse, error) {
+	user, err := uc.currentUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if !utils.CheckPassword(req.CurrentPassword, user.Password) {
+		return nil, apperrors.NewAppError(400, "当前密码错误", nil)
+	}
+
+	hashedPassword, err := utils.HashPassword(req.NewPassword)
+	if err != nil {
+		return nil, apperrors.NewAppError(500, "密码加密失败", err)
+	}
+	user.Password = hashedPassword
+	if err := uc.userRepo.Update(ctx, user); err != nil {
+		return nil, apperrors.NewAppError(500, "修改密码失败", err)
+	}
+	if err := uc.authService.RevokeUserSessions(ctx, user.ID); err != nil {
+		return nil, apperrors.NewAppError(500, "吊销登录会话失败", err)
+	}
+	return uc.authService.issueTokens(user)
+}
+
+// UpdateUser 管理员更新用户信息，修改角色或禁用时吊销该用户的全部会话
+func (uc *UserService) UpdateUser(ctx context.Context, id uint64, req dto.UpdateUserRequest) (*dto.UserResponse, error) {
+	user, err := uc.findManagedUser(ctx, id, req.Role != "" || req.Status != 0)
+	if err != nil {
+		return nil, err
+	}
+
+	emailChanged := req.Email != "" && req.Email != user.Email
+	if emailChanged {
+		exists, err := uc.userRepo.ExistsByEmail(ctx, req.Email)
+		if err != nil {
+			return nil, apperrors.NewAppError(500, "检查邮箱失败", err)
+		}
+		if exists {
+			return nil, apperrors.NewAppError(400, "邮箱已存在", nil)
+		}
+		user.ChangeEmail(req.Email)
+	}
+	if req.DisplayName != "" {
+		user.DisplayName = req.DisplayName
+	}
+
+	// 角色写在访问Token中，变更后需要重新登录才能生效
+	roleChanged := req.Role != "" && req.Role != user.Role
+	if roleChanged {
+		user.Role = req.Role
+	}
+	if req.Status != 0 {
+		user.Status = req.Status
+	}
+
+	return uc.saveManagedUser(ctx, user, roleChanged, emailChanged)
+}
+
+// PatchUser 按 JSON Merge Patch 或 JSON Patch 部分更新用户，可修改的字段与 UpdateUser 相同
+func (uc *UserService) PatchUser(ctx context.Context, id uint64, patch jsonpatch.Patch) (*dto.UserResponse, error) {
+	user, err := uc.findManagedUser(ctx, id, false)
+	if err != nil {
+		return nil, err
+	}
+	current := dto.UserPatchDocument{
+		Email:       user.Email,
+		DisplayName: user.DisplayName,
+		Role:        string(permission.ParseRole(user.Role)),
+		Status:      user.Status,
+	}
+	var patched dto.UserPatchDocument
+	if err := applyPatch(patch, current, &patched); err != nil {
+		return nil, err
+	}
+	if patched == current {
+		return toUserResponse(user), nil
+	}
+
+	roleChanged := patched.Role != current.Role
+	if roleChanged || patched.Status != current.Status {
+		if err := checkNotSelf(ctx, id); err != nil {
+			return nil, err
+		}
+	}
+	emailChanged := patched.Email != current.Email
+	if emailChanged {
+		exists, err := uc.userRepo.ExistsByEmail(ctx, patched.Email)
+		if err != nil {
+			return nil, apperrors.NewAppError(500, "检查邮箱失败", err)
+		}
+		if exists {
+			return nil, apperrors.NewAppError(400, "邮箱已存在", nil)
+		}
+		user.ChangeEmail(patched.Email)
+	}
+	if patched.DisplayName != current.DisplayName {
+		user.DisplayName = patched.DisplayName
+	}
+	if roleChanged {
+		user.Role = patched.Role
+	}
+	if patched.Status != current.Status {
+		user.Status = patched.Status
+	}
+
+	return uc.saveManagedUser(ctx, user, roleChanged, emailChanged)
+}
+
+// saveManagedUser 保存管理员对用户的修改，禁用后吊销全部会话，角色变更后需要重新登录
+// 邮箱变更后作废发往旧邮箱的重置密码和邮箱验证链接
+func (uc *UserService) saveManagedUser(ctx context.Context, user *entity.User, roleChanged, emailChanged bool) (*dto.UserResponse, error) {
+	if err := uc.userRepo.Update(ctx, user); err != nil {
+		return nil, apperrors.NewAppError(500, "更新用户失败", err)
+	}
+	if emailChanged {
+		now := time.Now()
+		for _, purpose := range []entity.OneTimeTokenPurpose{entity.OneTimeTokenPasswordReset, entity.OneTimeTokenEmailVerify} {
+			if err := uc.oneTimeRepo.InvalidateByUser(ctx, user.ID, purpose, now); err != nil {
+				return nil, apperrors.NewAppError(500, "作废令牌失败", err)
+			}
+		}
+	}
+	var err error
+	switch {
+	case !user.IsActive():
+		err = uc.authService.LockOutUser(ctx, user.ID)
+	case roleChanged:
+		err = uc.authService.RevokeUserSessions(ctx, user.ID)
+	}
+	if err != nil {
+		return nil, apperrors.NewAppError(500, "吊销登录会话失败", err)
+	}
+	return toUserResponse(user), nil
+}
+
+// DisableUser 禁用用户并立即吊销其全部会话，个人访问令牌在下次使用时失效
+func (uc *UserService) DisableUser(ctx context.Context, id uint64) (*dto.UserResponse, error) {
+	user, err := uc.findManagedUser(ctx, id, true)
+	if err != nil {
+		return nil, err
+	}
+	if user.Status == entity.UserStatusDisabled {
+		return nil, apperrors.NewAppError(400, "用户已被禁用", nil)
+	}
+
+	user.Disable()
+	if err := uc.userRepo.Update(ctx, user); err != nil {
+		return nil, apperrors.NewAppError(500, "禁用用户失败", err)
+	}
+	if err := uc.authService.LockOutUser(ctx, user.ID); err != nil {
+		return nil, apperrors.NewAppError(500, "吊销登录会话失败", err)
+	}
+	return toUserResponse(user), nil
+}
+
+// EnableUser 重新启用已禁用的用户
+func (uc *UserService) EnableUser(ctx context.Context, id uint64) (*dto.UserResponse, error) {
+	user, err := uc.findManagedUser(ctx, id, true)
+	if err != nil {
+		return nil, err
+	}
+	if user.Status == entity.UserStatusActive {
+		return nil, apperrors.NewAppError(400, "用户未被禁用", nil)
+	}
+
+	user.Enable()
+	if err := uc.userRepo.Update(ctx, user); err != nil {
+		return nil, apperrors.NewAppError(500, "启用用户失败", err)
+	}
+	return toUserResponse(user), nil
+}
+
+// DeleteUser 删除用户（软删除）并吊销其全部会话
+func (uc *UserService) DeleteUser(ctx context.Context, id uint64) error {
+	user, err := uc.findManagedUser(ctx, id, true)
+	if err != nil {
+		return err
+	}
+
+	if err := uc.userRepo.Delete(ctx, user.ID); err != nil {
+		return apperrors.NewAppError(500, "删除用户失败", err)
+	}
+	if err := uc.authService.LockOutUser(ctx, user.ID); err != nil {
+		return apperrors.NewAppError(500, "吊销登录会话失败", err)
+	}
+	return nil
+}
+
+// currentUser 获取当前登录用户，个人访问令牌不能修改个人资料和密码
+func (uc *UserService) currentUser(ctx context.Context) (*entity.User, error) {
+	userID, err := contextutil.GetUserID(ctx)
+	if err != nil {
+		return nil, apperrors.ErrUnauthorized
+	}
+	if contextutil.GetScopes(ctx) != nil {
+		return nil, apperrors.ErrForbidden
+	}
+
+	user, err := uc.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		return nil, apperrors.NewAppError(500, "查询用户失败", err)
+	}
+	if user == nil || user.IsDeleted() {
+		return nil, apperrors.ErrNotFound
+	}
+	return user, nil
+}
+
+// findManagedUser 查找管理员要操作的用户，notSelf 为 true 时不允许操作自己，避免管理员把自己锁在系统外
+func (uc *UserService) findManagedUser(ctx context.Context, id uint64, notSelf bool) (*entity.User, error) {
+	if notSelf {
+		if err := checkNotSelf(ctx, id); err != nil {
+			return nil, err
+		}
+	}
+
+	user, err := uc.userRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, apperrors.NewAppError(500, "查询用户失败", err)
+	}
+	if user == nil || user.IsDeleted() {
+		return nil, apperrors.ErrNotFound
+	}
+	return user, nil
+}
+
+// checkNotSelf 管理员不能修改自己的角色和状态，也不能删除自己
+func checkNotSelf(ctx context.Context, id uint64) error {
+	currentID, err := contextutil.GetUserID(ctx)
+	if err != nil {
+		return apperrors.ErrUnauthorized
+	}
+	if currentID == id {
+		return apperrors.NewAppError(400, "不能对自己执行该操作", nil)
+	}
+	return nil
+}
+
+func toUserResponse(user *entity.User) *dto.UserResponse {
+	return &dto.UserResponse{
+		ID:          user.ID,
+		Name:        user.Name,
+		DisplayName: user.DisplayName,
+		Email:       user.Email,
+		Avatar:      user.Avatar,
+		TeamID:      user.TeamID,
+		Role:        string(permission.ParseRole(user.Role)),
+		Status:      user.Status,
+
+		EmailVerified:    user.EmailVerified,
+		TwoFactorEnabled: user.TwoFactorEnabled,
+	}
+}
@@ -0,0 +1,31 @@
+package entity
+
+import "time"
+
+// AccessToken 个人访问令牌，供脚本和机器人调用API
+type AccessToken struct {
+	BaseEntity
+	UserID     uint64
+	Name       string
+	Prefix     string // 明文前缀，便于用户识别令牌
+	TokenHash  string
+	Scopes     []string
+	ExpiresAt  *time.Time
+	LastUsedAt *time.Time
+	RevokedAt  *time.Time
+}
+
+// IsUsable 检查令牌当前是否可用
+func (t *AccessToken) IsUsable(now time.Time) bool {
+	if t.RevokedAt != nil || t.IsDeleted() {
+		return false
+	}
+	return t.ExpiresAt == nil || now.Before(*t.ExpiresAt)
+}
+
+// Revoke 吊销令牌
+func (t *AccessToken) Revoke(now time.Time) {
+	if t.RevokedAt == nil {
+		t.RevokedAt = &now
+	}
+}
@@ -0,0 +1,53 @@
+package entity
+
+// Board 项目看板，由有序的列组成，每一列对应一个任务状态
+type Board struct {
+	BaseEntity
+	ProjectID uint64
+	Name      string
+	Columns   []*BoardColumn // 按 Position 从左到右排列
+}
+
+// BoardColumn 看板列
+type BoardColumn struct {
+	ID       uint64
+	BoardID  uint64
+	Name     string
+	Status   TaskStatus
+	Position int
+	WIPLimit int // 在制品上限，0 表示不限制
+}
+
+// NewBoard 创建新看板，未指定列时使用默认的 待办 → 进行中 → 评审中 → 已完成
+func NewBoard(projectID uint64, name string, columns []*BoardColumn) *Board {
+	if len(columns) == 0 {
+		columns = []*BoardColumn{
+			{Name: "待办", Status: TaskStatusTodo},
+			{Name: "进行中", Status: TaskStatusInProgress},
+			{Name: "评审中", Status: TaskStatusInReview},
+			{Name: "已完成", Status: TaskStatusDone},
+		}
+	}
+	b := &Board{ProjectID: projectID, Name: name}
+	b.SetColumns(columns)
+	return b
+}
+
+// SetColumns 按给定顺序设置看板列
+func (b *Board) SetColumns(columns []*BoardColumn) {
+	for i, col := range columns {
+		col.BoardID = b.ID
+		col.Position = i
+	}
+	b.Columns = columns
+}
+
+// Column 根据ID查找看板列
+func (b *Board) Column(id uint64) *BoardColumn {
+	for _, col := range b.Columns {
+		if col.ID == id {
+			return col
+		}
+	}
+	return nil
+}
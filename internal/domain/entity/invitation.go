@@ -0,0 +1,55 @@
+package entity
+
+import "time"
+
+// InvitationStatus 邀请状态，由接受、撤销时间和过期时间推导
+type InvitationStatus string
+
+const (
+	InvitationPending  InvitationStatus = "pending"
+	InvitationAccepted InvitationStatus = "accepted"
+	InvitationRevoked  InvitationStatus = "revoked"
+	InvitationExpired  InvitationStatus = "expired"
+)
+
+// Invitation 邮件邀请，可以同时邀请加入一个团队和一个项目
+type Invitation struct {
+	BaseEntity
+	Email       string
+	Role        string // 接受后用户的全局角色
+	TeamID      uint64
+	TeamRole    TeamRole
+	ProjectID   uint64
+	ProjectRole ProjectRole
+	InvitedBy   uint64
+	TokenHash   string
+	ExpiresAt   time.Time
+
+	AcceptedAt     *time.Time
+	AcceptedUserID uint64
+	RevokedAt      *time.Time
+}
+
+// Status 获取邀请在 now 时刻的状态
+func (i *Invitation) Status(now time.Time) InvitationStatus {
+	switch {
+	case i.AcceptedAt != nil:
+		return InvitationAccepted
+	case i.RevokedAt != nil:
+		return InvitationRevoked
+	case !now.Before(i.ExpiresAt):
+		return InvitationExpired
+	}
+	return InvitationPending
+}
+
+// Renew 更换邀请链接并重新计算有效期，旧链接随即失效
+func (i *Invitation) Renew(tokenHash string, expiresAt time.Time) {
+	i.TokenHash = tokenHash
+	i.ExpiresAt = expiresAt
+}
+
+// Revoke 撤销邀请
+func (i *Invitation) Revoke(at time.Time) {
+	i.RevokedAt = &at
+}
@@ -0,0 +1,64 @@
+package entity
+
+import "time"
+
+// MilestoneState 里程碑状态
+type MilestoneState string
+
+const (
+	MilestoneStateOpen   MilestoneState = "open"
+	MilestoneStateClosed MilestoneState = "closed"
+)
+
+// IsValid 检查是否为已定义的里程碑状态
+func (s MilestoneState) IsValid() bool {
+	return s == MilestoneStateOpen || s == MilestoneStateClosed
+}
+
+// Milestone 项目里程碑，同一项目内按 Position 升序排列
+type Milestone struct {
+	BaseEntity
+	ProjectID   uint64
+	Name        string
+	Description string
+	DueDate     time.Time
+	State       MilestoneState
+	Position    int
+	ClosedAt    *time.Time
+}
+
+// NewMilestone 创建新里程碑，初始为进行中
+func NewMilestone(projectID uint64, name, description string, dueDate time.Time) *Milestone {
+	return &Milestone{
+		ProjectID:   projectID,
+		Name:        name,
+		Description: description,
+		DueDate:     dueDate,
+		State:       MilestoneStateOpen,
+	}
+}
+
+// UpdateBasicInfo 更新名称、描述和目标日期
+func (m *Milestone) UpdateBasicInfo(name, description string, dueDate time.Time) {
+	m.Name = name
+	m.Description = description
+	m.DueDate = dueDate
+}
+
+// SetState 设置状态，关闭时记录关闭时间，重新打开时清空
+func (m *Milestone) SetState(state MilestoneState, at time.Time) {
+	if state == m.State {
+		return
+	}
+	m.State = state
+	if state == MilestoneStateClosed {
+		m.ClosedAt = &at
+	} else {
+		m.ClosedAt = nil
+	}
+}
+
+// IsOverdue 检查里程碑在 now 时刻是否已逾期：目标日期已过但仍未关闭
+func (m *Milestone) IsOverdue(now time.Time) bool {
+	return m.State == MilestoneStateOpen && !m.DueDate.IsZero() && now.After(m.DueDate)
+}
@@ -0,0 +1,35 @@
+package entity
+
+import (
+	"strings"
+	"time"
+)
+
+// OneTimeTokenPurpose 一次性令牌用途
+type OneTimeTokenPurpose string
+
+const (
+	OneTimeTokenPasswordReset OneTimeTokenPurpose = "password_reset"
+	OneTimeTokenEmailVerify   OneTimeTokenPurpose = "email_verify"
+)
+
+// OneTimeToken 一次性令牌，用于找回密码和邮箱验证
+type OneTimeToken struct {
+	BaseEntity
+	UserID    uint64
+	Purpose   OneTimeTokenPurpose
+	Email     string // 签发时的邮箱，兑换时邮箱已变更则令牌失效
+	TokenHash string
+	ExpiresAt time.Time
+	UsedAt    *time.Time
+}
+
+// IsUsable 检查令牌是否未使用且未过期
+func (t *OneTimeToken) IsUsable(now time.Time) bool {
+	return t.UsedAt == nil && now.Before(t.ExpiresAt)
+}
+
+// MatchesEmail 检查令牌是否签发给用户当前的邮箱
+func (t *OneTimeToken) MatchesEmail(email string) bool {
+	return t.Email != "" && strings.EqualFold(t.Email, email)
+}
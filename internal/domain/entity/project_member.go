@@ -0,0 +1,39 @@
+package entity
+
+// ProjectRole 项目内角色
+type ProjectRole string
+
+const (
+	ProjectRoleOwner      ProjectRole = "owner"
+	ProjectRoleMaintainer ProjectRole = "maintainer"
+	ProjectRoleMember     ProjectRole = "member"
+	ProjectRoleViewer     ProjectRole = "viewer"
+)
+
+// projectRoleRanks 角色等级，数值越大权限越高
+var projectRoleRanks = map[ProjectRole]int{
+	ProjectRoleViewer:     1,
+	ProjectRoleMember:     2,
+	ProjectRoleMaintainer: 3,
+	ProjectRoleOwner:      4,
+}
+
+// IsValid 检查是否为已定义的项目角色
+func (r ProjectRole) IsValid() bool {
+	_, ok := projectRoleRanks[r]
+	return ok
+}
+
+// AtLeast 检查角色是否不低于指定角色，非项目成员（空角色）始终返回 false
+func (r ProjectRole) AtLeast(min ProjectRole) bool {
+	return r.IsValid() && projectRoleRanks[r] >= projectRoleRanks[min]
+}
+
+// CanManage 检查当前角色能否管理拥有 target 角色的成员
+// owner 可以管理任何成员，其余角色只能管理比自己等级低的成员
+func (r ProjectRole) CanManage(target ProjectRole) bool {
+	if r == ProjectRoleOwner {
+		return true
+	}
+	return r.AtLeast(ProjectRoleMaintainer) && projectRoleRanks[r] > projectRoleRanks[target]
+}
@@ -0,0 +1,14 @@
+package entity
+
+import "time"
+
+// ProjectStatusChange 项目状态变更历史，只追加不修改
+type ProjectStatusChange struct {
+	ID         uint64
+	ProjectID  uint64
+	FromStatus ProjectStatus
+	ToStatus   ProjectStatus
+	Reason     string
+	OperatorID uint64
+	CreatedAt  time.Time
+}
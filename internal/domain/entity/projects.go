@@ -1,6 +1,10 @@
 package entity
 
-import "time"
+import (
+	"errors"
+	"math"
+	"time"
+)
 
 type ProjectStatus int
 
@@ -10,6 +14,31 @@ const (
 	ProjectStatusArchived  ProjectStatus = 3
 )
 
+// IsValid 检查是否为已定义的项目状态
+func (s ProjectStatus) IsValid() bool {
+	switch s {
+	case ProjectStatusActive, ProjectStatusCompleted, ProjectStatusArchived:
+		return true
+	}
+	return false
+}
+
+// projectStatusTransitions 允许的状态变更：进行中 → 已完成 → 已归档，已完成和已归档的项目可以重新打开
+var projectStatusTransitions = map[ProjectStatus][]ProjectStatus{
+	ProjectStatusActive:    {ProjectStatusCompleted},
+	ProjectStatusCompleted: {ProjectStatusArchived, ProjectStatusActive},
+	ProjectStatusArchived:  {ProjectStatusActive},
+}
+
+var (
+	// ErrProjectStatusTransition 不允许的项目状态变更
+	ErrProjectStatusTransition = errors.New("project status transition not allowed")
+	// ErrProjectReopenReason 重新打开项目时必须填写原因
+	ErrProjectReopenReason = errors.New("reason is required to reopen a project")
+	// ErrProjectVersionConflict 保存时项目已被其他人修改
+	ErrProjectVersionConflict = errors.New("project has been modified since it was read")
+)
+
 type ProjectPriority int
 
 const (
@@ -19,6 +48,36 @@ const (
 	ProjectPriorityP3 ProjectPriority = 3
 )
 
+// ProgressMode 项目进度的计算方式
+type ProgressMode string
+
+const (
+	ProgressModeManual     ProgressMode = "manual"     // 手动填写
+	ProgressModeTasks      ProgressMode = "tasks"      // 已完成任务数 / 任务总数
+	ProgressModeEstimate   ProgressMode = "estimate"   // 按预估工时加权
+	ProgressModeMilestones ProgressMode = "milestones" // 已关闭里程碑数 / 里程碑总数
+)
+
+// IsValid 检查是否为已定义的进度计算方式
+func (m ProgressMode) IsValid() bool {
+	switch m {
+	case ProgressModeManual, ProgressModeTasks, ProgressModeEstimate, ProgressModeMilestones:
+		return true
+	}
+	return false
+}
+
+// ProgressStats 计算进度所需的任务和里程碑统计，已取消的任务不计入
+type ProgressStats struct {
+	Total         int64
+	Done          int64
+	TotalEstimate float64
+	DoneEstimate  float64
+
+	TotalMilestones  int64
+	ClosedMilestones int64
+}
+
 type Project struct {
 	BaseEntity
 	Name        string
@@ -30,6 +89,9 @@ type Project struct {
 	Progress    int
 	Priority    ProjectPriority
 	CoverImage  string
+
+	ProgressMode ProgressMode `gorm:"type:varchar(16);default:tasks"`
+	Version      uint64       // 乐观锁版本号，每次保存基本信息后加一
 }
 
 // NewProject 创建新项目
@@ -41,6 +103,9 @@ func NewProject(name, description string, ownerID uint64) *Project {
 		Status:      ProjectStatusActive,
 		Progress:    0,
 		Priority:    ProjectPriorityP2,
+
+		ProgressMode: ProgressModeTasks,
+		Version:      1,
 	}
 }
 
@@ -51,9 +116,39 @@ func (p *Project) UpdateBasicInfo(name, description string, coverImage string) {
 	p.CoverImage = coverImage
 }
 
-// SetStatus 设置状态
-func (p *Project) SetStatus(status ProjectStatus) {
-	p.Status = status
+// CanTransitionTo 检查能否从当前状态变更为 to
+func (p *Project) CanTransitionTo(to ProjectStatus) bool {
+	for _, next := range projectStatusTransitions[p.Status] {
+		if next == to {
+			return true
+		}
+	}
+	return false
+}
+
+// TransitionTo 按状态机变更状态，返回需要记录的变更历史；重新打开项目时必须填写原因
+func (p *Project) TransitionTo(to ProjectStatus, reason string, operatorID uint64, at time.Time) (*ProjectStatusChange, error) {
+	if !p.CanTransitionTo(to) {
+		return nil, ErrProjectStatusTransition
+	}
+	if to == ProjectStatusActive && reason == "" {
+		return nil, ErrProjectReopenReason
+	}
+	change := &ProjectStatusChange{
+		ProjectID:  p.ID,
+		FromStatus: p.Status,
+		ToStatus:   to,
+		Reason:     reason,
+		OperatorID: operatorID,
+		CreatedAt:  at,
+	}
+	p.Status = to
+	return change, nil
+}
+
+// IsArchived 检查项目是否已归档，已归档的项目只能重新打开，不能做其他修改
+func (p *Project) IsArchived() bool {
+	return p.Status == ProjectStatusArchived
 }
 
 // SetSchedule 设置进度安排
@@ -67,6 +162,46 @@ func (p *Project) SetPriorities(priority ProjectPriority) {
 	p.Priority = priority
 }
 
+// SetProgressMode 设置进度计算方式
+func (p *Project) SetProgressMode(mode ProgressMode) {
+	p.ProgressMode = mode
+}
+
+// IsManualProgress 检查进度是否由用户手动填写
+func (p *Project) IsManualProgress() bool {
+	return p.ProgressMode == ProgressModeManual
+}
+
+// SetProgress 手动设置进度，超出 0-100 的值会被截断
+func (p *Project) SetProgress(progress int) {
+	p.Progress = min(max(progress, 0), 100)
+}
+
+// RecomputeProgress 根据任务统计重新计算进度，手动模式下不做修改，返回进度是否变化
+// 按工时加权时如果任务都没有预估工时、按里程碑计算时如果没有里程碑，退回按任务数计算；向下取整，只有全部完成才是 100
+func (p *Project) RecomputeProgress(stats ProgressStats) bool {
+	if p.IsManualProgress() {
+		return false
+	}
+
+	var ratio float64
+	switch {
+	case p.ProgressMode == ProgressModeMilestones && stats.TotalMilestones > 0:
+		ratio = float64(stats.ClosedMilestones) / float64(stats.TotalMilestones)
+	case stats.Total > 0 && stats.Done == stats.Total:
+		ratio = 1
+	case p.ProgressMode == ProgressModeEstimate && stats.TotalEstimate > 0:
+		ratio = stats.DoneEstimate / stats.TotalEstimate
+	case stats.Total > 0:
+		ratio = float64(stats.Done) / float64(stats.Total)
+	}
+	progress := int(math.Floor(ratio * 100))
+
+	changed := progress != p.Progress
+	p.Progress = progress
+	return changed
+}
+
 func (p *Project) IsActive() bool {
 	return p.Status == ProjectStatusActive && !p.IsDeleted()
 }
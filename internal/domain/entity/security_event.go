@@ -0,0 +1,24 @@
+package entity
+
+import "time"
+
+// SecurityEventType 安全事件类型
+type SecurityEventType string
+
+const (
+	SecurityEventAccountLocked   SecurityEventType = "account_locked"
+	SecurityEventAccountUnlocked SecurityEventType = "account_unlocked"
+	SecurityEventIPThrottled     SecurityEventType = "ip_throttled"
+)
+
+// SecurityEvent 安全事件，供安全审计查看
+type SecurityEvent struct {
+	ID        uint64
+	Type      SecurityEventType
+	UserID    uint64 // 账号不存在时为0
+	Username  string
+	IP        string
+	ActorID   uint64 // 执行操作的管理员，系统触发时为0
+	Detail    string
+	CreatedAt time.Time
+}
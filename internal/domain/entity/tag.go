@@ -0,0 +1,38 @@
+package entity
+
+import "strings"
+
+// DefaultTagColor 未指定颜色时使用的标签颜色
+const DefaultTagColor = "#8C8C8C"
+
+// Tag 项目标签，全局共享，按名称唯一
+type Tag struct {
+	BaseEntity
+	Name       string
+	Color      string // #RRGGBB
+	UsageCount int64  // 使用该标签的项目数，仅查询时填充
+}
+
+// NewTag 创建新标签
+func NewTag(name, color string) *Tag {
+	t := &Tag{Name: NormalizeTagName(name), Color: DefaultTagColor}
+	t.SetColor(color)
+	return t
+}
+
+// Rename 修改标签名称
+func (t *Tag) Rename(name string) {
+	t.Name = NormalizeTagName(name)
+}
+
+// SetColor 设置标签颜色，空值保持不变
+func (t *Tag) SetColor(color string) {
+	if color != "" {
+		t.Color = strings.ToUpper(color)
+	}
+}
+
+// NormalizeTagName 规范化标签名称：去掉首尾空白
+func NormalizeTagName(name string) string {
+	return strings.TrimSpace(name)
+}
@@ -0,0 +1,119 @@
+package entity
+
+import (
+	"errors"
+	"time"
+)
+
+var (
+	// ErrWIPLimitReached 任务的目标状态对应的看板列已达到在制品上限
+	ErrWIPLimitReached = errors.New("work in progress limit reached")
+	// ErrTaskNotFound 保存时任务已被删除
+	ErrTaskNotFound = errors.New("task not found")
+)
+
+// TaskStatus 任务状态
+type TaskStatus string
+
+const (
+	TaskStatusTodo       TaskStatus = "todo"
+	TaskStatusInProgress TaskStatus = "in_progress"
+	TaskStatusInReview   TaskStatus = "in_review"
+	TaskStatusDone       TaskStatus = "done"
+	TaskStatusCancelled  TaskStatus = "cancelled"
+)
+
+// IsValid 检查是否为已定义的任务状态
+func (s TaskStatus) IsValid() bool {
+	switch s {
+	case TaskStatusTodo, TaskStatusInProgress, TaskStatusInReview, TaskStatusDone, TaskStatusCancelled:
+		return true
+	}
+	return false
+}
+
+// IsClosed 已完成或已取消的任务视为关闭
+func (s TaskStatus) IsClosed() bool {
+	return s == TaskStatusDone || s == TaskStatusCancelled
+}
+
+// TaskPriority 任务优先级，取值与项目优先级一致，P0 最高
+type TaskPriority int
+
+const (
+	TaskPriorityP0 TaskPriority = 0
+	TaskPriorityP1 TaskPriority = 1
+	TaskPriorityP2 TaskPriority = 2
+	TaskPriorityP3 TaskPriority = 3
+)
+
+// IsValid 检查是否为已定义的任务优先级
+func (p TaskPriority) IsValid() bool {
+	return p >= TaskPriorityP0 && p <= TaskPriorityP3
+}
+
+type Task struct {
+	BaseEntity
+	ProjectID   uint64
+	Title       string
+	Description string
+	AssigneeID  uint64 // 0 表示未分配
+	ReporterID  uint64
+	Status      TaskStatus
+	Priority    TaskPriority
+	DueDate     time.Time
+	Estimate    float64 // 预估工时（小时）
+	Rank        string  // 看板内的排序键（分数索引），按字节序升序排列
+	CompletedAt *time.Time
+}
+
+// NewTask 创建新任务
+func NewTask(projectID uint64, title, description string, reporterID uint64) *Task {
+	return &Task{
+		ProjectID:   projectID,
+		Title:       title,
+		Description: description,
+		ReporterID:  reporterID,
+		Status:      TaskStatusTodo,
+		Priority:    TaskPriorityP2,
+	}
+}
+
+// UpdateBasicInfo 更新基本信息
+func (t *Task) UpdateBasicInfo(title, description string) {
+	t.Title = title
+	t.Description = description
+}
+
+// Assign 指派处理人，assigneeID 为 0 时取消指派
+func (t *Task) Assign(assigneeID uint64) {
+	t.AssigneeID = assigneeID
+}
+
+// SetStatus 设置状态，进入完成状态时记录完成时间，重新打开时清空
+func (t *Task) SetStatus(status TaskStatus, at time.Time) {
+	if status == TaskStatusDone && t.Status != TaskStatusDone {
+		t.CompletedAt = &at
+	} else if status != TaskStatusDone {
+		t.CompletedAt = nil
+	}
+	t.Status = status
+}
+
+// SetPlan 设置优先级、截止日期和预估工时
+func (t *Task) SetPlan(priority TaskPriority, dueDate time.Time, estimate float64) {
+	t.Priority = priority
+	t.DueDate = dueDate
+	t.Estimate = estimate
+}
+
+// MoveTo 将任务移动到 status 对应的列，rank 为新位置的排序键
+func (t *Task) MoveTo(status TaskStatus, rank string, at time.Time) {
+	t.SetStatus(status, at)
+	t.Rank = rank
+}
+
+// IsOverdue 检查任务在 now 时刻是否已逾期，关闭的任务不算逾期
+func (t *Task) IsOverdue(now time.Time) bool {
+	return !t.DueDate.IsZero() && now.After(t.DueDate) && !t.Status.IsClosed()
+}
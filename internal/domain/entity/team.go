@@ -5,4 +5,35 @@ type Team struct {
 	Name        string
 	Description string
 	OwnerId     uint64
+	ParentId    uint64 `gorm:"index"` // 上级团队，0 表示顶级团队
+}
+
+// NewTeam 创建新团队
+func NewTeam(name, description string, ownerID uint64) *Team {
+	return &Team{
+		Name:        name,
+		Description: description,
+		OwnerId:     ownerID,
+	}
+}
+
+// UpdateBasicInfo 更新基本信息
+func (t *Team) UpdateBasicInfo(name, description string) {
+	t.Name = name
+	t.Description = description
+}
+
+// TransferOwnership 转移团队负责人
+func (t *Team) TransferOwnership(ownerID uint64) {
+	t.OwnerId = ownerID
+}
+
+// MoveTo 调整上级团队，parentID 为 0 时成为顶级团队
+func (t *Team) MoveTo(parentID uint64) {
+	t.ParentId = parentID
+}
+
+// IsOwner 检查用户是否为团队负责人
+func (t *Team) IsOwner(userID uint64) bool {
+	return t.OwnerId == userID
 }
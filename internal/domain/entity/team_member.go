@@ -0,0 +1,14 @@
+package entity
+
+// TeamRole 团队内角色
+type TeamRole string
+
+const (
+	TeamRoleLead   TeamRole = "lead"
+	TeamRoleMember TeamRole = "member"
+)
+
+// IsValid 检查是否为已定义的团队角色
+func (r TeamRole) IsValid() bool {
+	return r == TeamRoleLead || r == TeamRoleMember
+}
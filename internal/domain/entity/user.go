@@ -1,18 +1,78 @@
 package entity
 
+import "time"
+
+// 用户状态
+const (
+	UserStatusActive   = 1
+	UserStatusDisabled = 2
+)
+
 // User 用户实体（示例）
 type User struct {
 	BaseEntity
-	Name     string `json:"name" gorm:"uniqueIndex;not null"`
-	Email    string `json:"email" gorm:"uniqueIndex;not null"`
+	// 用户名和邮箱只在未删除的用户中唯一，删除后可以被新用户使用
+	Name     string `json:"name" gorm:"uniqueIndex:idx_users_active_name,where:deleted_at IS NULL;not null"`
+	Email    string `json:"email" gorm:"uniqueIndex:idx_users_active_email,where:deleted_at IS NULL;not null"`
 	Password string `json:"-" gorm:"not null"`
 	Status   int    `json:"status" gorm:"default:1"` // 1:正常 2:禁用
 	Avatar   string `json:"avatar"`
 	TeamID   uint64 `json:"team_id"`
 	Role     string `json:"role"`
+
+	DisplayName string `json:"display_name"` // 显示名称，为空时使用 Name
+
+	EmailVerified   bool       `json:"email_verified" gorm:"default:false"`
+	EmailVerifiedAt *time.Time `json:"email_verified_at"`
+
+	// 两步验证：设置后、确认前 TwoFactorSecret 已保存但 TwoFactorEnabled 仍为 false
+	TwoFactorEnabled  bool   `json:"two_factor_enabled" gorm:"default:false"`
+	TwoFactorSecret   string `json:"-"`
+	TwoFactorLastStep int64  `json:"-"` // 最近一次通过校验的TOTP时间步，用于拒绝重放
 }
 
 // IsActive 检查用户是否激活
 func (u *User) IsActive() bool {
-	return u.Status == 1 && !u.IsDeleted()
+	return u.Status == UserStatusActive && !u.IsDeleted()
+}
+
+// Disable 禁用用户
+func (u *User) Disable() {
+	u.Status = UserStatusDisabled
+}
+
+// Enable 重新启用用户
+func (u *User) Enable() {
+	u.Status = UserStatusActive
+}
+
+// UpdateProfile 更新个人资料
+func (u *User) UpdateProfile(displayName, avatar string) {
+	u.DisplayName = displayName
+	u.Avatar = avatar
+}
+
+// ChangeEmail 修改邮箱，新邮箱需要重新验证
+func (u *User) ChangeEmail(email string) {
+	if u.Email == email {
+		return
+	}
+	u.Email = email
+	u.EmailVerified = false
+	u.EmailVerifiedAt = nil
+}
+
+// MarkEmailVerified 标记邮箱已验证
+func (u *User) MarkEmailVerified(at time.Time) {
+	if !u.EmailVerified {
+		u.EmailVerified = true
+		u.EmailVerifiedAt = &at
+	}
+}
+
+// ClearTwoFactor 关闭两步验证并清除密钥
+func (u *User) ClearTwoFactor() {
+	u.TwoFactorEnabled = false
+	u.TwoFactorSecret = ""
+	u.TwoFactorLastStep = 0
 }
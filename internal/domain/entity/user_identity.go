@@ -0,0 +1,23 @@
+package entity
+
+// UserIdentity 外部身份提供方账号与本地用户的绑定关系
+type UserIdentity struct {
+	BaseEntity
+	UserID   uint64
+	Provider string // 配置中的提供方名称
+	Subject  string // ID Token 中的 sub
+	Email    string
+}
+
+// OIDCLoginState 单点登录跳转前保存的状态，回调时按 state 取出并立即失效
+type OIDCLoginState struct {
+	Provider     string `json:"provider"`
+	CodeVerifier string `json:"code_verifier"`
+	Nonce        string `json:"nonce"`
+}
+
+// OIDCLoginResult 回调成功后保存的登录结果，前端凭一次性登录代码换取 Token
+type OIDCLoginResult struct {
+	Provider string `json:"provider"`
+	UserID   uint64 `json:"user_id"`
+}
@@ -0,0 +1,123 @@
+package permission
+
+// Role 全局角色
+type Role string
+
+const (
+	RoleAdmin   Role = "admin"
+	RoleManager Role = "manager"
+	RoleMember  Role = "member"
+	RoleGuest   Role = "guest"
+)
+
+// Permission 权限标识，格式为 "资源:操作"
+type Permission string
+
+const (
+	ProjectRead          Permission = "project:read"
+	ProjectCreate        Permission = "project:create"
+	ProjectUpdate        Permission = "project:update"
+	ProjectDelete        Permission = "project:delete"
+	ProjectManageMembers Permission = "project:manage_members"
+
+	UserRead   Permission = "user:read"
+	UserCreate Permission = "user:create"
+	UserUpdate Permission = "user:update"
+	UserDelete Permission = "user:delete"
+
+	TeamRead   Permission = "team:read"
+	TeamManage Permission = "team:manage"
+
+	TaskRead  Permission = "task:read"
+	TaskWrite Permission = "task:write"
+
+	TagManage Permission = "tag:manage"
+
+	StatsRead Permission = "stats:read"
+
+	SecurityRead Permission = "security:read"
+)
+
+// rolePermissions 各角色拥有的权限集合，admin 拥有全部权限
+var rolePermissions = map[Role]map[Permission]bool{
+	RoleManager: set(
+		ProjectRead, ProjectCreate, ProjectUpdate, ProjectDelete, ProjectManageMembers,
+		UserRead,
+		TeamRead, TeamManage,
+		TaskRead, TaskWrite,
+		TagManage,
+		StatsRead,
+	),
+	// 项目级别的写操作还会由 ProjectService 按项目角色再次校验
+	RoleMember: set(
+		ProjectRead, ProjectCreate, ProjectUpdate, ProjectDelete, ProjectManageMembers,
+		UserRead,
+		TeamRead,
+		TaskRead, TaskWrite,
+	),
+	RoleGuest: set(
+		ProjectRead,
+		TeamRead,
+		TaskRead,
+	),
+}
+
+// ParseRole 解析角色字符串，未设置角色的历史用户按 member 处理
+func ParseRole(role string) Role {
+	if role == "" {
+		return RoleMember
+	}
+	return Role(role)
+}
+
+// IsValid 检查是否为已定义的角色
+func (r Role) IsValid() bool {
+	if r == RoleAdmin {
+		return true
+	}
+	_, ok := rolePermissions[r]
+	return ok
+}
+
+// Can 检查角色是否拥有指定权限
+func (r Role) Can(p Permission) bool {
+	if r == RoleAdmin {
+		return true
+	}
+	return rolePermissions[r][p]
+}
+
+// Permissions 返回角色拥有的权限列表
+func (r Role) Permissions() []Permission {
+	if r == RoleAdmin {
+		return All()
+	}
+	perms := make([]Permission, 0, len(rolePermissions[r]))
+	for _, p := range All() {
+		if rolePermissions[r][p] {
+			perms = append(perms, p)
+		}
+	}
+	return perms
+}
+
+// All 返回全部已定义的权限
+func All() []Permission {
+	return []Permission{
+		ProjectRead, ProjectCreate, ProjectUpdate, ProjectDelete, ProjectManageMembers,
+		UserRead, UserCreate, UserUpdate, UserDelete,
+		TeamRead, TeamManage,
+		TaskRead, TaskWrite,
+		TagManage,
+		StatsRead,
+		SecurityRead,
+	}
+}
+
+func set(perms ...Permission) map[Permission]bool {
+	m := make(map[Permission]bool, len(perms))
+	for _, p := range perms {
+		m[p] = true
+	}
+	return m
+}
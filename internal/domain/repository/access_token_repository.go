@@ -0,0 +1,17 @@
+package repository
+
+import (
+	"FLOWGO/internal/domain/entity"
+	"context"
+	"time"
+)
+
+// AccessTokenRepository 个人访问令牌仓储接口
+type AccessTokenRepository interface {
+	Create(ctx context.Context, token *entity.AccessToken) error
+	FindByID(ctx context.Context, id uint64) (*entity.AccessToken, error)
+	FindByHash(ctx context.Context, tokenHash string) (*entity.AccessToken, error)
+	ListByUserID(ctx context.Context, userID uint64) ([]*entity.AccessToken, error)
+	Revoke(ctx context.Context, id uint64, revokedAt time.Time) error
+	TouchLastUsed(ctx context.Context, id uint64, usedAt time.Time) error
+}
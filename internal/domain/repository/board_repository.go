@@ -0,0 +1,19 @@
+package repository
+
+import (
+	"FLOWGO/internal/domain/entity"
+	"context"
+)
+
+// BoardRepository 看板仓储接口，看板与列作为一个整体读写
+type BoardRepository interface {
+	FindByID(ctx context.Context, id uint64) (*entity.Board, error)
+	Create(ctx context.Context, board *entity.Board) error
+
+	// Update 保存看板名称和列：有ID的列更新，没有ID的列新建，不在列表中的列删除
+	Update(ctx context.Context, board *entity.Board) error
+	Delete(ctx context.Context, id uint64) error
+
+	// ListByProject 查询项目的全部看板
+	ListByProject(ctx context.Context, projectID uint64) ([]*entity.Board, error)
+}
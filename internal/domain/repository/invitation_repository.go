@@ -0,0 +1,27 @@
+package repository
+
+import (
+	"FLOWGO/internal/domain/entity"
+	"context"
+	"time"
+)
+
+// InvitationRepository 邀请仓储接口
+type InvitationRepository interface {
+	Create(ctx context.Context, invitation *entity.Invitation) error
+	FindByID(ctx context.Context, id uint64) (*entity.Invitation, error)
+	FindByTokenHash(ctx context.Context, tokenHash string) (*entity.Invitation, error)
+
+	// FindPendingByEmail 查找邮箱在 now 时刻仍有效的邀请
+	FindPendingByEmail(ctx context.Context, email string, now time.Time) (*entity.Invitation, error)
+
+	// Update 保存链接、有效期和撤销时间
+	Update(ctx context.Context, invitation *entity.Invitation) error
+
+	// Accept 在一个事务中创建受邀用户、标记邀请已接受，并把用户加入 teamID 团队和 projectID 项目（为 0 时跳过）
+	// 邀请已被接受、撤销、过期或链接已被重新发送替换时返回 false，不做任何修改
+	Accept(ctx context.Context, invitation *entity.Invitation, user *entity.User, teamID, projectID uint64, at time.Time) (bool, error)
+
+	// List 按创建时间倒序分页查询，status 为空时查询全部
+	List(ctx context.Context, status entity.InvitationStatus, now time.Time, page, pageSize int) ([]*entity.Invitation, int64, error)
+}
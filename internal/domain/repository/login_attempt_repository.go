@@ -0,0 +1,25 @@
+package repository
+
+import (
+	"context"
+	"time"
+)
+
+// LoginAttemptRepository 登录失败计数与封禁状态存储接口
+// key 由调用方区分维度，例如 "user:alice"、"ip:10.0.0.1"
+type LoginAttemptRepository interface {
+	// AddFailure 记录一次失败，返回滑动窗口内的失败次数
+	AddFailure(ctx context.Context, key string, at time.Time, window time.Duration) (int, error)
+
+	// ClearFailures 清空失败记录
+	ClearFailures(ctx context.Context, key string) error
+
+	// Block 封禁到指定时间
+	Block(ctx context.Context, key string, until time.Time) error
+
+	// BlockedUntil 获取封禁截止时间，未封禁时返回零值
+	BlockedUntil(ctx context.Context, key string) (time.Time, error)
+
+	// Unblock 解除封禁
+	Unblock(ctx context.Context, key string) error
+}
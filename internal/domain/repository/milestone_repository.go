@@ -0,0 +1,23 @@
+package repository
+
+import (
+	"FLOWGO/internal/domain/entity"
+	"context"
+)
+
+// MilestoneRepository 里程碑仓储接口
+// 新建、修改、删除里程碑时在同一事务中重新计算所属项目的进度
+type MilestoneRepository interface {
+	FindByID(ctx context.Context, id uint64) (*entity.Milestone, error)
+
+	// Create 创建里程碑，排在项目现有里程碑之后
+	Create(ctx context.Context, milestone *entity.Milestone) error
+	Update(ctx context.Context, milestone *entity.Milestone) error
+	Delete(ctx context.Context, id uint64) error
+
+	// ListByProject 查询项目的全部里程碑，按 Position 升序
+	ListByProject(ctx context.Context, projectID uint64) ([]*entity.Milestone, error)
+
+	// Reorder 按 ids 的顺序重新设置项目内里程碑的 Position
+	Reorder(ctx context.Context, projectID uint64, ids []uint64) error
+}
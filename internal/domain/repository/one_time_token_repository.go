@@ -0,0 +1,19 @@
+package repository
+
+import (
+	"FLOWGO/internal/domain/entity"
+	"context"
+	"time"
+)
+
+// OneTimeTokenRepository 一次性令牌仓储接口
+type OneTimeTokenRepository interface {
+	Create(ctx context.Context, token *entity.OneTimeToken) error
+	FindByHash(ctx context.Context, purpose entity.OneTimeTokenPurpose, tokenHash string) (*entity.OneTimeToken, error)
+
+	// MarkUsed 标记令牌已使用，令牌已被使用过时返回 false
+	MarkUsed(ctx context.Context, id uint64, usedAt time.Time) (bool, error)
+
+	// InvalidateByUser 作废用户指定用途的全部未使用令牌
+	InvalidateByUser(ctx context.Context, userID uint64, purpose entity.OneTimeTokenPurpose, at time.Time) error
+}
@@ -3,17 +3,63 @@ package repository
 import (
 	"FLOWGO/internal/domain/entity"
 	"context"
+	"time"
 )
 
+// ProjectFilter 项目列表过滤条件，零值字段不参与过滤
+// 关键字匹配名称和描述，排序字段见 ProjectSortFields，默认按创建时间倒序
+type ProjectFilter struct {
+	TeamID             uint64   // 只查询关联到该团队的项目
+	IncludeDescendants bool     // 同时包含下级团队的项目
+	Tags               []string // 按标签名称过滤
+	MatchAllTags       bool     // true 时必须包含全部标签，否则包含任意一个即可
+
+	QuerySpec
+	Status       *entity.ProjectStatus
+	Priority     *entity.ProjectPriority
+	OwnerID      uint64
+	MemberID     uint64    // 负责人或项目成员
+	DeadlineFrom time.Time // 截止时间不早于该时间
+	DeadlineTo   time.Time // 截止时间早于该时间
+}
+
 type ProjectsRepository interface {
 	BaseRepository[entity.Project]
+	// CreateWithOwner 在同一事务中创建项目并把负责人加入项目成员（owner 角色）
+	CreateWithOwner(ctx context.Context, project *entity.Project) error
+	// UpdateWithLinks 在同一事务中更新项目并替换关联的团队和标签，teamIds、tagIds 为 nil 时保持不变
+	// 版本号规则与 Update 相同，版本冲突时团队和标签也不做修改
+	UpdateWithLinks(ctx context.Context, project *entity.Project, teamIds, tagIds []uint64) error
 	ListAvailableTeams(ctx context.Context) ([]*entity.Team, error)
 	DeleteTeamsByProjectId(ctx context.Context, projectId uint64) error
 	AddTeams(ctx context.Context, projectId uint64, teamIds []uint64) error
 	ListTeamIdsByProjectId(ctx context.Context, projectId uint64) ([]uint64, error)
 	ListUsersByProjectId(ctx context.Context, projectId uint64) ([]*entity.User, error)
 	ListAvailableUsers(ctx context.Context) ([]*entity.User, error)
-	AddUsers(ctx context.Context, projectId uint64, userIds []uint64) error
+	AddUsers(ctx context.Context, projectId uint64, userIds []uint64, role entity.ProjectRole) error
+	FindUserRole(ctx context.Context, projectId uint64, userId uint64) (entity.ProjectRole, error)
+	ListUserRoles(ctx context.Context, projectId uint64) (map[uint64]entity.ProjectRole, error)
+	UpdateUserRole(ctx context.Context, projectId uint64, userId uint64, role entity.ProjectRole) error
 	RemoveUsers(ctx context.Context, projectId uint64, userId uint64) error
-	ListUsersInProjectTeams(ctx context.Context, projectId uint64) ([]*entity.User, error)
+	// ListByFilter 按过滤条件分页查询项目
+	ListByFilter(ctx context.Context, filter ProjectFilter, page, pageSize int) ([]*entity.Project, int64, error)
+	// ListUsersInProjectTeams 查询项目关联团队的成员，includeDescendants 为 true 时包含下级团队的成员
+	ListUsersInProjectTeams(ctx context.Context, projectId uint64, includeDescendants bool) ([]*entity.User, error)
+	// SetTags 将项目的标签替换为 tagIds
+	SetTags(ctx context.Context, projectId uint64, tagIds []uint64) error
+	// ListTagsByProjectId 查询项目的标签，按名称排序
+	ListTagsByProjectId(ctx context.Context, projectId uint64) ([]*entity.Tag, error)
+	// ChangeStatus 保存项目状态并记录变更历史，项目当前状态已不是 change.FromStatus 时不做修改并返回 false
+	// 变更成功后版本号加一，并写回 project.Version
+	ChangeStatus(ctx context.Context, project *entity.Project, change *entity.ProjectStatusChange) (bool, error)
+	// ListStatusChanges 查询项目的状态变更历史，最近的在前
+	ListStatusChanges(ctx context.Context, projectId uint64) ([]*entity.ProjectStatusChange, error)
+	// FindDeletedByID 查找回收站中的项目，项目不存在或未删除时返回 nil
+	FindDeletedByID(ctx context.Context, id uint64) (*entity.Project, error)
+	// ListDeleted 分页查询回收站中的项目，ownerId 不为 0 时只查询该用户负责的项目，最近删除的在前
+	ListDeleted(ctx context.Context, ownerId uint64, page, pageSize int) ([]*entity.Project, int64, error)
+	// Restore 恢复已删除的项目，以及随项目一起删除的团队和成员关联
+	Restore(ctx context.Context, id uint64) error
+	// PurgeDeletedBefore 永久删除在 cutoff 之前删除的项目及其全部数据，返回删除的项目数
+	PurgeDeletedBefore(ctx context.Context, cutoff time.Time) (int64, error)
 }
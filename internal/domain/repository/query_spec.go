@@ -0,0 +1,64 @@
+package repository
+
+import (
+	"fmt"
+	"strings"
+)
+
+// SortField 排序条件
+type SortField struct {
+	Field string
+	Desc  bool
+}
+
+// QuerySpec 列表查询的通用规格：关键字搜索和排序，各仓储的过滤条件内嵌使用
+// 可搜索的列和可排序字段对应的列由各仓储实现决定
+type QuerySpec struct {
+	Keyword string      // 关键字，模糊匹配
+	Sort    []SortField // 按顺序依次排序，为空时使用仓储的默认排序
+}
+
+// 项目列表可排序字段
+// 团队和成员与项目是多对多关系，一个项目没有唯一的团队或成员可供比较，因此只能过滤不能排序
+var ProjectSortFields = []string{"name", "status", "priority", "owner_id", "deadline", "start_date", "progress", "created_at", "updated_at"}
+
+// 用户列表可排序字段
+// 用户可以加入多个团队，users.team_id 不再维护，因此团队只能过滤不能排序
+var UserSortFields = []string{"name", "email", "status", "role", "created_at", "updated_at"}
+
+// ParseSort 解析排序表达式，如 "-priority,deadline"，字段前加 - 表示降序
+// 只允许 allowed 中的字段，同一字段不能重复出现
+func ParseSort(expr string, allowed []string) ([]SortField, error) {
+	var sorts []SortField
+	seen := make(map[string]bool)
+	for _, part := range strings.Split(expr, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		sort := SortField{Field: part}
+		if strings.HasPrefix(part, "-") {
+			sort = SortField{Field: part[1:], Desc: true}
+		} else if strings.HasPrefix(part, "+") {
+			sort.Field = part[1:]
+		}
+		if !contains(allowed, sort.Field) {
+			return nil, fmt.Errorf("unsupported sort field: %s", sort.Field)
+		}
+		if seen[sort.Field] {
+			return nil, fmt.Errorf("duplicate sort field: %s", sort.Field)
+		}
+		seen[sort.Field] = true
+		sorts = append(sorts, sort)
+	}
+	return sorts, nil
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
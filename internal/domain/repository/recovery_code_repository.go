@@ -0,0 +1,15 @@
+package repository
+
+import (
+	"context"
+	"time"
+)
+
+// RecoveryCodeRepository 两步验证恢复码仓储接口
+type RecoveryCodeRepository interface {
+	// Replace 用新的一组恢复码替换用户原有的全部恢复码
+	Replace(ctx context.Context, userID uint64, codeHashes []string) error
+
+	// Use 使用一个恢复码，恢复码不存在或已被使用时返回 false
+	Use(ctx context.Context, userID uint64, codeHash string, usedAt time.Time) (bool, error)
+}
@@ -0,0 +1,14 @@
+package repository
+
+import (
+	"FLOWGO/internal/domain/entity"
+	"context"
+)
+
+// SecurityEventRepository 安全事件仓储接口
+type SecurityEventRepository interface {
+	Create(ctx context.Context, event *entity.SecurityEvent) error
+
+	// List 按时间倒序分页查询，eventType 为空时查询全部类型
+	List(ctx context.Context, eventType string, page, pageSize int) ([]*entity.SecurityEvent, int64, error)
+}
@@ -0,0 +1,23 @@
+package repository
+
+import (
+	"FLOWGO/internal/domain/entity"
+	"context"
+)
+
+// TagRepository 标签仓储接口
+type TagRepository interface {
+	FindByID(ctx context.Context, id uint64) (*entity.Tag, error)
+	FindByName(ctx context.Context, name string) (*entity.Tag, error)
+	Create(ctx context.Context, tag *entity.Tag) error
+	Update(ctx context.Context, tag *entity.Tag) error
+
+	// Delete 删除标签（物理删除），同时解除与全部项目的关联
+	Delete(ctx context.Context, id uint64) error
+
+	// FindOrCreate 按名称查找标签，不存在的标签使用默认颜色创建，返回顺序与 names 一致
+	FindOrCreate(ctx context.Context, names []string) ([]*entity.Tag, error)
+
+	// Suggest 按名称前缀查询标签，用于输入时自动补全，使用次数多的排在前面
+	Suggest(ctx context.Context, prefix string, limit int) ([]*entity.Tag, error)
+}
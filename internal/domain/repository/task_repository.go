@@ -0,0 +1,47 @@
+package repository
+
+import (
+	"FLOWGO/internal/domain/entity"
+	"context"
+	"time"
+)
+
+// TaskFilter 任务列表过滤条件，零值字段不参与过滤
+type TaskFilter struct {
+	ProjectID  uint64
+	Status     entity.TaskStatus
+	Priority   *entity.TaskPriority
+	AssigneeID uint64
+	ReporterID uint64
+	Unassigned bool      // 只查询未指派的任务
+	Keyword    string    // 按标题模糊匹配
+	OverdueAt  time.Time // 只查询在该时刻已逾期（截止日期已过且未关闭）的任务
+}
+
+// TaskRepository 任务仓储接口
+// 新建、修改、删除、移动任务时在同一事务中重新计算所属项目的进度
+// 新建任务或修改任务状态时，目标状态对应的任一看板列已达到在制品上限则返回 entity.ErrWIPLimitReached
+// 修改或移动时任务已被删除则返回 entity.ErrTaskNotFound
+type TaskRepository interface {
+	FindByID(ctx context.Context, id uint64) (*entity.Task, error)
+	Create(ctx context.Context, task *entity.Task) error
+	Update(ctx context.Context, task *entity.Task) error
+	Delete(ctx context.Context, id uint64) error
+
+	// List 按过滤条件分页查询，按优先级从高到低、创建时间倒序排序
+	List(ctx context.Context, filter TaskFilter, page, pageSize int) ([]*entity.Task, int64, error)
+
+	// ListByStatuses 查询项目中处于给定状态的全部任务，按排序键升序排列
+	ListByStatuses(ctx context.Context, projectID uint64, statuses []entity.TaskStatus) ([]*entity.Task, error)
+
+	// MaxRank 查询项目中最大的排序键，没有任务时返回空字符串
+	MaxRank(ctx context.Context, projectID uint64) (string, error)
+
+	// NextRank 查询状态列中排在 after 之后的第一个排序键，after 为空时返回列中第一个排序键
+	// excludeID 对应的任务不参与查询，列中没有更靠后的任务时返回空字符串
+	NextRank(ctx context.Context, projectID uint64, status entity.TaskStatus, after string, excludeID uint64) (string, error)
+
+	// Move 保存任务的状态和排序键，与在制品上限检查、项目进度计算在同一事务中完成
+	// 列内调整顺序不受上限限制
+	Move(ctx context.Context, task *entity.Task) error
+}
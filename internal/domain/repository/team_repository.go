@@ -6,5 +6,33 @@ import (
 )
 
 type TeamRepository interface {
+	BaseRepository[entity.Team]
 	ListAvailableTeams(ctx context.Context) ([]*entity.Team, error)
+
+	// ExistsByName 检查团队名称是否已被其他团队使用，excludeID 为0时检查全部团队
+	ExistsByName(ctx context.Context, name string, excludeID uint64) (bool, error)
+
+	// ListSubtreeIDs 查询团队及其全部下级团队的ID
+	ListSubtreeIDs(ctx context.Context, teamID uint64) ([]uint64, error)
+
+	// ListMembers 查询团队成员
+	ListMembers(ctx context.Context, teamID uint64) ([]*entity.User, error)
+
+	// ListMemberRoles 查询团队全部成员的角色
+	ListMemberRoles(ctx context.Context, teamID uint64) (map[uint64]entity.TeamRole, error)
+
+	// FindMemberRole 查询用户在团队中的角色，非成员返回空角色
+	FindMemberRole(ctx context.Context, teamID, userID uint64) (entity.TeamRole, error)
+
+	// AddMembers 添加团队成员，已是成员的用户保持原角色不变
+	AddMembers(ctx context.Context, teamID uint64, userIDs []uint64, role entity.TeamRole) error
+
+	// SetMemberRole 设置成员角色，用户不是成员时加入团队
+	SetMemberRole(ctx context.Context, teamID, userID uint64, role entity.TeamRole) error
+
+	// TransferOwner 在同一事务中更新团队负责人并把新负责人设为 lead
+	TransferOwner(ctx context.Context, team *entity.Team) error
+
+	// RemoveMember 移除团队成员
+	RemoveMember(ctx context.Context, teamID, userID uint64) error
 }
@@ -0,0 +1,24 @@
+package repository
+
+import (
+	"context"
+	"time"
+)
+
+// TokenRepository Token吊销仓储接口
+type TokenRepository interface {
+	// Revoke 吊销指定 jti 的Token，记录保留到Token自然过期为止
+	Revoke(ctx context.Context, jti string, expiresAt time.Time) error
+
+	// Consume 原子地吊销一次性Token（刷新Token、两步验证挑战Token），Token此前已被使用或吊销时返回 false
+	Consume(ctx context.Context, jti string, expiresAt time.Time) (bool, error)
+
+	// IsRevoked 检查指定 jti 的Token是否已被吊销
+	IsRevoked(ctx context.Context, jti string) (bool, error)
+
+	// RevokeUserTokens 吊销用户在 issuedBefore 之前签发的全部Token，记录保留到 expiresAt
+	RevokeUserTokens(ctx context.Context, userID uint64, issuedBefore, expiresAt time.Time) error
+
+	// UserTokensRevokedAt 获取用户Token的吊销时间点，未吊销时返回零值
+	UserTokensRevokedAt(ctx context.Context, userID uint64) (time.Time, error)
+}
@@ -0,0 +1,28 @@
+package repository
+
+import (
+	"FLOWGO/internal/domain/entity"
+	"context"
+	"time"
+)
+
+// UserIdentityRepository 外部身份绑定仓储接口
+type UserIdentityRepository interface {
+	Create(ctx context.Context, identity *entity.UserIdentity) error
+	FindBySubject(ctx context.Context, provider, subject string) (*entity.UserIdentity, error)
+}
+
+// OIDCStateRepository 单点登录状态存储接口
+type OIDCStateRepository interface {
+	// Save 保存状态，超过 ttl 后自动失效
+	Save(ctx context.Context, state string, data *entity.OIDCLoginState, ttl time.Duration) error
+
+	// Take 取出并删除状态，保证同一 state 只能使用一次，不存在时返回 nil
+	Take(ctx context.Context, state string) (*entity.OIDCLoginState, error)
+
+	// SaveResult 保存回调得到的登录结果，超过 ttl 后自动失效
+	SaveResult(ctx context.Context, code string, result *entity.OIDCLoginResult, ttl time.Duration) error
+
+	// TakeResult 取出并删除登录结果，同一登录代码只能使用一次，不存在时返回 nil
+	TakeResult(ctx context.Context, code string) (*entity.OIDCLoginResult, error)
+}
@@ -3,15 +3,51 @@ package repository
 import (
 	"FLOWGO/internal/domain/entity"
 	"context"
+	"time"
 )
 
+// UserFilter 用户列表过滤条件，零值字段不参与过滤
+// 关键字匹配用户名、显示名称和邮箱，排序字段见 UserSortFields，默认按创建时间倒序
+type UserFilter struct {
+	QuerySpec
+	Status int
+	Role   string
+	TeamID uint64 // 团队成员
+}
+
 // UserRepository 用户仓储接口
 type UserRepository interface {
 	BaseRepository[entity.User]
 
+	// FindByName 根据用户名查找
+	FindByName(ctx context.Context, name string) (*entity.User, error)
+
 	// FindByEmail 根据邮箱查找
 	FindByEmail(ctx context.Context, email string) (*entity.User, error)
 
-	// ExistsByEmail 检查邮箱是否存在
+	// ExistsByName 检查用户名是否被未删除的用户使用，回收站中的用户不计入
+	ExistsByName(ctx context.Context, name string) (bool, error)
+
+	// ExistsByEmail 检查邮箱是否被未删除的用户使用，回收站中的用户不计入
 	ExistsByEmail(ctx context.Context, email string) (bool, error)
+
+	// UpdateTwoFactor 只更新两步验证相关字段；recoveryCodeHashes 不为 nil 时在同一事务中替换用户的全部恢复码
+	UpdateTwoFactor(ctx context.Context, user *entity.User, recoveryCodeHashes []string) error
+
+	// AdvanceTwoFactorStep 记录通过校验的TOTP时间步，step 不大于已记录的时间步时返回 false
+	AdvanceTwoFactorStep(ctx context.Context, userID uint64, step int64) (bool, error)
+
+	// ListByFilter 按过滤条件分页查询用户
+	ListByFilter(ctx context.Context, filter UserFilter, page, pageSize int) ([]*entity.User, int64, error)
+
+	// ListDeleted 分页查询回收站中的用户，最近删除的在前
+	ListDeleted(ctx context.Context, page, pageSize int) ([]*entity.User, int64, error)
+
+	// Restore 恢复已删除的用户
+	Restore(ctx context.Context, id uint64) error
+
+	// PurgeDeletedBefore 永久删除在 cutoff 之前删除的用户及其登录凭据和成员关系
+	// 其负责的任务改为未指派，报告的任务转给项目负责人，负责的团队改为无负责人
+	// 仍是项目负责人的用户保留在回收站中，返回删除的用户数
+	PurgeDeletedBefore(ctx context.Context, cutoff time.Time) (int64, error)
 }
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"log"
 	"os"
+	"strings"
 
 	"gopkg.in/yaml.v3"
 )
@@ -14,6 +15,10 @@ type Config struct {
 	Database DatabaseConfig `yaml:"database"`
 	Redis    RedisConfig    `yaml:"redis"`
 	JWT      JWTConfig      `yaml:"jwt"`
+	Mail     MailConfig     `yaml:"mail"`
+	Security SecurityConfig `yaml:"security"`
+	OIDC     OIDCConfig     `yaml:"oidc"`
+	Trash    TrashConfig    `yaml:"trash"`
 }
 
 // ServerConfig 服务器配置
@@ -22,6 +27,10 @@ type ServerConfig struct {
 	Mode         string `yaml:"mode"`
 	ReadTimeout  int    `yaml:"read_timeout"`
 	WriteTimeout int    `yaml:"write_timeout"`
+	PublicURL    string `yaml:"public_url"` // 前端访问地址，用于生成邮件中的链接
+	// TrustedProxies 可信反向代理的 IP 或 CIDR，只有来自这些地址的 X-Forwarded-For 才会被采信
+	// 默认为空，客户端 IP 取连接的对端地址
+	TrustedProxies []string `yaml:"trusted_proxies"`
 }
 
 // DatabaseConfig 数据库配置
@@ -53,7 +62,74 @@ type JWTConfig struct {
 	PublicKeyLocation  string `yaml:"public_key_location"`  // 公钥文件路径
 	PrivateKeyLocation string `yaml:"private_key_location"` // 私钥文件路径
 
-	Expiration int `yaml:"expiration"` // 过期时间（小时）
+	// 多密钥方式（支持轮换）：配置后优先于上面的单密钥配置
+	Keys      []JWTKeyConfig `yaml:"keys"`
+	ActiveKid string         `yaml:"active_kid"` // 当前用于签名的密钥
+
+	Expiration        int `yaml:"expiration"`         // 过期时间（小时），未设置 access_expiration 时使用
+	AccessExpiration  int `yaml:"access_expiration"`  // 访问Token过期时间（分钟）
+	RefreshExpiration int `yaml:"refresh_expiration"` // 刷新Token过期时间（小时）
+}
+
+// JWTKeyConfig 密钥环中单个密钥的配置
+// 只配置公钥的密钥仅用于验证已签发的Token，适用于轮换下线的旧密钥
+type JWTKeyConfig struct {
+	Kid                string `yaml:"kid"`
+	Algorithm          string `yaml:"algorithm"`            // HS256, RS256, ES256, EdDSA
+	SecretKey          string `yaml:"secret_key"`           // HS256 使用
+	PrivateKeyLocation string `yaml:"private_key_location"` // 私钥文件路径
+	PublicKeyLocation  string `yaml:"public_key_location"`  // 公钥文件路径
+}
+
+// MailConfig 邮件配置
+type MailConfig struct {
+	Driver   string `yaml:"driver"` // smtp, file, log
+	Host     string `yaml:"host"`
+	Port     string `yaml:"port"`
+	Username string `yaml:"username"`
+	Password string `yaml:"password"`
+	From     string `yaml:"from"`
+	Dir      string `yaml:"dir"` // file 驱动的输出目录
+}
+
+// SecurityConfig 安全配置
+type SecurityConfig struct {
+	Login         LoginProtectionConfig `yaml:"login"`
+	EncryptionKey string                `yaml:"encryption_key"` // 加密保存两步验证密钥，未配置时使用 jwt.secret_key
+}
+
+// LoginProtectionConfig 登录防暴力破解配置
+type LoginProtectionConfig struct {
+	Window          int `yaml:"window"`           // 失败计数的滑动窗口（分钟）
+	BackoffAfter    int `yaml:"backoff_after"`    // 同一账号失败多少次后开始指数退避
+	MaxBackoff      int `yaml:"max_backoff"`      // 退避等待时间上限（秒）
+	LockoutAfter    int `yaml:"lockout_after"`    // 同一账号失败多少次后临时锁定
+	LockoutDuration int `yaml:"lockout_duration"` // 锁定时长（分钟）
+	MaxIPAttempts   int `yaml:"max_ip_attempts"`  // 同一IP在窗口内允许的失败次数，超出后指数退避
+}
+
+// OIDCConfig 单点登录配置，可以同时启用多个身份提供方
+type OIDCConfig struct {
+	Providers        []OIDCProviderConfig `yaml:"providers"`
+	LoginRedirectURL string               `yaml:"login_redirect_url"` // 登录成功后携带一次性登录代码跳转的前端地址，默认 {public_url}/login/oidc
+}
+
+// OIDCProviderConfig 单个 OpenID Connect 身份提供方
+type OIDCProviderConfig struct {
+	Name         string   `yaml:"name"`         // 用于路由的唯一标识，如 /auth/oidc/{name}/login
+	DisplayName  string   `yaml:"display_name"` // 登录页显示的名称
+	Issuer       string   `yaml:"issuer"`
+	ClientID     string   `yaml:"client_id"`
+	ClientSecret string   `yaml:"client_secret"`
+	RedirectURL  string   `yaml:"redirect_url"` // 默认 {public_url}/api/v1/auth/oidc/{name}/callback
+	Scopes       []string `yaml:"scopes"`
+	DefaultRole  string   `yaml:"default_role"` // 首次登录自动创建用户时的角色，默认 member
+	TrustEmail   bool     `yaml:"trust_email"`  // 提供方不返回 email_verified 时是否视为已验证
+}
+
+// TrashConfig 回收站配置
+type TrashConfig struct {
+	RetentionDays int `yaml:"retention_days"` // 删除的项目和用户在回收站保留的天数，过期后由后台任务永久清除
 }
 
 var AppConfig *Config
@@ -107,6 +183,9 @@ func setDefaults() {
 	if AppConfig.Server.WriteTimeout == 0 {
 		AppConfig.Server.WriteTimeout = 30
 	}
+	if AppConfig.Server.PublicURL == "" {
+		AppConfig.Server.PublicURL = "http://localhost:" + AppConfig.Server.Port
+	}
 	if AppConfig.Database.Host == "" {
 		AppConfig.Database.Host = "localhost"
 	}
@@ -125,10 +204,61 @@ func setDefaults() {
 	if AppConfig.Redis.Port == "" {
 		AppConfig.Redis.Port = "6379"
 	}
+	if AppConfig.Mail.Driver == "" {
+		AppConfig.Mail.Driver = "log"
+	}
+	if AppConfig.Mail.Port == "" {
+		AppConfig.Mail.Port = "587"
+	}
+	if AppConfig.Mail.From == "" {
+		AppConfig.Mail.From = "FlowGo <no-reply@flowgo.local>"
+	}
+	if AppConfig.Security.Login.Window == 0 {
+		AppConfig.Security.Login.Window = 15
+	}
+	if AppConfig.Security.Login.BackoffAfter == 0 {
+		AppConfig.Security.Login.BackoffAfter = 3
+	}
+	if AppConfig.Security.Login.MaxBackoff == 0 {
+		AppConfig.Security.Login.MaxBackoff = 300
+	}
+	if AppConfig.Security.Login.LockoutAfter == 0 {
+		AppConfig.Security.Login.LockoutAfter = 10
+	}
+	if AppConfig.Security.Login.LockoutDuration == 0 {
+		AppConfig.Security.Login.LockoutDuration = 15
+	}
+	if AppConfig.Security.Login.MaxIPAttempts == 0 {
+		AppConfig.Security.Login.MaxIPAttempts = 50
+	}
+	for i := range AppConfig.OIDC.Providers {
+		p := &AppConfig.OIDC.Providers[i]
+		if p.DisplayName == "" {
+			p.DisplayName = p.Name
+		}
+		if p.RedirectURL == "" {
+			p.RedirectURL = strings.TrimRight(AppConfig.Server.PublicURL, "/") + "/api/v1/auth/oidc/" + p.Name + "/callback"
+		}
+	}
+	if AppConfig.OIDC.LoginRedirectURL == "" {
+		AppConfig.OIDC.LoginRedirectURL = strings.TrimRight(AppConfig.Server.PublicURL, "/") + "/login/oidc"
+	}
+	if AppConfig.Trash.RetentionDays == 0 {
+		AppConfig.Trash.RetentionDays = 30
+	}
 	if AppConfig.JWT.SecretKey == "" {
 		AppConfig.JWT.SecretKey = "your-secret-key-change-in-production"
 	}
+	if AppConfig.Security.EncryptionKey == "" {
+		AppConfig.Security.EncryptionKey = AppConfig.JWT.SecretKey
+	}
 	if AppConfig.JWT.Expiration == 0 {
 		AppConfig.JWT.Expiration = 24 // 默认24小时
+		if AppConfig.JWT.AccessExpiration == 0 {
+			AppConfig.JWT.AccessExpiration = 15 // 未配置旧的 expiration 时，访问Token默认15分钟
+		}
+	}
+	if AppConfig.JWT.RefreshExpiration == 0 {
+		AppConfig.JWT.RefreshExpiration = 24 * 7 // 默认7天
 	}
 }
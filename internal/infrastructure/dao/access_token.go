@@ -0,0 +1,20 @@
+package dao
+
+import "time"
+
+// AccessTokenPO 个人访问令牌持久化对象
+type AccessTokenPO struct {
+	BasePO
+	UserId     uint64     `gorm:"column:user_id;not null;index"`
+	Name       string     `gorm:"column:name;type:varchar(100);not null"`
+	Prefix     string     `gorm:"column:prefix;type:varchar(20)"`
+	TokenHash  string     `gorm:"column:token_hash;type:varchar(64);uniqueIndex;not null"`
+	Scopes     string     `gorm:"column:scopes;type:varchar(1000)"` // 逗号分隔
+	ExpiresAt  *time.Time `gorm:"column:expires_at"`
+	LastUsedAt *time.Time `gorm:"column:last_used_at"`
+	RevokedAt  *time.Time `gorm:"column:revoked_at"`
+}
+
+func (AccessTokenPO) TableName() string {
+	return "access_tokens"
+}
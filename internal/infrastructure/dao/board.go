@@ -0,0 +1,26 @@
+package dao
+
+// BoardPO 看板持久化对象
+type BoardPO struct {
+	BasePO
+	ProjectId uint64 `gorm:"column:project_id;not null;index"`
+	Name      string `gorm:"column:name;type:varchar(100);not null"`
+}
+
+func (BoardPO) TableName() string {
+	return "boards"
+}
+
+// BoardColumnPO 看板列持久化对象，随看板一起物理删除
+type BoardColumnPO struct {
+	ID       uint64 `gorm:"primaryKey;autoIncrement"`
+	BoardId  uint64 `gorm:"column:board_id;not null;index"`
+	Name     string `gorm:"column:name;type:varchar(50);not null"`
+	Status   string `gorm:"column:status;type:varchar(16);not null"`
+	Position int    `gorm:"column:position;not null"`
+	WIPLimit int    `gorm:"column:wip_limit;not null"`
+}
+
+func (BoardColumnPO) TableName() string {
+	return "board_columns"
+}
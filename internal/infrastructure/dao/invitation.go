@@ -0,0 +1,25 @@
+package dao
+
+import "time"
+
+// InvitationPO 邀请持久化对象
+type InvitationPO struct {
+	BasePO
+	Email       string    `gorm:"column:email;type:varchar(255);not null;index"`
+	Role        string    `gorm:"column:role;type:varchar(16);not null"`
+	TeamId      uint64    `gorm:"column:team_id"`
+	TeamRole    string    `gorm:"column:team_role;type:varchar(16)"`
+	ProjectId   uint64    `gorm:"column:project_id"`
+	ProjectRole string    `gorm:"column:project_role;type:varchar(16)"`
+	InvitedBy   uint64    `gorm:"column:invited_by;not null"`
+	TokenHash   string    `gorm:"column:token_hash;type:varchar(64);uniqueIndex;not null"`
+	ExpiresAt   time.Time `gorm:"column:expires_at;not null"`
+
+	AcceptedAt     *time.Time `gorm:"column:accepted_at"`
+	AcceptedUserId uint64     `gorm:"column:accepted_user_id"`
+	RevokedAt      *time.Time `gorm:"column:revoked_at"`
+}
+
+func (InvitationPO) TableName() string {
+	return "invitations"
+}
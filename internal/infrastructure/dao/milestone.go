@@ -0,0 +1,19 @@
+package dao
+
+import "time"
+
+// MilestonePO 里程碑持久化对象
+type MilestonePO struct {
+	BasePO
+	ProjectId   uint64     `gorm:"column:project_id;not null;index"`
+	Name        string     `gorm:"column:name;type:varchar(100);not null"`
+	Description string     `gorm:"column:description;type:text"`
+	DueDate     *time.Time `gorm:"column:due_date"`
+	State       string     `gorm:"column:state;type:varchar(16);not null;default:open"`
+	Position    int        `gorm:"column:position;not null"`
+	ClosedAt    *time.Time `gorm:"column:closed_at"`
+}
+
+func (MilestonePO) TableName() string {
+	return "milestones"
+}
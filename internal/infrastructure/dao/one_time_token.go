@@ -0,0 +1,18 @@
+package dao
+
+import "time"
+
+// OneTimeTokenPO 一次性令牌持久化对象
+type OneTimeTokenPO struct {
+	BasePO
+	UserId    uint64     `gorm:"column:user_id;not null;index"`
+	Purpose   string     `gorm:"column:purpose;type:varchar(32);not null"`
+	Email     string     `gorm:"column:email;type:varchar(100);not null;default:''"`
+	TokenHash string     `gorm:"column:token_hash;type:varchar(64);uniqueIndex;not null"`
+	ExpiresAt time.Time  `gorm:"column:expires_at;not null"`
+	UsedAt    *time.Time `gorm:"column:used_at"`
+}
+
+func (OneTimeTokenPO) TableName() string {
+	return "one_time_tokens"
+}
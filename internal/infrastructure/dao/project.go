@@ -16,6 +16,9 @@ type ProjectPO struct {
 	Progress    int        `gorm:"default:0"`
 	Priority    int        `gorm:"type:tinyint;default:2"`
 	CoverImage  string     `gorm:"type:varchar(255)"`
+
+	ProgressMode string `gorm:"type:varchar(16);default:tasks"`
+	Version      uint64 `gorm:"not null;default:1"`
 }
 
 func (ProjectPO) TableName() string {
@@ -0,0 +1,18 @@
+package dao
+
+import "time"
+
+// ProjectStatusChangePO 项目状态变更历史持久化对象，只追加不修改
+type ProjectStatusChangePO struct {
+	ID         uint64    `gorm:"primaryKey;autoIncrement"`
+	ProjectId  uint64    `gorm:"column:project_id;not null;index"`
+	FromStatus int       `gorm:"column:from_status;type:tinyint;not null"`
+	ToStatus   int       `gorm:"column:to_status;type:tinyint;not null"`
+	Reason     string    `gorm:"column:reason;type:varchar(500)"`
+	OperatorId uint64    `gorm:"column:operator_id"`
+	CreatedAt  time.Time `gorm:"autoCreateTime"`
+}
+
+func (ProjectStatusChangePO) TableName() string {
+	return "project_status_changes"
+}
@@ -3,9 +3,9 @@ package dao
 // ProjectUserPO 项目用户关联表
 type ProjectUserPO struct {
 	BasePO
-	ProjectId uint64 `json:"project_id"`
-	UserId    uint64 `json:"user_id"`
-	Role      string `json:"role"`
+	ProjectId uint64 `json:"project_id" gorm:"index"`
+	UserId    uint64 `json:"user_id" gorm:"index"`
+	Role      string `json:"role"` // owner, maintainer, member, viewer
 }
 
 func (ProjectUserPO) TableName() string {
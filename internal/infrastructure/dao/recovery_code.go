@@ -0,0 +1,15 @@
+package dao
+
+import "time"
+
+// RecoveryCodePO 两步验证恢复码持久化对象，只保存摘要
+type RecoveryCodePO struct {
+	BasePO
+	UserId   uint64     `gorm:"column:user_id;not null;index"`
+	CodeHash string     `gorm:"column:code_hash;type:varchar(64);not null"`
+	UsedAt   *time.Time `gorm:"column:used_at"`
+}
+
+func (RecoveryCodePO) TableName() string {
+	return "user_recovery_codes"
+}
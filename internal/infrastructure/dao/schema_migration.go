@@ -0,0 +1,13 @@
+package dao
+
+import "time"
+
+// SchemaMigrationPO 已执行的一次性数据迁移记录
+type SchemaMigrationPO struct {
+	Version   string    `gorm:"column:version;primaryKey;type:varchar(64)"`
+	AppliedAt time.Time `gorm:"column:applied_at;not null"`
+}
+
+func (SchemaMigrationPO) TableName() string {
+	return "schema_migrations"
+}
@@ -0,0 +1,19 @@
+package dao
+
+import "time"
+
+// SecurityEventPO 安全事件持久化对象，只追加不修改
+type SecurityEventPO struct {
+	ID        uint64    `gorm:"primaryKey;autoIncrement"`
+	Type      string    `gorm:"column:type;type:varchar(32);not null;index"`
+	UserId    uint64    `gorm:"column:user_id;index"`
+	Username  string    `gorm:"column:username;type:varchar(255)"`
+	IP        string    `gorm:"column:ip;type:varchar(64)"`
+	ActorId   uint64    `gorm:"column:actor_id"`
+	Detail    string    `gorm:"column:detail;type:varchar(512)"`
+	CreatedAt time.Time `gorm:"autoCreateTime;index"`
+}
+
+func (SecurityEventPO) TableName() string {
+	return "security_events"
+}
@@ -0,0 +1,25 @@
+package dao
+
+import "time"
+
+// TagPO 标签持久化对象
+type TagPO struct {
+	BasePO
+	Name  string `gorm:"column:name;type:varchar(50);not null;uniqueIndex"`
+	Color string `gorm:"column:color;type:varchar(7);not null"`
+}
+
+func (TagPO) TableName() string {
+	return "tags"
+}
+
+// ProjectTagPO 项目标签关联表，解除关联时物理删除
+type ProjectTagPO struct {
+	ProjectId uint64    `gorm:"column:project_id;primaryKey"`
+	TagId     uint64    `gorm:"column:tag_id;primaryKey;index"`
+	CreatedAt time.Time `gorm:"autoCreateTime"`
+}
+
+func (ProjectTagPO) TableName() string {
+	return "project_tags"
+}
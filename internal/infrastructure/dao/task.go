@@ -0,0 +1,23 @@
+package dao
+
+import "time"
+
+// TaskPO 任务持久化对象
+type TaskPO struct {
+	BasePO
+	ProjectId   uint64     `gorm:"column:project_id;not null;index;index:idx_tasks_column,priority:1"`
+	Title       string     `gorm:"column:title;type:varchar(200);not null"`
+	Description string     `gorm:"column:description;type:text"`
+	AssigneeId  uint64     `gorm:"column:assignee_id;index"`
+	ReporterId  uint64     `gorm:"column:reporter_id;not null;index"`
+	Status      string     `gorm:"column:status;type:varchar(16);not null;default:todo;index:idx_tasks_column,priority:2"`
+	Priority    int        `gorm:"column:priority;type:tinyint;not null"`
+	DueDate     *time.Time `gorm:"column:due_date"`
+	Estimate    float64    `gorm:"column:estimate;not null"`
+	Rank        string     `gorm:"column:rank_key;type:varchar(64);not null;default:'';index:idx_tasks_column,priority:3"`
+	CompletedAt *time.Time `gorm:"column:completed_at"`
+}
+
+func (TaskPO) TableName() string {
+	return "tasks"
+}
@@ -5,6 +5,7 @@ type TeamPO struct {
 	Name        string `gorm:"column:name;not null"`
 	Description string `gorm:"column:description"`
 	OwnerId     uint64 `gorm:"column:owner_id"`
+	ParentId    uint64 `gorm:"column:parent_id;index"`
 }
 
 func (TeamPO) TableName() string {
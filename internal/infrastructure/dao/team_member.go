@@ -0,0 +1,13 @@
+package dao
+
+// TeamMemberPO 团队成员关联表，一个用户可以加入多个团队
+type TeamMemberPO struct {
+	BasePO
+	TeamId uint64 `gorm:"column:team_id;not null;uniqueIndex:idx_team_user"`
+	UserId uint64 `gorm:"column:user_id;not null;uniqueIndex:idx_team_user;index"`
+	Role   string `gorm:"column:role;type:varchar(16);not null"` // lead, member
+}
+
+func (TeamMemberPO) TableName() string {
+	return "team_members"
+}
@@ -2,12 +2,13 @@ package dao
 
 type UserPO struct {
 	BasePO
-	Name   string `json:"name"`
-	Email  string `json:"email"`
-	Avatar string `json:"avatar"`
-	TeamID uint64 `json:"team_id"`
-	Role   string `json:"role"`
-	Status string `json:"status"`
+	Name        string `json:"name"`
+	DisplayName string `json:"display_name"`
+	Email       string `json:"email"`
+	Avatar      string `json:"avatar"`
+	TeamID      uint64 `json:"team_id"`
+	Role        string `json:"role"`
+	Status      int    `json:"status"` // 1:正常 2:禁用
 }
 
 func (UserPO) TableName() string {
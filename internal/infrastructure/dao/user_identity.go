@@ -0,0 +1,14 @@
+package dao
+
+// UserIdentityPO 外部身份绑定持久化对象
+type UserIdentityPO struct {
+	BasePO
+	UserId   uint64 `gorm:"column:user_id;not null;index"`
+	Provider string `gorm:"column:provider;type:varchar(64);not null;uniqueIndex:idx_provider_subject"`
+	Subject  string `gorm:"column:subject;type:varchar(255);not null;uniqueIndex:idx_provider_subject"`
+	Email    string `gorm:"column:email;type:varchar(255)"`
+}
+
+func (UserIdentityPO) TableName() string {
+	return "user_identities"
+}
@@ -10,6 +10,7 @@ import (
 
 	"FLOWGO/internal/domain/entity"
 	"FLOWGO/internal/infrastructure/config"
+	"FLOWGO/internal/infrastructure/dao"
 )
 
 var DB *gorm.DB
@@ -39,11 +40,37 @@ func InitDB() error {
 		&entity.User{},
 		&entity.Project{},
 		&entity.Team{},
+		&dao.ProjectTeamPO{},
+		&dao.ProjectUserPO{},
+		&dao.AccessTokenPO{},
+		&dao.OneTimeTokenPO{},
+		&dao.RecoveryCodePO{},
+		&dao.SecurityEventPO{},
+		&dao.UserIdentityPO{},
+		&dao.TeamMemberPO{},
+		&dao.InvitationPO{},
+		&dao.TaskPO{},
+		&dao.BoardPO{},
+		&dao.BoardColumnPO{},
+		&dao.TagPO{},
+		&dao.ProjectTagPO{},
+		&dao.ProjectStatusChangePO{},
+		&dao.MilestonePO{},
+		&dao.SchemaMigrationPO{},
 		&entity.VisitStat{}, // IP 统计
 	)
 	if err != nil {
 		return fmt.Errorf("failed to migrate database: %w", err)
 	}
+	if err := MigrateTeamMembers(DB); err != nil {
+		return fmt.Errorf("failed to migrate team members: %w", err)
+	}
+	if err := MigrateUserUniqueIndexes(DB); err != nil {
+		return fmt.Errorf("failed to migrate user indexes: %w", err)
+	}
+	if err := MigrateTaskRanks(DB); err != nil {
+		return fmt.Errorf("failed to migrate task ranks: %w", err)
+	}
 
 	// Enable Foreign Keys for SQLite
 	if err := DB.Exec("PRAGMA foreign_keys = ON").Error; err != nil {
@@ -0,0 +1,112 @@
+package database
+
+import (
+	"log"
+	"time"
+
+	"FLOWGO/internal/infrastructure/dao"
+	"FLOWGO/pkg/rank"
+
+	"gorm.io/gorm"
+)
+
+// runOnce 在事务中执行一次性数据迁移，并在 schema_migrations 中记录版本，已记录的版本直接跳过
+func runOnce(db *gorm.DB, version string, migrate func(tx *gorm.DB) error) error {
+	return db.Transaction(func(tx *gorm.DB) error {
+		var count int64
+		if err := tx.Model(&dao.SchemaMigrationPO{}).Where("version = ?", version).Count(&count).Error; err != nil {
+			return err
+		}
+		if count > 0 {
+			return nil
+		}
+		if err := migrate(tx); err != nil {
+			return err
+		}
+		return tx.Create(&dao.SchemaMigrationPO{Version: version, AppliedAt: time.Now()}).Error
+	})
+}
+
+// MigrateTeamMembers 将旧的 users.team_id 单团队数据迁移到 team_members 关联表
+// 团队负责人迁移为 lead，其余为 member；只执行一次，之后移除的成员不会因为旧的 team_id 被重新加入
+func MigrateTeamMembers(db *gorm.DB) error {
+	return runOnce(db, "20261017_team_members", func(tx *gorm.DB) error {
+		res := tx.Exec(`
+			INSERT INTO team_members (team_id, user_id, role, created_at, updated_at)
+			SELECT u.team_id, u.id,
+				CASE WHEN t.owner_id = u.id THEN 'lead' ELSE 'member' END,
+				CURRENT_TIMESTAMP, CURRENT_TIMESTAMP
+			FROM users u
+			JOIN teams t ON t.id = u.team_id AND t.deleted_at IS NULL
+			WHERE u.team_id <> 0 AND u.deleted_at IS NULL
+				AND NOT EXISTS (
+					SELECT 1 FROM team_members m WHERE m.team_id = u.team_id AND m.user_id = u.id
+				)`)
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected > 0 {
+			log.Printf("Migrated %d team memberships from users.team_id", res.RowsAffected)
+		}
+		return nil
+	})
+}
+
+// MigrateUserUniqueIndexes 删除旧的用户名、邮箱唯一索引，改由只约束未删除用户的部分索引（AutoMigrate 创建）保证唯一
+// 旧索引覆盖回收站中的用户，会导致删除后的用户名和邮箱无法再注册
+func MigrateUserUniqueIndexes(db *gorm.DB) error {
+	return runOnce(db, "20261017_user_active_unique", func(tx *gorm.DB) error {
+		for _, index := range []string{"idx_users_name", "idx_users_email"} {
+			if err := tx.Exec("DROP INDEX IF EXISTS " + index).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// MigrateTaskRanks 为还没有排序键的任务生成排序键，按创建顺序追加到所在项目的末尾
+// 只处理排序键为空的任务，可重复执行
+func MigrateTaskRanks(db *gorm.DB) error {
+	var tasks []struct {
+		ID        uint64
+		ProjectId uint64
+	}
+	if err := db.Table("tasks").
+		Select("id, project_id").
+		Where("rank_key = '' AND deleted_at IS NULL").
+		Order("project_id, id").
+		Scan(&tasks).Error; err != nil {
+		return err
+	}
+
+	last := make(map[uint64]string)
+	for _, t := range tasks {
+		prev, ok := last[t.ProjectId]
+		if !ok {
+			var ranks []string
+			if err := db.Table("tasks").
+				Where("project_id = ? AND deleted_at IS NULL", t.ProjectId).
+				Order("rank_key DESC").
+				Limit(1).
+				Pluck("rank_key", &ranks).Error; err != nil {
+				return err
+			}
+			if len(ranks) > 0 {
+				prev = ranks[0]
+			}
+		}
+		next, err := rank.After(prev)
+		if err != nil {
+			return err
+		}
+		if err := db.Table("tasks").Where("id = ?", t.ID).Update("rank_key", next).Error; err != nil {
+			return err
+		}
+		last[t.ProjectId] = next
+	}
+	if len(tasks) > 0 {
+		log.Printf("Generated board ranks for %d tasks", len(tasks))
+	}
+	return nil
+}
@@ -0,0 +1,137 @@
+package repository
+
+import (
+	"FLOWGO/internal/domain/entity"
+	"FLOWGO/internal/domain/repository"
+	"FLOWGO/internal/infrastructure/dao"
+	"context"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// accessTokenRepository 个人访问令牌仓储实现
+type accessTokenRepository struct {
+	db *gorm.DB
+}
+
+// NewAccessTokenRepository 创建个人访问令牌仓储实例
+func NewAccessTokenRepository(db *gorm.DB) repository.AccessTokenRepository {
+	return &accessTokenRepository{db: db}
+}
+
+// Create 创建令牌
+func (r *accessTokenRepository) Create(ctx context.Context, token *entity.AccessToken) error {
+	po := r.toPO(token)
+	if err := r.db.WithContext(ctx).Create(po).Error; err != nil {
+		return err
+	}
+	token.ID = po.ID
+	token.CreatedAt = po.CreatedAt
+	token.UpdatedAt = po.UpdatedAt
+	return nil
+}
+
+// FindByID 根据ID查找
+func (r *accessTokenRepository) FindByID(ctx context.Context, id uint64) (*entity.AccessToken, error) {
+	var po dao.AccessTokenPO
+	err := r.db.WithContext(ctx).Where("id = ?", id).First(&po).Error
+	if err == gorm.ErrRecordNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return r.toEntity(&po), nil
+}
+
+// FindByHash 根据令牌摘要查找
+func (r *accessTokenRepository) FindByHash(ctx context.Context, tokenHash string) (*entity.AccessToken, error) {
+	var po dao.AccessTokenPO
+	err := r.db.WithContext(ctx).Where("token_hash = ?", tokenHash).First(&po).Error
+	if err == gorm.ErrRecordNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return r.toEntity(&po), nil
+}
+
+// ListByUserID 查询用户的全部令牌
+func (r *accessTokenRepository) ListByUserID(ctx context.Context, userID uint64) ([]*entity.AccessToken, error) {
+	var pos []*dao.AccessTokenPO
+	err := r.db.WithContext(ctx).
+		Where("user_id = ?", userID).
+		Order("created_at DESC").
+		Find(&pos).Error
+	if err != nil {
+		return nil, err
+	}
+	tokens := make([]*entity.AccessToken, len(pos))
+	for i, po := range pos {
+		tokens[i] = r.toEntity(po)
+	}
+	return tokens, nil
+}
+
+// Revoke 吊销令牌
+func (r *accessTokenRepository) Revoke(ctx context.Context, id uint64, revokedAt time.Time) error {
+	return r.db.WithContext(ctx).Model(&dao.AccessTokenPO{}).
+		Where("id = ? AND revoked_at IS NULL", id).
+		Update("revoked_at", revokedAt).Error
+}
+
+// TouchLastUsed 更新最后使用时间
+func (r *accessTokenRepository) TouchLastUsed(ctx context.Context, id uint64, usedAt time.Time) error {
+	return r.db.WithContext(ctx).Model(&dao.AccessTokenPO{}).
+		Where("id = ?", id).
+		UpdateColumn("last_used_at", usedAt).Error
+}
+
+// Helper methods
+
+func (r *accessTokenRepository) toPO(e *entity.AccessToken) *dao.AccessTokenPO {
+	return &dao.AccessTokenPO{
+		BasePO: dao.BasePO{
+			ID:        e.ID,
+			CreatedAt: e.CreatedAt,
+			UpdatedAt: e.UpdatedAt,
+		},
+		UserId:     e.UserID,
+		Name:       e.Name,
+		Prefix:     e.Prefix,
+		TokenHash:  e.TokenHash,
+		Scopes:     strings.Join(e.Scopes, ","),
+		ExpiresAt:  e.ExpiresAt,
+		LastUsedAt: e.LastUsedAt,
+		RevokedAt:  e.RevokedAt,
+	}
+}
+
+func (r *accessTokenRepository) toEntity(po *dao.AccessTokenPO) *entity.AccessToken {
+	var scopes []string
+	if po.Scopes != "" {
+		scopes = strings.Split(po.Scopes, ",")
+	}
+	e := &entity.AccessToken{
+		BaseEntity: entity.BaseEntity{
+			ID:        po.ID,
+			CreatedAt: po.CreatedAt,
+			UpdatedAt: po.UpdatedAt,
+		},
+		UserID:     po.UserId,
+		Name:       po.Name,
+		Prefix:     po.Prefix,
+		TokenHash:  po.TokenHash,
+		Scopes:     scopes,
+		ExpiresAt:  po.ExpiresAt,
+		LastUsedAt: po.LastUsedAt,
+		RevokedAt:  po.RevokedAt,
+	}
+	if po.DeletedAt.Valid {
+		e.DeletedAt = &po.DeletedAt.Time
+	}
+	return e
+}
@@ -0,0 +1,207 @@
+package repository
+
+import (
+	"FLOWGO/internal/domain/entity"
+	"FLOWGO/internal/domain/repository"
+	"FLOWGO/internal/infrastructure/dao"
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// boardRepository 看板仓储实现
+type boardRepository struct {
+	db *gorm.DB
+}
+
+// NewBoardRepository 创建看板仓储实例
+func NewBoardRepository(db *gorm.DB) repository.BoardRepository {
+	return &boardRepository{db: db}
+}
+
+// Create 创建看板及其全部列
+func (r *boardRepository) Create(ctx context.Context, board *entity.Board) error {
+	po := r.toPO(board)
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(po).Error; err != nil {
+			return err
+		}
+		board.ID = po.ID
+		return r.saveColumns(tx, board)
+	})
+	if err != nil {
+		return err
+	}
+	board.CreatedAt = po.CreatedAt
+	board.UpdatedAt = po.UpdatedAt
+	return nil
+}
+
+// FindByID 根据ID查找，同时加载全部列
+func (r *boardRepository) FindByID(ctx context.Context, id uint64) (*entity.Board, error) {
+	var po dao.BoardPO
+	err := r.db.WithContext(ctx).Where("id = ?", id).First(&po).Error
+	if err == gorm.ErrRecordNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	boards, err := r.withColumns(ctx, []*dao.BoardPO{&po})
+	if err != nil {
+		return nil, err
+	}
+	return boards[0], nil
+}
+
+// Update 保存看板名称和列
+func (r *boardRepository) Update(ctx context.Context, board *entity.Board) error {
+	board.UpdatedAt = time.Now()
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&dao.BoardPO{BasePO: dao.BasePO{ID: board.ID}}).
+			Select("name", "updated_at").
+			Updates(r.toPO(board)).Error; err != nil {
+			return err
+		}
+
+		keep := make([]uint64, 0, len(board.Columns))
+		for _, col := range board.Columns {
+			if col.ID != 0 {
+				keep = append(keep, col.ID)
+			}
+		}
+		query := tx.Where("board_id = ?", board.ID)
+		if len(keep) > 0 {
+			query = query.Where("id NOT IN ?", keep)
+		}
+		if err := query.Delete(&dao.BoardColumnPO{}).Error; err != nil {
+			return err
+		}
+		return r.saveColumns(tx, board)
+	})
+}
+
+// Delete 删除看板（软删除），看板列物理删除，任务不受影响
+func (r *boardRepository) Delete(ctx context.Context, id uint64) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Delete(&dao.BoardColumnPO{}, "board_id = ?", id).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&dao.BoardPO{}, id).Error
+	})
+}
+
+// ListByProject 查询项目的全部看板，按创建顺序排列
+func (r *boardRepository) ListByProject(ctx context.Context, projectID uint64) ([]*entity.Board, error) {
+	var pos []*dao.BoardPO
+	if err := r.db.WithContext(ctx).
+		Where("project_id = ?", projectID).
+		Order("id ASC").
+		Find(&pos).Error; err != nil {
+		return nil, err
+	}
+	return r.withColumns(ctx, pos)
+}
+
+// saveColumns 新建或更新看板的列，回写新建列的ID
+func (r *boardRepository) saveColumns(tx *gorm.DB, board *entity.Board) error {
+	for _, col := range board.Columns {
+		col.BoardID = board.ID
+		po := r.columnToPO(col)
+		if col.ID == 0 {
+			if err := tx.Create(po).Error; err != nil {
+				return err
+			}
+			col.ID = po.ID
+			continue
+		}
+		if err := tx.Model(&dao.BoardColumnPO{}).
+			Where("id = ? AND board_id = ?", col.ID, board.ID).
+			Select("name", "status", "position", "wip_limit").
+			Updates(po).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// withColumns 批量加载看板的列
+func (r *boardRepository) withColumns(ctx context.Context, pos []*dao.BoardPO) ([]*entity.Board, error) {
+	boards := make([]*entity.Board, len(pos))
+	if len(pos) == 0 {
+		return boards, nil
+	}
+	ids := make([]uint64, len(pos))
+	byID := make(map[uint64]*entity.Board, len(pos))
+	for i, po := range pos {
+		boards[i] = r.toEntity(po)
+		ids[i] = po.ID
+		byID[po.ID] = boards[i]
+	}
+
+	var cols []*dao.BoardColumnPO
+	if err := r.db.WithContext(ctx).
+		Where("board_id IN ?", ids).
+		Order("position ASC, id ASC").
+		Find(&cols).Error; err != nil {
+		return nil, err
+	}
+	for _, col := range cols {
+		if board := byID[col.BoardId]; board != nil {
+			board.Columns = append(board.Columns, r.columnToEntity(col))
+		}
+	}
+	return boards, nil
+}
+
+func (r *boardRepository) toPO(e *entity.Board) *dao.BoardPO {
+	return &dao.BoardPO{
+		BasePO: dao.BasePO{
+			ID:        e.ID,
+			CreatedAt: e.CreatedAt,
+			UpdatedAt: e.UpdatedAt,
+		},
+		ProjectId: e.ProjectID,
+		Name:      e.Name,
+	}
+}
+
+func (r *boardRepository) toEntity(po *dao.BoardPO) *entity.Board {
+	e := &entity.Board{
+		BaseEntity: entity.BaseEntity{
+			ID:        po.ID,
+			CreatedAt: po.CreatedAt,
+			UpdatedAt: po.UpdatedAt,
+		},
+		ProjectID: po.ProjectId,
+		Name:      po.Name,
+		Columns:   []*entity.BoardColumn{},
+	}
+	if po.DeletedAt.Valid {
+		e.DeletedAt = &po.DeletedAt.Time
+	}
+	return e
+}
+
+func (r *boardRepository) columnToPO(e *entity.BoardColumn) *dao.BoardColumnPO {
+	return &dao.BoardColumnPO{
+		ID:       e.ID,
+		BoardId:  e.BoardID,
+		Name:     e.Name,
+		Status:   string(e.Status),
+		Position: e.Position,
+		WIPLimit: e.WIPLimit,
+	}
+}
+
+func (r *boardRepository) columnToEntity(po *dao.BoardColumnPO) *entity.BoardColumn {
+	return &entity.BoardColumn{
+		ID:       po.ID,
+		BoardID:  po.BoardId,
+		Name:     po.Name,
+		Status:   entity.TaskStatus(po.Status),
+		Position: po.Position,
+		WIPLimit: po.WIPLimit,
+	}
+}
@@ -0,0 +1,200 @@
+package repository
+
+import (
+	"FLOWGO/internal/domain/entity"
+	"FLOWGO/internal/domain/repository"
+	"FLOWGO/internal/infrastructure/dao"
+	"context"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// errInvitationNotPending 接受邀请时邀请已不可用，用于回滚事务
+var errInvitationNotPending = errors.New("invitation is no longer pending")
+
+// invitationRepository 邀请仓储实现
+type invitationRepository struct {
+	db *gorm.DB
+}
+
+// NewInvitationRepository 创建邀请仓储实例
+func NewInvitationRepository(db *gorm.DB) repository.InvitationRepository {
+	return &invitationRepository{db: db}
+}
+
+// Create 创建邀请
+func (r *invitationRepository) Create(ctx context.Context, invitation *entity.Invitation) error {
+	po := r.toPO(invitation)
+	if err := r.db.WithContext(ctx).Create(po).Error; err != nil {
+		return err
+	}
+	invitation.ID = po.ID
+	invitation.CreatedAt = po.CreatedAt
+	invitation.UpdatedAt = po.UpdatedAt
+	return nil
+}
+
+// FindByID 根据ID查找
+func (r *invitationRepository) FindByID(ctx context.Context, id uint64) (*entity.Invitation, error) {
+	return r.findOne(r.db.WithContext(ctx).Where("id = ?", id))
+}
+
+// FindByTokenHash 根据链接令牌摘要查找
+func (r *invitationRepository) FindByTokenHash(ctx context.Context, tokenHash string) (*entity.Invitation, error) {
+	return r.findOne(r.db.WithContext(ctx).Where("token_hash = ?", tokenHash))
+}
+
+// FindPendingByEmail 查找邮箱在 now 时刻仍有效的邀请
+func (r *invitationRepository) FindPendingByEmail(ctx context.Context, email string, now time.Time) (*entity.Invitation, error) {
+	query := r.db.WithContext(ctx).Where("email = ?", email)
+	return r.findOne(withInvitationStatus(query, entity.InvitationPending, now))
+}
+
+// Update 保存链接、有效期和撤销时间
+func (r *invitationRepository) Update(ctx context.Context, invitation *entity.Invitation) error {
+	invitation.UpdatedAt = time.Now()
+	return r.db.WithContext(ctx).Model(&dao.InvitationPO{BasePO: dao.BasePO{ID: invitation.ID}}).
+		Select("token_hash", "expires_at", "revoked_at", "updated_at").
+		Updates(r.toPO(invitation)).Error
+}
+
+// Accept 先创建用户，再按链接令牌和有效期条件更新邀请，保证同一邀请只能被接受一次
+// 任一步骤失败时整个事务回滚，邀请保持可用
+func (r *invitationRepository) Accept(ctx context.Context, invitation *entity.Invitation, user *entity.User, teamID, projectID uint64, at time.Time) (bool, error) {
+	accepted := false
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		user.CreatedAt = at
+		user.UpdatedAt = at
+		if err := tx.Create(user).Error; err != nil {
+			return err
+		}
+		res := tx.Model(&dao.InvitationPO{}).
+			Where("id = ? AND token_hash = ? AND accepted_at IS NULL AND revoked_at IS NULL AND expires_at > ?",
+				invitation.ID, invitation.TokenHash, at).
+			Updates(map[string]interface{}{"accepted_at": at, "accepted_user_id": user.ID})
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			return errInvitationNotPending
+		}
+		if teamID != 0 {
+			member := &dao.TeamMemberPO{TeamId: teamID, UserId: user.ID, Role: string(invitation.TeamRole)}
+			if err := tx.Create(member).Error; err != nil {
+				return err
+			}
+		}
+		if projectID != 0 {
+			link := &dao.ProjectUserPO{ProjectId: projectID, UserId: user.ID, Role: string(invitation.ProjectRole)}
+			if err := tx.Create(link).Error; err != nil {
+				return err
+			}
+		}
+		accepted = true
+		return nil
+	})
+	if err == errInvitationNotPending {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	invitation.AcceptedAt = &at
+	invitation.AcceptedUserID = user.ID
+	return accepted, nil
+}
+
+// List 按创建时间倒序分页查询
+func (r *invitationRepository) List(ctx context.Context, status entity.InvitationStatus, now time.Time, page, pageSize int) ([]*entity.Invitation, int64, error) {
+	query := withInvitationStatus(r.db.WithContext(ctx).Model(&dao.InvitationPO{}), status, now)
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var pos []*dao.InvitationPO
+	offset := (page - 1) * pageSize
+	if err := query.Order("id DESC").Offset(offset).Limit(pageSize).Find(&pos).Error; err != nil {
+		return nil, 0, err
+	}
+
+	invitations := make([]*entity.Invitation, len(pos))
+	for i, po := range pos {
+		invitations[i] = r.toEntity(po)
+	}
+	return invitations, total, nil
+}
+
+func (r *invitationRepository) findOne(query *gorm.DB) (*entity.Invitation, error) {
+	var po dao.InvitationPO
+	err := query.Order("id DESC").First(&po).Error
+	if err == gorm.ErrRecordNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return r.toEntity(&po), nil
+}
+
+// withInvitationStatus 按邀请状态过滤，与 entity.Invitation.Status 的推导规则一致
+func withInvitationStatus(query *gorm.DB, status entity.InvitationStatus, now time.Time) *gorm.DB {
+	switch status {
+	case entity.InvitationAccepted:
+		return query.Where("accepted_at IS NOT NULL")
+	case entity.InvitationRevoked:
+		return query.Where("accepted_at IS NULL AND revoked_at IS NOT NULL")
+	case entity.InvitationExpired:
+		return query.Where("accepted_at IS NULL AND revoked_at IS NULL AND expires_at <= ?", now)
+	case entity.InvitationPending:
+		return query.Where("accepted_at IS NULL AND revoked_at IS NULL AND expires_at > ?", now)
+	}
+	return query
+}
+
+func (r *invitationRepository) toPO(e *entity.Invitation) *dao.InvitationPO {
+	return &dao.InvitationPO{
+		BasePO: dao.BasePO{
+			ID:        e.ID,
+			CreatedAt: e.CreatedAt,
+			UpdatedAt: e.UpdatedAt,
+		},
+		Email:          e.Email,
+		Role:           e.Role,
+		TeamId:         e.TeamID,
+		TeamRole:       string(e.TeamRole),
+		ProjectId:      e.ProjectID,
+		ProjectRole:    string(e.ProjectRole),
+		InvitedBy:      e.InvitedBy,
+		TokenHash:      e.TokenHash,
+		ExpiresAt:      e.ExpiresAt,
+		AcceptedAt:     e.AcceptedAt,
+		AcceptedUserId: e.AcceptedUserID,
+		RevokedAt:      e.RevokedAt,
+	}
+}
+
+func (r *invitationRepository) toEntity(po *dao.InvitationPO) *entity.Invitation {
+	return &entity.Invitation{
+		BaseEntity: entity.BaseEntity{
+			ID:        po.ID,
+			CreatedAt: po.CreatedAt,
+			UpdatedAt: po.UpdatedAt,
+		},
+		Email:          po.Email,
+		Role:           po.Role,
+		TeamID:         po.TeamId,
+		TeamRole:       entity.TeamRole(po.TeamRole),
+		ProjectID:      po.ProjectId,
+		ProjectRole:    entity.ProjectRole(po.ProjectRole),
+		InvitedBy:      po.InvitedBy,
+		TokenHash:      po.TokenHash,
+		ExpiresAt:      po.ExpiresAt,
+		AcceptedAt:     po.AcceptedAt,
+		AcceptedUserID: po.AcceptedUserId,
+		RevokedAt:      po.RevokedAt,
+	}
+}
@@ -0,0 +1,172 @@
+package repository
+
+import (
+	"context"
+	"log"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+
+	domainRepo "FLOWGO/internal/domain/repository"
+)
+
+const (
+	loginFailureKeyPrefix = "flowgo:login_fail:"
+	loginBlockKeyPrefix   = "flowgo:login_block:"
+)
+
+// loginAttemptRepository 登录失败计数仓储实现
+// 使用Redis有序集合实现滑动窗口，Redis不可用时退化为进程内存储
+type loginAttemptRepository struct {
+	client *redis.Client
+
+	mu        sync.Mutex
+	failures  map[string][]time.Time
+	blocks    map[string]time.Time
+	windows   map[string]time.Duration
+	lastPurge time.Time
+}
+
+// NewLoginAttemptRepository 创建登录失败计数仓储实例，client 可以为 nil
+func NewLoginAttemptRepository(client *redis.Client) domainRepo.LoginAttemptRepository {
+	return &loginAttemptRepository{
+		client:   client,
+		failures: make(map[string][]time.Time),
+		blocks:   make(map[string]time.Time),
+		windows:  make(map[string]time.Duration),
+	}
+}
+
+// AddFailure 记录一次失败，返回滑动窗口内的失败次数
+func (r *loginAttemptRepository) AddFailure(ctx context.Context, key string, at time.Time, window time.Duration) (int, error) {
+	if r.client != nil {
+		redisKey := loginFailureKeyPrefix + key
+		var card *redis.IntCmd
+		_, err := r.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+			nanos := at.UnixNano()
+			pipe.ZRemRangeByScore(ctx, redisKey, "-inf", strconv.FormatInt(at.Add(-window).UnixNano(), 10))
+			pipe.ZAdd(ctx, redisKey, redis.Z{Score: float64(nanos), Member: strconv.FormatInt(nanos, 10)})
+			card = pipe.ZCard(ctx, redisKey)
+			pipe.PExpire(ctx, redisKey, window)
+			return nil
+		})
+		if err == nil {
+			return int(card.Val()), nil
+		}
+		log.Printf("Warning: failed to record login failure in redis, using memory fallback: %v", err)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.purgeExpired(at)
+
+	kept := r.failures[key][:0]
+	for _, t := range r.failures[key] {
+		if t.After(at.Add(-window)) {
+			kept = append(kept, t)
+		}
+	}
+	kept = append(kept, at)
+	r.failures[key] = kept
+	r.windows[key] = window
+	return len(kept), nil
+}
+
+// ClearFailures 清空失败记录
+func (r *loginAttemptRepository) ClearFailures(ctx context.Context, key string) error {
+	r.mu.Lock()
+	delete(r.failures, key)
+	delete(r.windows, key)
+	r.mu.Unlock()
+
+	if r.client != nil {
+		if err := r.client.Del(ctx, loginFailureKeyPrefix+key).Err(); err != nil {
+			log.Printf("Warning: failed to clear login failures in redis: %v", err)
+		}
+	}
+	return nil
+}
+
+// Block 封禁到指定时间
+func (r *loginAttemptRepository) Block(ctx context.Context, key string, until time.Time) error {
+	ttl := time.Until(until)
+	if ttl <= 0 {
+		return nil
+	}
+
+	if r.client != nil {
+		err := r.client.Set(ctx, loginBlockKeyPrefix+key, until.Unix(), ttl).Err()
+		if err == nil {
+			return nil
+		}
+		log.Printf("Warning: failed to store login block in redis, using memory fallback: %v", err)
+	}
+
+	r.mu.Lock()
+	r.blocks[key] = until
+	r.mu.Unlock()
+	return nil
+}
+
+// BlockedUntil 获取封禁截止时间，Redis与内存中取较晚的一个
+func (r *loginAttemptRepository) BlockedUntil(ctx context.Context, key string) (time.Time, error) {
+	now := time.Now()
+
+	r.mu.Lock()
+	r.purgeExpired(now)
+	until := r.blocks[key]
+	r.mu.Unlock()
+
+	if r.client != nil {
+		sec, err := r.client.Get(ctx, loginBlockKeyPrefix+key).Int64()
+		switch {
+		case err == redis.Nil:
+		case err != nil:
+			log.Printf("Warning: failed to check login block in redis, using memory fallback: %v", err)
+		default:
+			if t := time.Unix(sec, 0); t.After(until) {
+				until = t
+			}
+		}
+	}
+
+	if !until.After(now) {
+		return time.Time{}, nil
+	}
+	return until, nil
+}
+
+// Unblock 解除封禁
+func (r *loginAttemptRepository) Unblock(ctx context.Context, key string) error {
+	r.mu.Lock()
+	delete(r.blocks, key)
+	r.mu.Unlock()
+
+	if r.client != nil {
+		if err := r.client.Del(ctx, loginBlockKeyPrefix+key).Err(); err != nil {
+			log.Printf("Warning: failed to remove login block in redis: %v", err)
+		}
+	}
+	return nil
+}
+
+// purgeExpired 定期清理过期的失败记录和封禁，调用方需持有锁
+func (r *loginAttemptRepository) purgeExpired(now time.Time) {
+	if now.Sub(r.lastPurge) < time.Minute {
+		return
+	}
+	r.lastPurge = now
+	for key, times := range r.failures {
+		if len(times) == 0 || now.Sub(times[len(times)-1]) > r.windows[key] {
+			delete(r.failures, key)
+			delete(r.windows, key)
+		}
+	}
+	for key, until := range r.blocks {
+		if now.After(until) {
+			delete(r.blocks, key)
+		}
+	}
+}
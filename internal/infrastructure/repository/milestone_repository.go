@@ -0,0 +1,162 @@
+package repository
+
+import (
+	"FLOWGO/internal/domain/entity"
+	"FLOWGO/internal/domain/repository"
+	"FLOWGO/internal/infrastructure/dao"
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// milestoneRepository 里程碑仓储实现
+type milestoneRepository struct {
+	db *gorm.DB
+}
+
+// NewMilestoneRepository 创建里程碑仓储实例
+func NewMilestoneRepository(db *gorm.DB) repository.MilestoneRepository {
+	return &milestoneRepository{db: db}
+}
+
+// FindByID 根据ID查找
+func (r *milestoneRepository) FindByID(ctx context.Context, id uint64) (*entity.Milestone, error) {
+	var po dao.MilestonePO
+	err := r.db.WithContext(ctx).Where("id = ?", id).First(&po).Error
+	if err == gorm.ErrRecordNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return r.toEntity(&po), nil
+}
+
+// Create 创建里程碑，Position 取项目内现有最大值加一
+func (r *milestoneRepository) Create(ctx context.Context, milestone *entity.Milestone) error {
+	po := r.toPO(milestone)
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var maxPosition int
+		if err := tx.Model(&dao.MilestonePO{}).
+			Select("COALESCE(MAX(position), 0)").
+			Where("project_id = ?", milestone.ProjectID).
+			Scan(&maxPosition).Error; err != nil {
+			return err
+		}
+		po.Position = maxPosition + 1
+		if err := tx.Create(po).Error; err != nil {
+			return err
+		}
+		return recomputeProjectProgress(tx, milestone.ProjectID)
+	})
+	if err != nil {
+		return err
+	}
+	milestone.ID = po.ID
+	milestone.Position = po.Position
+	milestone.CreatedAt = po.CreatedAt
+	milestone.UpdatedAt = po.UpdatedAt
+	return nil
+}
+
+// Update 更新里程碑，顺序通过 Reorder 调整
+func (r *milestoneRepository) Update(ctx context.Context, milestone *entity.Milestone) error {
+	milestone.UpdatedAt = time.Now()
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&dao.MilestonePO{BasePO: dao.BasePO{ID: milestone.ID}}).
+			Select("name", "description", "due_date", "state", "closed_at", "updated_at").
+			Updates(r.toPO(milestone)).Error; err != nil {
+			return err
+		}
+		return recomputeProjectProgress(tx, milestone.ProjectID)
+	})
+}
+
+// Delete 删除里程碑（软删除），同时重新计算项目进度
+func (r *milestoneRepository) Delete(ctx context.Context, id uint64) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var po dao.MilestonePO
+		if err := tx.Select("id", "project_id").Where("id = ?", id).First(&po).Error; err != nil {
+			return err
+		}
+		if err := tx.Delete(&dao.MilestonePO{}, id).Error; err != nil {
+			return err
+		}
+		return recomputeProjectProgress(tx, po.ProjectId)
+	})
+}
+
+// ListByProject 查询项目的全部里程碑，按 Position 升序
+func (r *milestoneRepository) ListByProject(ctx context.Context, projectID uint64) ([]*entity.Milestone, error) {
+	var pos []*dao.MilestonePO
+	if err := r.db.WithContext(ctx).
+		Where("project_id = ?", projectID).
+		Order("position ASC, id ASC").
+		Find(&pos).Error; err != nil {
+		return nil, err
+	}
+	milestones := make([]*entity.Milestone, len(pos))
+	for i, po := range pos {
+		milestones[i] = r.toEntity(po)
+	}
+	return milestones, nil
+}
+
+// Reorder 按 ids 的顺序重新设置 Position，从 1 开始
+func (r *milestoneRepository) Reorder(ctx context.Context, projectID uint64, ids []uint64) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for i, id := range ids {
+			if err := tx.Model(&dao.MilestonePO{}).
+				Where("id = ? AND project_id = ?", id, projectID).
+				UpdateColumn("position", i+1).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (r *milestoneRepository) toPO(e *entity.Milestone) *dao.MilestonePO {
+	var dueDate *time.Time
+	if !e.DueDate.IsZero() {
+		dueDate = &e.DueDate
+	}
+	return &dao.MilestonePO{
+		BasePO: dao.BasePO{
+			ID:        e.ID,
+			CreatedAt: e.CreatedAt,
+			UpdatedAt: e.UpdatedAt,
+		},
+		ProjectId:   e.ProjectID,
+		Name:        e.Name,
+		Description: e.Description,
+		DueDate:     dueDate,
+		State:       string(e.State),
+		Position:    e.Position,
+		ClosedAt:    e.ClosedAt,
+	}
+}
+
+func (r *milestoneRepository) toEntity(po *dao.MilestonePO) *entity.Milestone {
+	e := &entity.Milestone{
+		BaseEntity: entity.BaseEntity{
+			ID:        po.ID,
+			CreatedAt: po.CreatedAt,
+			UpdatedAt: po.UpdatedAt,
+		},
+		ProjectID:   po.ProjectId,
+		Name:        po.Name,
+		Description: po.Description,
+		State:       entity.MilestoneState(po.State),
+		Position:    po.Position,
+		ClosedAt:    po.ClosedAt,
+	}
+	if po.DueDate != nil {
+		e.DueDate = *po.DueDate
+	}
+	if po.DeletedAt.Valid {
+		e.DeletedAt = &po.DeletedAt.Time
+	}
+	return e
+}
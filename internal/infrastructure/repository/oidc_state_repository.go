@@ -0,0 +1,135 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+
+	"FLOWGO/internal/domain/entity"
+	domainRepo "FLOWGO/internal/domain/repository"
+)
+
+const (
+	oidcStateKeyPrefix  = "flowgo:oidc_state:"
+	oidcResultKeyPrefix = "flowgo:oidc_result:"
+)
+
+type memoryOIDCEntry struct {
+	payload   []byte
+	expiresAt time.Time
+}
+
+// oidcStateRepository 单点登录状态存储实现
+// 优先写入Redis以支持多实例部署，Redis不可用时退化为进程内存储
+type oidcStateRepository struct {
+	client *redis.Client
+
+	mu        sync.Mutex
+	entries   map[string]memoryOIDCEntry
+	lastPurge time.Time
+}
+
+// NewOIDCStateRepository 创建单点登录状态存储实例，client 可以为 nil
+func NewOIDCStateRepository(client *redis.Client) domainRepo.OIDCStateRepository {
+	return &oidcStateRepository{
+		client:  client,
+		entries: make(map[string]memoryOIDCEntry),
+	}
+}
+
+// Save 保存状态
+func (r *oidcStateRepository) Save(ctx context.Context, state string, data *entity.OIDCLoginState, ttl time.Duration) error {
+	return r.save(ctx, oidcStateKeyPrefix+state, data, ttl)
+}
+
+// Take 取出并删除状态
+func (r *oidcStateRepository) Take(ctx context.Context, state string) (*entity.OIDCLoginState, error) {
+	var data entity.OIDCLoginState
+	ok, err := r.take(ctx, oidcStateKeyPrefix+state, &data)
+	if err != nil || !ok {
+		return nil, err
+	}
+	return &data, nil
+}
+
+// SaveResult 保存登录结果
+func (r *oidcStateRepository) SaveResult(ctx context.Context, code string, result *entity.OIDCLoginResult, ttl time.Duration) error {
+	return r.save(ctx, oidcResultKeyPrefix+code, result, ttl)
+}
+
+// TakeResult 取出并删除登录结果
+func (r *oidcStateRepository) TakeResult(ctx context.Context, code string) (*entity.OIDCLoginResult, error) {
+	var result entity.OIDCLoginResult
+	ok, err := r.take(ctx, oidcResultKeyPrefix+code, &result)
+	if err != nil || !ok {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// save 序列化后写入Redis，失败时写入进程内存储
+func (r *oidcStateRepository) save(ctx context.Context, key string, v interface{}, ttl time.Duration) error {
+	payload, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	if r.client != nil {
+		err := r.client.Set(ctx, key, payload, ttl).Err()
+		if err == nil {
+			return nil
+		}
+		log.Printf("Warning: failed to store oidc state in redis, using memory fallback: %v", err)
+	}
+
+	now := time.Now()
+	r.mu.Lock()
+	r.purgeExpired(now)
+	r.entries[key] = memoryOIDCEntry{payload: payload, expiresAt: now.Add(ttl)}
+	r.mu.Unlock()
+	return nil
+}
+
+// take 取出并删除 key 对应的值，不存在或已过期时返回 false
+func (r *oidcStateRepository) take(ctx context.Context, key string, v interface{}) (bool, error) {
+	now := time.Now()
+	r.mu.Lock()
+	e, ok := r.entries[key]
+	delete(r.entries, key)
+	r.mu.Unlock()
+	if ok {
+		if now.After(e.expiresAt) {
+			return false, nil
+		}
+		return true, json.Unmarshal(e.payload, v)
+	}
+
+	if r.client != nil {
+		payload, err := r.client.GetDel(ctx, key).Bytes()
+		if err == redis.Nil {
+			return false, nil
+		}
+		if err != nil {
+			log.Printf("Warning: failed to load oidc state from redis, using memory fallback: %v", err)
+			return false, nil
+		}
+		return true, json.Unmarshal(payload, v)
+	}
+	return false, nil
+}
+
+// purgeExpired 定期清理过期状态，调用方需持有锁
+func (r *oidcStateRepository) purgeExpired(now time.Time) {
+	if now.Sub(r.lastPurge) < time.Minute {
+		return
+	}
+	r.lastPurge = now
+	for key, e := range r.entries {
+		if now.After(e.expiresAt) {
+			delete(r.entries, key)
+		}
+	}
+}
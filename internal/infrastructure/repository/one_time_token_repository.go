@@ -0,0 +1,84 @@
+package repository
+
+import (
+	"FLOWGO/internal/domain/entity"
+	"FLOWGO/internal/domain/repository"
+	"FLOWGO/internal/infrastructure/dao"
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// oneTimeTokenRepository 一次性令牌仓储实现
+type oneTimeTokenRepository struct {
+	db *gorm.DB
+}
+
+// NewOneTimeTokenRepository 创建一次性令牌仓储实例
+func NewOneTimeTokenRepository(db *gorm.DB) repository.OneTimeTokenRepository {
+	return &oneTimeTokenRepository{db: db}
+}
+
+// Create 创建令牌
+func (r *oneTimeTokenRepository) Create(ctx context.Context, token *entity.OneTimeToken) error {
+	po := &dao.OneTimeTokenPO{
+		UserId:    token.UserID,
+		Purpose:   string(token.Purpose),
+		Email:     token.Email,
+		TokenHash: token.TokenHash,
+		ExpiresAt: token.ExpiresAt,
+	}
+	if err := r.db.WithContext(ctx).Create(po).Error; err != nil {
+		return err
+	}
+	token.ID = po.ID
+	token.CreatedAt = po.CreatedAt
+	token.UpdatedAt = po.UpdatedAt
+	return nil
+}
+
+// FindByHash 根据用途和令牌摘要查找
+func (r *oneTimeTokenRepository) FindByHash(ctx context.Context, purpose entity.OneTimeTokenPurpose, tokenHash string) (*entity.OneTimeToken, error) {
+	var po dao.OneTimeTokenPO
+	err := r.db.WithContext(ctx).
+		Where("purpose = ? AND token_hash = ?", string(purpose), tokenHash).
+		First(&po).Error
+	if err == gorm.ErrRecordNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &entity.OneTimeToken{
+		BaseEntity: entity.BaseEntity{
+			ID:        po.ID,
+			CreatedAt: po.CreatedAt,
+			UpdatedAt: po.UpdatedAt,
+		},
+		UserID:    po.UserId,
+		Purpose:   entity.OneTimeTokenPurpose(po.Purpose),
+		Email:     po.Email,
+		TokenHash: po.TokenHash,
+		ExpiresAt: po.ExpiresAt,
+		UsedAt:    po.UsedAt,
+	}, nil
+}
+
+// MarkUsed 标记令牌已使用，通过条件更新保证同一令牌只能使用一次
+func (r *oneTimeTokenRepository) MarkUsed(ctx context.Context, id uint64, usedAt time.Time) (bool, error) {
+	result := r.db.WithContext(ctx).Model(&dao.OneTimeTokenPO{}).
+		Where("id = ? AND used_at IS NULL", id).
+		Update("used_at", usedAt)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// InvalidateByUser 作废用户指定用途的全部未使用令牌
+func (r *oneTimeTokenRepository) InvalidateByUser(ctx context.Context, userID uint64, purpose entity.OneTimeTokenPurpose, at time.Time) error {
+	return r.db.WithContext(ctx).Model(&dao.OneTimeTokenPO{}).
+		Where("user_id = ? AND purpose = ? AND used_at IS NULL", userID, string(purpose)).
+		Update("used_at", at).Error
+}
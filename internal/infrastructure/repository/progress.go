@@ -0,0 +1,52 @@
+package repository
+
+import (
+	"FLOWGO/internal/domain/entity"
+	"FLOWGO/internal/infrastructure/dao"
+
+	"gorm.io/gorm"
+)
+
+// recomputeProjectProgress 根据项目当前的任务和里程碑重新计算并保存进度，手动模式的项目保持不变
+// 需要在修改任务或里程碑的同一事务中调用，保证进度与任务、里程碑数据一致
+func recomputeProjectProgress(tx *gorm.DB, projectID uint64) error {
+	var po dao.ProjectPO
+	err := tx.Select("id", "progress", "progress_mode").Where("id = ?", projectID).Take(&po).Error
+	if err == gorm.ErrRecordNotFound {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	project := &entity.Project{Progress: po.Progress, ProgressMode: entity.ProgressMode(po.ProgressMode)}
+	if project.IsManualProgress() {
+		return nil
+	}
+
+	var stats entity.ProgressStats
+	if err := tx.Model(&dao.TaskPO{}).
+		Select(`COUNT(*) AS total,
+			COALESCE(SUM(CASE WHEN status = ? THEN 1 ELSE 0 END), 0) AS done,
+			COALESCE(SUM(estimate), 0) AS total_estimate,
+			COALESCE(SUM(CASE WHEN status = ? THEN estimate ELSE 0 END), 0) AS done_estimate`,
+			string(entity.TaskStatusDone), string(entity.TaskStatusDone)).
+		Where("project_id = ? AND status <> ?", projectID, string(entity.TaskStatusCancelled)).
+		Scan(&stats).Error; err != nil {
+		return err
+	}
+	if project.ProgressMode == entity.ProgressModeMilestones {
+		if err := tx.Model(&dao.MilestonePO{}).
+			Select("COUNT(*) AS total_milestones, COALESCE(SUM(CASE WHEN state = ? THEN 1 ELSE 0 END), 0) AS closed_milestones",
+				string(entity.MilestoneStateClosed)).
+			Where("project_id = ?", projectID).
+			Scan(&stats).Error; err != nil {
+			return err
+		}
+	}
+
+	if !project.RecomputeProgress(stats) {
+		return nil
+	}
+	// 进度由任务推导，不视为对项目本身的修改，不更新 updated_at
+	return tx.Model(&dao.ProjectPO{}).Where("id = ?", projectID).UpdateColumn("progress", project.Progress).Error
+}
@@ -33,9 +33,44 @@ func (r *projectsRepository) Create(ctx context.Context, project *entity.Project
 	return nil
 }
 
-// Delete 删除项目
+// CreateWithOwner 创建项目并添加 owner 成员，任一步失败都会回滚
+func (r *projectsRepository) CreateWithOwner(ctx context.Context, project *entity.Project) error {
+	po := r.toPO(project)
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(po).Error; err != nil {
+			return err
+		}
+		return tx.Create(&dao.ProjectUserPO{
+			ProjectId: po.ID,
+			UserId:    project.OwnerID,
+			Role:      string(entity.ProjectRoleOwner),
+		}).Error
+	})
+	if err != nil {
+		return err
+	}
+	project.ID = po.ID
+	project.CreatedAt = po.CreatedAt
+	project.UpdatedAt = po.UpdatedAt
+	return nil
+}
+
+// Delete 删除项目（软删除），团队和成员关联以相同的删除时间一并软删除，恢复时据此找回
 func (r *projectsRepository) Delete(ctx context.Context, id uint64) error {
-	return r.db.WithContext(ctx).Delete(&dao.ProjectPO{}, id).Error
+	now := time.Now()
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&dao.ProjectPO{}).Where("id = ?", id).
+			UpdateColumn("deleted_at", now).Error; err != nil {
+			return err
+		}
+		for _, link := range projectLinks {
+			if err := tx.Model(link).Where("project_id = ?", id).
+				UpdateColumn("deleted_at", now).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
 }
 
 // FindById 根据ID查找
@@ -83,12 +118,146 @@ func (r *projectsRepository) List(ctx context.Context, page, pageSize int) ([]*e
 	return projects, total, nil
 }
 
-// Update 更新项目
+// projectSortColumns 项目排序字段对应的列
+var projectSortColumns = map[string]string{
+	"name":       "name",
+	"status":     "status",
+	"priority":   "priority",
+	"owner_id":   "owner_id",
+	"deadline":   "deadline",
+	"start_date": "start_date",
+	"progress":   "progress",
+	"created_at": "created_at",
+	"updated_at": "updated_at",
+}
+
+// ListByFilter 按过滤条件分页查询项目
+func (r *projectsRepository) ListByFilter(ctx context.Context, filter repository.ProjectFilter, page, pageSize int) ([]*entity.Project, int64, error) {
+	query := r.db.WithContext(ctx).Model(&dao.ProjectPO{})
+
+	if filter.TeamID != 0 {
+		teamIDs := []uint64{filter.TeamID}
+		if filter.IncludeDescendants {
+			var err error
+			if teamIDs, err = subtreeTeamIDs(r.db.WithContext(ctx), teamIDs); err != nil {
+				return nil, 0, err
+			}
+		}
+		projectIDs := r.db.Model(&dao.ProjectTeamPO{}).
+			Select("project_id").
+			Where("team_id IN ?", teamIDs)
+		query = query.Where("id IN (?)", projectIDs)
+	}
+
+	if len(filter.Tags) > 0 {
+		projectIDs := r.db.Table("project_tags").
+			Select("project_tags.project_id").
+			Joins("JOIN tags ON tags.id = project_tags.tag_id").
+			Where("tags.name IN ?", filter.Tags)
+		if filter.MatchAllTags {
+			// 名称唯一，命中的标签数等于请求的标签数即包含全部标签
+			projectIDs = projectIDs.
+				Group("project_tags.project_id").
+				Having("COUNT(DISTINCT tags.id) = ?", len(filter.Tags))
+		}
+		query = query.Where("id IN (?)", projectIDs)
+	}
+
+	if filter.Status != nil {
+		query = query.Where("status = ?", int(*filter.Status))
+	}
+	if filter.Priority != nil {
+		query = query.Where("priority = ?", int(*filter.Priority))
+	}
+	if filter.OwnerID != 0 {
+		query = query.Where("owner_id = ?", filter.OwnerID)
+	}
+	if filter.MemberID != 0 {
+		memberProjectIDs := r.db.Model(&dao.ProjectUserPO{}).
+			Select("project_id").
+			Where("user_id = ?", filter.MemberID)
+		query = query.Where("(owner_id = ? OR id IN (?))", filter.MemberID, memberProjectIDs)
+	}
+	if !filter.DeadlineFrom.IsZero() {
+		query = query.Where("deadline >= ?", filter.DeadlineFrom)
+	}
+	if !filter.DeadlineTo.IsZero() {
+		query = query.Where("deadline < ?", filter.DeadlineTo)
+	}
+	query = applyKeyword(query, filter.Keyword, "name", "description")
+	query = query.Session(&gorm.Session{})
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	var pos []*dao.ProjectPO
+	if err := applySort(query, filter.Sort, projectSortColumns, createdAtDesc).
+		Offset((page - 1) * pageSize).
+		Limit(pageSize).
+		Find(&pos).Error; err != nil {
+		return nil, 0, err
+	}
+
+	projects := make([]*entity.Project, len(pos))
+	for i, po := range pos {
+		projects[i] = r.toEntity(po)
+	}
+	return projects, total, nil
+}
+
+// projectEditableColumns Update 写入的字段
+var projectEditableColumns = []string{
+	"name", "description", "cover_image", "deadline", "start_date", "priority", "progress_mode", "version", "updated_at",
+}
+
+// Update 更新项目，团队和标签保持不变
 func (r *projectsRepository) Update(ctx context.Context, project *entity.Project) error {
+	return r.UpdateWithLinks(ctx, project, nil, nil)
+}
+
+// UpdateWithLinks 更新项目并替换团队和标签
+// 进度只在手动模式下写入，自动模式下按任务重新计算，避免用读取时的旧值覆盖计算结果
+// 按 project.Version 条件更新，版本号不一致时返回 entity.ErrProjectVersionConflict，成功后版本号加一
+// 可编辑的字段全部写入，空的封面和日期也会保存：PUT 为全量更新，不传封面时会清空；描述在请求中必填，不会被清空
+func (r *projectsRepository) UpdateWithLinks(ctx context.Context, project *entity.Project, teamIds, tagIds []uint64) error {
 	po := r.toPO(project)
-	// BasePO updates
-	// Ensure ID is set for update
-	return r.db.WithContext(ctx).Model(&dao.ProjectPO{BasePO: dao.BasePO{ID: project.ID}}).Updates(po).Error
+	po.Version = project.Version + 1
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		model := &dao.ProjectPO{BasePO: dao.BasePO{ID: project.ID}}
+		// 状态只能通过 ChangeStatus 修改，负责人不通过更新接口修改
+		res := tx.Model(model).Where("version = ?", project.Version).
+			Select(projectEditableColumns).
+			Updates(po)
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			return entity.ErrProjectVersionConflict
+		}
+		if teamIds != nil {
+			if err := replaceProjectTeams(tx, project.ID, teamIds); err != nil {
+				return err
+			}
+		}
+		if tagIds != nil {
+			if err := replaceProjectTags(tx, project.ID, tagIds); err != nil {
+				return err
+			}
+		}
+		if project.IsManualProgress() {
+			return tx.Model(model).Update("progress", project.Progress).Error
+		}
+		if err := recomputeProjectProgress(tx, project.ID); err != nil {
+			return err
+		}
+		return tx.Model(&dao.ProjectPO{}).Select("progress").Where("id = ?", project.ID).Scan(&project.Progress).Error
+	})
+	if err != nil {
+		return err
+	}
+	project.Version = po.Version
+	return nil
 }
 
 // ListAvailableTeams 列表查询可用团队
@@ -110,6 +279,7 @@ func (r *projectsRepository) ListAvailableTeams(ctx context.Context) ([]*entity.
 			Name:        po.Name,
 			Description: po.Description,
 			OwnerId:     po.OwnerId,
+			ParentId:    po.ParentId,
 		}
 	}
 	return teams, nil
@@ -149,6 +319,75 @@ func (r *projectsRepository) ListTeamIdsByProjectId(ctx context.Context, project
 	return teamIds, nil
 }
 
+// SetTags 将项目的标签替换为 tagIds
+func (r *projectsRepository) SetTags(ctx context.Context, projectId uint64, tagIds []uint64) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return replaceProjectTags(tx, projectId, tagIds)
+	})
+}
+
+// replaceProjectTeams 在事务中将项目关联的团队替换为 teamIds
+func replaceProjectTeams(tx *gorm.DB, projectId uint64, teamIds []uint64) error {
+	if err := tx.Delete(&dao.ProjectTeamPO{}, "project_id = ?", projectId).Error; err != nil {
+		return err
+	}
+	if len(teamIds) == 0 {
+		return nil
+	}
+	pos := make([]*dao.ProjectTeamPO, 0, len(teamIds))
+	for _, teamId := range teamIds {
+		pos = append(pos, &dao.ProjectTeamPO{
+			ProjectId: projectId,
+			TeamId:    teamId,
+		})
+	}
+	return tx.Create(&pos).Error
+}
+
+// replaceProjectTags 在事务中将项目的标签替换为 tagIds
+func replaceProjectTags(tx *gorm.DB, projectId uint64, tagIds []uint64) error {
+	if err := tx.Delete(&dao.ProjectTagPO{}, "project_id = ?", projectId).Error; err != nil {
+		return err
+	}
+	if len(tagIds) == 0 {
+		return nil
+	}
+	pos := make([]*dao.ProjectTagPO, 0, len(tagIds))
+	for _, tagId := range tagIds {
+		pos = append(pos, &dao.ProjectTagPO{
+			ProjectId: projectId,
+			TagId:     tagId,
+		})
+	}
+	return tx.Create(&pos).Error
+}
+
+// ListTagsByProjectId 查询项目的标签，按名称排序
+func (r *projectsRepository) ListTagsByProjectId(ctx context.Context, projectId uint64) ([]*entity.Tag, error) {
+	var pos []*dao.TagPO
+	err := r.db.WithContext(ctx).
+		Joins("JOIN project_tags ON project_tags.tag_id = tags.id").
+		Where("project_tags.project_id = ?", projectId).
+		Order("tags.name").
+		Find(&pos).Error
+	if err != nil {
+		return nil, err
+	}
+	tags := make([]*entity.Tag, len(pos))
+	for i, po := range pos {
+		tags[i] = &entity.Tag{
+			BaseEntity: entity.BaseEntity{
+				ID:        po.ID,
+				CreatedAt: po.CreatedAt,
+				UpdatedAt: po.UpdatedAt,
+			},
+			Name:  po.Name,
+			Color: po.Color,
+		}
+	}
+	return tags, nil
+}
+
 // ListUsersByProjectId 根据项目ID列表查询用户
 func (r *projectsRepository) ListUsersByProjectId(ctx context.Context, projectId uint64) ([]*entity.User, error) {
 	// 只查询 projects_users 表，不再通过 Team 关联
@@ -187,59 +426,40 @@ func (r *projectsRepository) ListUsersByProjectId(ctx context.Context, projectId
 
 	users := make([]*entity.User, len(pos))
 	for i, po := range pos {
-		users[i] = &entity.User{
-			BaseEntity: entity.BaseEntity{
-				ID:        po.ID,
-				CreatedAt: po.CreatedAt,
-				UpdatedAt: po.UpdatedAt,
-				DeletedAt: &po.DeletedAt.Time,
-			},
-			Name:   po.Name,
-			Email:  po.Email,
-			Status: 1, // 数据库中是字符串(online/offline)，暂时映射为1(正常)
-			Avatar: po.Avatar,
-			TeamID: po.TeamID,
-			Role:   po.Role,
-		}
+		users[i] = r.userToEntity(po)
 	}
 	return users, nil
 }
 
-// ListAvailableUsers 列表查询可用用户
+// ListAvailableUsers 列表查询可用用户，已禁用和已删除的用户不会返回
 func (r *projectsRepository) ListAvailableUsers(ctx context.Context) ([]*entity.User, error) {
 	var pos []*dao.UserPO
-	err := r.db.WithContext(ctx).Where("deleted_at IS NULL").Find(&pos).Error
+	err := r.db.WithContext(ctx).
+		Where("status = ? AND deleted_at IS NULL", entity.UserStatusActive).
+		Find(&pos).Error
 	if err != nil {
 		return nil, err
 	}
 	users := make([]*entity.User, len(pos))
 	for i, po := range pos {
-		users[i] = &entity.User{
-			BaseEntity: entity.BaseEntity{
-				ID:        po.ID,
-				CreatedAt: po.CreatedAt,
-				UpdatedAt: po.UpdatedAt,
-				DeletedAt: &po.DeletedAt.Time,
-			},
-			Name:   po.Name,
-			Email:  po.Email,
-			Status: 1, // 数据库中是字符串(online/offline)，暂时映射为1(正常)
-		}
+		users[i] = r.userToEntity(po)
 	}
 	return users, nil
 }
 
-// AddUsers 添加项目成员
-func (r *projectsRepository) AddUsers(ctx context.Context, projectId uint64, userIds []uint64) error {
+// AddUsers 添加项目成员，已是成员的用户保持原角色不变
+func (r *projectsRepository) AddUsers(ctx context.Context, projectId uint64, userIds []uint64, role entity.ProjectRole) error {
 	if len(userIds) == 0 {
 		return nil
 	}
 	// 简单实现：尝试批量插入，利用唯一索引忽略重复或在应用层过滤
 	// 这里选择应用层过滤以避免错误
 	var existingUserIds []uint64
-	r.db.WithContext(ctx).Model(&dao.ProjectUserPO{}).
+	if err := r.db.WithContext(ctx).Model(&dao.ProjectUserPO{}).
 		Where("project_id = ? AND user_id IN ?", projectId, userIds).
-		Pluck("user_id", &existingUserIds)
+		Pluck("user_id", &existingUserIds).Error; err != nil {
+		return err
+	}
 
 	existingMap := make(map[uint64]bool)
 	for _, id := range existingUserIds {
@@ -249,10 +469,11 @@ func (r *projectsRepository) AddUsers(ctx context.Context, projectId uint64, use
 	var newPOs []*dao.ProjectUserPO
 	for _, uid := range userIds {
 		if !existingMap[uid] {
+			existingMap[uid] = true
 			newPOs = append(newPOs, &dao.ProjectUserPO{
 				ProjectId: projectId,
 				UserId:    uid,
-				Role:      "member",
+				Role:      string(role),
 			})
 		}
 	}
@@ -260,25 +481,48 @@ func (r *projectsRepository) AddUsers(ctx context.Context, projectId uint64, use
 	if len(newPOs) > 0 {
 		return r.db.WithContext(ctx).Create(&newPOs).Error
 	}
+	return nil
+}
 
-	for _, uid := range userIds {
-		if !existingMap[uid] {
-			newPOs = append(newPOs, &dao.ProjectUserPO{
-				ProjectId: projectId,
-				UserId:    uid,
-				Role:      "member",
-			})
-		}
+// FindUserRole 查询用户在项目中的角色，非成员返回空角色
+func (r *projectsRepository) FindUserRole(ctx context.Context, projectId uint64, userId uint64) (entity.ProjectRole, error) {
+	var po dao.ProjectUserPO
+	err := r.db.WithContext(ctx).
+		Where("project_id = ? AND user_id = ?", projectId, userId).
+		First(&po).Error
+	if err == gorm.ErrRecordNotFound {
+		return "", nil
 	}
+	if err != nil {
+		return "", err
+	}
+	return entity.ProjectRole(po.Role), nil
+}
 
-	if len(newPOs) > 0 {
-		return r.db.WithContext(ctx).Create(&newPOs).Error
+// ListUserRoles 查询项目全部成员的角色
+func (r *projectsRepository) ListUserRoles(ctx context.Context, projectId uint64) (map[uint64]entity.ProjectRole, error) {
+	var pos []*dao.ProjectUserPO
+	err := r.db.WithContext(ctx).Where("project_id = ?", projectId).Find(&pos).Error
+	if err != nil {
+		return nil, err
 	}
-	return nil
+	roles := make(map[uint64]entity.ProjectRole, len(pos))
+	for _, po := range pos {
+		roles[po.UserId] = entity.ProjectRole(po.Role)
+	}
+	return roles, nil
 }
 
-// ListUsersInProjectTeams 获取项目关联团队下的所有用户 (用于添加成员时的候选列表)
-func (r *projectsRepository) ListUsersInProjectTeams(ctx context.Context, projectId uint64) ([]*entity.User, error) {
+// UpdateUserRole 修改项目成员角色
+func (r *projectsRepository) UpdateUserRole(ctx context.Context, projectId uint64, userId uint64, role entity.ProjectRole) error {
+	return r.db.WithContext(ctx).Model(&dao.ProjectUserPO{}).
+		Where("project_id = ? AND user_id = ?", projectId, userId).
+		Update("role", string(role)).Error
+}
+
+// ListUsersInProjectTeams 获取项目关联团队下的所有启用状态的用户 (用于添加成员时的候选列表)
+// includeDescendants 为 true 时同时包含下级团队的成员
+func (r *projectsRepository) ListUsersInProjectTeams(ctx context.Context, projectId uint64, includeDescendants bool) ([]*entity.User, error) {
 	var teamIDs []uint64
 	// 1. 先查项目关联的团队ID
 	err := r.db.WithContext(ctx).
@@ -289,15 +533,23 @@ func (r *projectsRepository) ListUsersInProjectTeams(ctx context.Context, projec
 		return nil, err
 	}
 
+	if includeDescendants {
+		if teamIDs, err = subtreeTeamIDs(r.db.WithContext(ctx), teamIDs); err != nil {
+			return nil, err
+		}
+	}
 	if len(teamIDs) == 0 {
 		return []*entity.User{}, nil
 	}
 
-	// 2. 再查这些团队下的用户
+	// 2. 再通过团队成员关系查这些团队下的用户，同时属于多个团队的用户只返回一次
+	memberIDs := r.db.Model(&dao.TeamMemberPO{}).
+		Select("user_id").
+		Where("team_id IN ?", teamIDs)
 	var pos []*dao.UserPO
 	err = r.db.WithContext(ctx).
 		Model(&dao.UserPO{}).
-		Where("team_id IN ? AND deleted_at IS NULL", teamIDs).
+		Where("id IN (?) AND status = ? AND deleted_at IS NULL", memberIDs, entity.UserStatusActive).
 		Find(&pos).Error
 	if err != nil {
 		return nil, err
@@ -305,20 +557,7 @@ func (r *projectsRepository) ListUsersInProjectTeams(ctx context.Context, projec
 
 	users := make([]*entity.User, len(pos))
 	for i, po := range pos {
-		users[i] = &entity.User{
-			BaseEntity: entity.BaseEntity{
-				ID:        po.ID,
-				CreatedAt: po.CreatedAt,
-				UpdatedAt: po.UpdatedAt,
-				DeletedAt: &po.DeletedAt.Time,
-			},
-			Name:   po.Name,
-			Email:  po.Email,
-			Status: 1,
-			Avatar: po.Avatar,
-			TeamID: po.TeamID,
-			Role:   po.Role,
-		}
+		users[i] = r.userToEntity(po)
 	}
 	return users, nil
 }
@@ -358,6 +597,9 @@ func (r *projectsRepository) toPO(e *entity.Project) *dao.ProjectPO {
 		Progress:    e.Progress,
 		Priority:    int(e.Priority),
 		CoverImage:  e.CoverImage,
+
+		ProgressMode: string(e.ProgressMode),
+		Version:      e.Version,
 	}
 }
 
@@ -386,9 +628,212 @@ func (r *projectsRepository) toEntity(po *dao.ProjectPO) *entity.Project {
 		Progress:    po.Progress,
 		Priority:    entity.ProjectPriority(po.Priority),
 		CoverImage:  po.CoverImage,
+
+		ProgressMode: entity.ProgressMode(po.ProgressMode),
+		Version:      po.Version,
+	}
+	if po.DeletedAt.Valid {
+		e.DeletedAt = &po.DeletedAt.Time
+	}
+	return e
+}
+
+func (r *projectsRepository) userToEntity(po *dao.UserPO) *entity.User {
+	e := &entity.User{
+		BaseEntity: entity.BaseEntity{
+			ID:        po.ID,
+			CreatedAt: po.CreatedAt,
+			UpdatedAt: po.UpdatedAt,
+		},
+		Name:        po.Name,
+		DisplayName: po.DisplayName,
+		Email:       po.Email,
+		Status:      po.Status,
+		Avatar:      po.Avatar,
+		TeamID:      po.TeamID,
+		Role:        po.Role,
 	}
 	if po.DeletedAt.Valid {
 		e.DeletedAt = &po.DeletedAt.Time
 	}
 	return e
 }
+
+// ChangeStatus 保存项目状态并记录变更历史，按原状态条件更新，并发变更时只有一个成功
+func (r *projectsRepository) ChangeStatus(ctx context.Context, project *entity.Project, change *entity.ProjectStatusChange) (bool, error) {
+	po := &dao.ProjectStatusChangePO{
+		ProjectId:  change.ProjectID,
+		FromStatus: int(change.FromStatus),
+		ToStatus:   int(change.ToStatus),
+		Reason:     change.Reason,
+		OperatorId: change.OperatorID,
+		CreatedAt:  change.CreatedAt,
+	}
+	changed := false
+	var version uint64
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		res := tx.Model(&dao.ProjectPO{}).
+			Where("id = ? AND status = ?", change.ProjectID, int(change.FromStatus)).
+			Updates(map[string]interface{}{
+				"status":     int(change.ToStatus),
+				"version":    gorm.Expr("version + 1"),
+				"updated_at": change.CreatedAt,
+			})
+		if res.Error != nil || res.RowsAffected == 0 {
+			return res.Error
+		}
+		changed = true
+		if err := tx.Create(po).Error; err != nil {
+			return err
+		}
+		return tx.Model(&dao.ProjectPO{}).Select("version").Where("id = ?", change.ProjectID).Scan(&version).Error
+	})
+	if err != nil || !changed {
+		return false, err
+	}
+	change.ID = po.ID
+	project.Version = version
+	return true, nil
+}
+
+// ListStatusChanges 查询项目的状态变更历史，最近的在前
+func (r *projectsRepository) ListStatusChanges(ctx context.Context, projectId uint64) ([]*entity.ProjectStatusChange, error) {
+	var pos []*dao.ProjectStatusChangePO
+	if err := r.db.WithContext(ctx).
+		Where("project_id = ?", projectId).
+		Order("created_at DESC, id DESC").
+		Find(&pos).Error; err != nil {
+		return nil, err
+	}
+	changes := make([]*entity.ProjectStatusChange, len(pos))
+	for i, po := range pos {
+		changes[i] = &entity.ProjectStatusChange{
+			ID:         po.ID,
+			ProjectID:  po.ProjectId,
+			FromStatus: entity.ProjectStatus(po.FromStatus),
+			ToStatus:   entity.ProjectStatus(po.ToStatus),
+			Reason:     po.Reason,
+			OperatorID: po.OperatorId,
+			CreatedAt:  po.CreatedAt,
+		}
+	}
+	return changes, nil
+}
+
+// projectLinks 随项目一起删除和恢复的关联
+var projectLinks = []interface{}{&dao.ProjectTeamPO{}, &dao.ProjectUserPO{}}
+
+// purgeBatchSize 每个事务永久删除的记录数
+const purgeBatchSize = 200
+
+// FindDeletedByID 查找回收站中的项目
+func (r *projectsRepository) FindDeletedByID(ctx context.Context, id uint64) (*entity.Project, error) {
+	var po dao.ProjectPO
+	err := r.db.WithContext(ctx).Unscoped().
+		Where("id = ? AND deleted_at IS NOT NULL", id).
+		First(&po).Error
+	if err == gorm.ErrRecordNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return r.toEntity(&po), nil
+}
+
+// ListDeleted 分页查询回收站中的项目，最近删除的在前
+func (r *projectsRepository) ListDeleted(ctx context.Context, ownerId uint64, page, pageSize int) ([]*entity.Project, int64, error) {
+	query := r.db.WithContext(ctx).Unscoped().Model(&dao.ProjectPO{}).Where("deleted_at IS NOT NULL")
+	if ownerId != 0 {
+		query = query.Where("owner_id = ?", ownerId)
+	}
+	query = query.Session(&gorm.Session{})
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	var pos []*dao.ProjectPO
+	if err := query.
+		Offset((page - 1) * pageSize).
+		Limit(pageSize).
+		Order("deleted_at DESC, id DESC").
+		Find(&pos).Error; err != nil {
+		return nil, 0, err
+	}
+
+	projects := make([]*entity.Project, len(pos))
+	for i, po := range pos {
+		projects[i] = r.toEntity(po)
+	}
+	return projects, total, nil
+}
+
+// Restore 恢复已删除的项目，只恢复与项目删除时间相同的关联，删除前已解除的关联保持删除
+func (r *projectsRepository) Restore(ctx context.Context, id uint64) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var po dao.ProjectPO
+		if err := tx.Unscoped().Where("id = ? AND deleted_at IS NOT NULL", id).First(&po).Error; err != nil {
+			return err
+		}
+		for _, link := range projectLinks {
+			if err := tx.Unscoped().Model(link).
+				Where("project_id = ? AND deleted_at = ?", id, po.DeletedAt.Time).
+				UpdateColumn("deleted_at", nil).Error; err != nil {
+				return err
+			}
+		}
+		return tx.Unscoped().Model(&dao.ProjectPO{}).Where("id = ?", id).
+			UpdateColumn("deleted_at", nil).Error
+	})
+}
+
+// PurgeDeletedBefore 永久删除在 cutoff 之前删除的项目，连同任务、看板、里程碑、关联和状态历史，分批提交
+func (r *projectsRepository) PurgeDeletedBefore(ctx context.Context, cutoff time.Time) (int64, error) {
+	var purged int64
+	for {
+		var ids []uint64
+		if err := r.db.WithContext(ctx).Unscoped().Model(&dao.ProjectPO{}).
+			Where("deleted_at IS NOT NULL AND deleted_at < ?", cutoff).
+			Order("id").
+			Limit(purgeBatchSize).
+			Pluck("id", &ids).Error; err != nil {
+			return purged, err
+		}
+		if len(ids) == 0 {
+			return purged, nil
+		}
+		if err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+			return purgeProjects(tx, ids)
+		}); err != nil {
+			return purged, err
+		}
+		purged += int64(len(ids))
+		if len(ids) < purgeBatchSize {
+			return purged, nil
+		}
+	}
+}
+
+// purgeProjects 物理删除项目及其全部数据
+func purgeProjects(tx *gorm.DB, ids []uint64) error {
+	boardIDs := tx.Unscoped().Model(&dao.BoardPO{}).Select("id").Where("project_id IN ?", ids)
+	if err := tx.Unscoped().Where("board_id IN (?)", boardIDs).Delete(&dao.BoardColumnPO{}).Error; err != nil {
+		return err
+	}
+	children := []interface{}{
+		&dao.BoardPO{},
+		&dao.TaskPO{},
+		&dao.MilestonePO{},
+		&dao.ProjectTeamPO{},
+		&dao.ProjectUserPO{},
+		&dao.ProjectTagPO{},
+		&dao.ProjectStatusChangePO{},
+	}
+	for _, model := range children {
+		if err := tx.Unscoped().Where("project_id IN ?", ids).Delete(model).Error; err != nil {
+			return err
+		}
+	}
+	return tx.Unscoped().Where("id IN ?", ids).Delete(&dao.ProjectPO{}).Error
+}
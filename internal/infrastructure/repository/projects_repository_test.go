@@ -0,0 +1,178 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"gorm.io/gorm"
+
+	"FLOWGO/internal/infrastructure/dao"
+)
+
+// countUnscoped 统计表中满足条件的记录数，包括已软删除的
+func countUnscoped(t *testing.T, db *gorm.DB, model interface{}, query string, args ...interface{}) int64 {
+	t.Helper()
+	var n int64
+	if err := db.Unscoped().Model(model).Where(query, args...).Count(&n).Error; err != nil {
+		t.Fatalf("count %T: %v", model, err)
+	}
+	return n
+}
+
+// createProjectData 为项目创建看板、任务、里程碑、成员和团队关联
+func createProjectData(t *testing.T, db *gorm.DB, projectID uint64) {
+	t.Helper()
+	board := &dao.BoardPO{ProjectId: projectID, Name: "board"}
+	mustCreate(t, db, board)
+	mustCreate(t, db, &dao.BoardColumnPO{BoardId: board.ID, Name: "Todo", Status: "todo"})
+	mustCreate(t, db, &dao.TaskPO{ProjectId: projectID, Title: "task", ReporterId: 1, Status: "todo"})
+	mustCreate(t, db, &dao.MilestonePO{ProjectId: projectID, Name: "milestone", State: "open"})
+	mustCreate(t, db, &dao.ProjectUserPO{ProjectId: projectID, UserId: 1, Role: "owner"})
+	mustCreate(t, db, &dao.ProjectTeamPO{ProjectId: projectID, TeamId: 1})
+}
+
+// TestProjectDeleteRestore 删除时关联一并软删除，恢复时只找回与项目同时删除的关联
+func TestProjectDeleteRestore(t *testing.T) {
+	db := newTestDB(t)
+	repo := NewProjectsRepository(db)
+	ctx := context.Background()
+
+	project := createTestProject(t, db, 1)
+	kept := &dao.ProjectUserPO{ProjectId: project.ID, UserId: 2, Role: "member"}
+	removed := &dao.ProjectUserPO{ProjectId: project.ID, UserId: 3, Role: "member"}
+	mustCreate(t, db, kept)
+	mustCreate(t, db, removed)
+	mustCreate(t, db, &dao.ProjectTeamPO{ProjectId: project.ID, TeamId: 1})
+	// 删除项目之前已经移出项目的成员
+	if err := db.Delete(removed).Error; err != nil {
+		t.Fatalf("remove member: %v", err)
+	}
+	time.Sleep(10 * time.Millisecond)
+
+	if err := repo.Delete(ctx, project.ID); err != nil {
+		t.Fatalf("delete: %v", err)
+	}
+	if found, err := repo.FindByID(ctx, project.ID); err != nil || found != nil {
+		t.Fatalf("deleted project must not be found, got %+v, %v", found, err)
+	}
+	if found, err := repo.FindDeletedByID(ctx, project.ID); err != nil || found == nil {
+		t.Fatalf("deleted project must be in the trash, got %+v, %v", found, err)
+	}
+	if n := countUnscoped(t, db, &dao.ProjectUserPO{}, "project_id = ? AND deleted_at IS NULL", project.ID); n != 0 {
+		t.Fatalf("members must be deleted with the project, %d left", n)
+	}
+	if n := countUnscoped(t, db, &dao.ProjectTeamPO{}, "project_id = ? AND deleted_at IS NULL", project.ID); n != 0 {
+		t.Fatalf("teams must be deleted with the project, %d left", n)
+	}
+
+	if err := repo.Restore(ctx, project.ID); err != nil {
+		t.Fatalf("restore: %v", err)
+	}
+	if found, err := repo.FindByID(ctx, project.ID); err != nil || found == nil {
+		t.Fatalf("restored project must be found, got %+v, %v", found, err)
+	}
+	var members []dao.ProjectUserPO
+	if err := db.Where("project_id = ?", project.ID).Find(&members).Error; err != nil {
+		t.Fatalf("list members: %v", err)
+	}
+	if len(members) != 1 || members[0].UserId != kept.UserId {
+		t.Fatalf("only the member deleted with the project must be restored, got %+v", members)
+	}
+	if n := countUnscoped(t, db, &dao.ProjectTeamPO{}, "project_id = ? AND deleted_at IS NULL", project.ID); n != 1 {
+		t.Fatalf("team link must be restored, got %d", n)
+	}
+
+	// 不在回收站中的项目不能恢复
+	if err := repo.Restore(ctx, project.ID); err == nil {
+		t.Fatalf("restoring an active project must fail")
+	}
+}
+
+// TestProjectPurgeDeletedBefore 只永久删除超过保留期的项目及其全部数据
+func TestProjectPurgeDeletedBefore(t *testing.T) {
+	db := newTestDB(t)
+	repo := NewProjectsRepository(db)
+	ctx := context.Background()
+	cutoff := time.Now().Add(-30 * 24 * time.Hour)
+
+	expired := createTestProject(t, db, 1)
+	recent := createTestProject(t, db, 1)
+	active := createTestProject(t, db, 1)
+	for _, p := range []*dao.ProjectPO{expired, recent, active} {
+		createProjectData(t, db, p.ID)
+	}
+	if err := repo.Delete(ctx, expired.ID); err != nil {
+		t.Fatalf("delete: %v", err)
+	}
+	if err := repo.Delete(ctx, recent.ID); err != nil {
+		t.Fatalf("delete: %v", err)
+	}
+	if err := db.Unscoped().Model(&dao.ProjectPO{}).Where("id = ?", expired.ID).
+		UpdateColumn("deleted_at", cutoff.Add(-time.Hour)).Error; err != nil {
+		t.Fatalf("backdate: %v", err)
+	}
+
+	purged, err := repo.PurgeDeletedBefore(ctx, cutoff)
+	if err != nil {
+		t.Fatalf("purge: %v", err)
+	}
+	if purged != 1 {
+		t.Fatalf("expected 1 purged project, got %d", purged)
+	}
+
+	children := []interface{}{
+		&dao.BoardPO{},
+		&dao.TaskPO{},
+		&dao.MilestonePO{},
+		&dao.ProjectUserPO{},
+		&dao.ProjectTeamPO{},
+	}
+	for _, model := range children {
+		if n := countUnscoped(t, db, model, "project_id = ?", expired.ID); n != 0 {
+			t.Fatalf("%T of the purged project must be deleted, %d left", model, n)
+		}
+		for _, p := range []*dao.ProjectPO{recent, active} {
+			if n := countUnscoped(t, db, model, "project_id = ?", p.ID); n != 1 {
+				t.Fatalf("%T of project %d must be kept, got %d", model, p.ID, n)
+			}
+		}
+	}
+	if n := countUnscoped(t, db, &dao.BoardColumnPO{}, "1 = 1"); n != 2 {
+		t.Fatalf("columns of the purged board must be deleted, %d columns left", n)
+	}
+	if n := countUnscoped(t, db, &dao.ProjectPO{}, "id = ?", expired.ID); n != 0 {
+		t.Fatalf("purged project must be deleted")
+	}
+	if n := countUnscoped(t, db, &dao.ProjectPO{}, "1 = 1"); n != 2 {
+		t.Fatalf("other projects must be kept, got %d", n)
+	}
+}
+
+// TestProjectPurgeBatches 超过一批的过期项目分多个事务全部删除
+func TestProjectPurgeBatches(t *testing.T) {
+	db := newTestDB(t)
+	repo := NewProjectsRepository(db)
+	deletedAt := time.Now().Add(-48 * time.Hour)
+
+	total := purgeBatchSize*2 + 1
+	projects := make([]*dao.ProjectPO, total)
+	for i := range projects {
+		projects[i] = &dao.ProjectPO{Name: "project", Description: "test", OwnerId: 1, Version: 1}
+		projects[i].DeletedAt = gorm.DeletedAt{Time: deletedAt, Valid: true}
+	}
+	if err := db.CreateInBatches(projects, 100).Error; err != nil {
+		t.Fatalf("create projects: %v", err)
+	}
+
+	purged, err := repo.PurgeDeletedBefore(context.Background(), time.Now().Add(-24*time.Hour))
+	if err != nil {
+		t.Fatalf("purge: %v", err)
+	}
+	if purged != int64(total) {
+		t.Fatalf("expected %d purged projects, got %d", total, purged)
+	}
+	if n := countUnscoped(t, db, &dao.ProjectPO{}, "1 = 1"); n != 0 {
+		t.Fatalf("all expired projects must be purged, %d left", n)
+	}
+}
@@ -0,0 +1,46 @@
+package repository
+
+import (
+	"FLOWGO/internal/domain/repository"
+	"strings"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// createdAtDesc 列表的默认排序：最新创建的在前
+var createdAtDesc = clause.OrderByColumn{Column: clause.Column{Name: "created_at"}, Desc: true}
+
+// applyKeyword 关键字模糊匹配任意一列，关键字为空时不过滤
+func applyKeyword(db *gorm.DB, keyword string, columns ...string) *gorm.DB {
+	keyword = strings.TrimSpace(keyword)
+	if keyword == "" || len(columns) == 0 {
+		return db
+	}
+	pattern := "%" + likeEscaper.Replace(keyword) + "%"
+	conds := make([]string, len(columns))
+	args := make([]interface{}, len(columns))
+	for i, column := range columns {
+		conds[i] = column + ` LIKE ? ESCAPE '\'`
+		args[i] = pattern
+	}
+	return db.Where("("+strings.Join(conds, " OR ")+")", args...)
+}
+
+// applySort 按排序条件排序，columns 为排序字段到列的映射，不在映射中的字段忽略
+// 没有有效的排序条件时使用 defaults；最后总是按 id 排序，保证分页结果稳定
+func applySort(db *gorm.DB, sorts []repository.SortField, columns map[string]string, defaults ...clause.OrderByColumn) *gorm.DB {
+	var orderBy []clause.OrderByColumn
+	for _, sort := range sorts {
+		column, ok := columns[sort.Field]
+		if !ok {
+			continue
+		}
+		orderBy = append(orderBy, clause.OrderByColumn{Column: clause.Column{Name: column}, Desc: sort.Desc})
+	}
+	if len(orderBy) == 0 {
+		orderBy = defaults
+	}
+	orderBy = append(orderBy, clause.OrderByColumn{Column: clause.Column{Name: "id"}, Desc: true})
+	return db.Order(clause.OrderBy{Columns: orderBy})
+}
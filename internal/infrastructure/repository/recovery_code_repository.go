@@ -0,0 +1,53 @@
+package repository
+
+import (
+	"FLOWGO/internal/domain/repository"
+	"FLOWGO/internal/infrastructure/dao"
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// recoveryCodeRepository 恢复码仓储实现
+type recoveryCodeRepository struct {
+	db *gorm.DB
+}
+
+// NewRecoveryCodeRepository 创建恢复码仓储实例
+func NewRecoveryCodeRepository(db *gorm.DB) repository.RecoveryCodeRepository {
+	return &recoveryCodeRepository{db: db}
+}
+
+// Replace 用新的一组恢复码替换用户原有的全部恢复码
+func (r *recoveryCodeRepository) Replace(ctx context.Context, userID uint64, codeHashes []string) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return replaceRecoveryCodes(tx, userID, codeHashes)
+	})
+}
+
+// replaceRecoveryCodes 在事务中删除用户原有的恢复码并写入新的一组
+func replaceRecoveryCodes(tx *gorm.DB, userID uint64, codeHashes []string) error {
+	if err := tx.Where("user_id = ?", userID).Delete(&dao.RecoveryCodePO{}).Error; err != nil {
+		return err
+	}
+	if len(codeHashes) == 0 {
+		return nil
+	}
+	pos := make([]dao.RecoveryCodePO, 0, len(codeHashes))
+	for _, hash := range codeHashes {
+		pos = append(pos, dao.RecoveryCodePO{UserId: userID, CodeHash: hash})
+	}
+	return tx.Create(&pos).Error
+}
+
+// Use 使用一个恢复码，通过条件更新保证同一恢复码只能使用一次
+func (r *recoveryCodeRepository) Use(ctx context.Context, userID uint64, codeHash string, usedAt time.Time) (bool, error) {
+	result := r.db.WithContext(ctx).Model(&dao.RecoveryCodePO{}).
+		Where("user_id = ? AND code_hash = ? AND used_at IS NULL", userID, codeHash).
+		Update("used_at", usedAt)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
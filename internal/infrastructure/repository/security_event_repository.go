@@ -0,0 +1,72 @@
+package repository
+
+import (
+	"FLOWGO/internal/domain/entity"
+	"FLOWGO/internal/domain/repository"
+	"FLOWGO/internal/infrastructure/dao"
+	"context"
+
+	"gorm.io/gorm"
+)
+
+// securityEventRepository 安全事件仓储实现
+type securityEventRepository struct {
+	db *gorm.DB
+}
+
+// NewSecurityEventRepository 创建安全事件仓储实例
+func NewSecurityEventRepository(db *gorm.DB) repository.SecurityEventRepository {
+	return &securityEventRepository{db: db}
+}
+
+// Create 记录安全事件
+func (r *securityEventRepository) Create(ctx context.Context, event *entity.SecurityEvent) error {
+	po := &dao.SecurityEventPO{
+		Type:     string(event.Type),
+		UserId:   event.UserID,
+		Username: event.Username,
+		IP:       event.IP,
+		ActorId:  event.ActorID,
+		Detail:   event.Detail,
+	}
+	if err := r.db.WithContext(ctx).Create(po).Error; err != nil {
+		return err
+	}
+	event.ID = po.ID
+	event.CreatedAt = po.CreatedAt
+	return nil
+}
+
+// List 按时间倒序分页查询
+func (r *securityEventRepository) List(ctx context.Context, eventType string, page, pageSize int) ([]*entity.SecurityEvent, int64, error) {
+	query := r.db.WithContext(ctx).Model(&dao.SecurityEventPO{})
+	if eventType != "" {
+		query = query.Where("type = ?", eventType)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var pos []dao.SecurityEventPO
+	offset := (page - 1) * pageSize
+	if err := query.Order("id DESC").Offset(offset).Limit(pageSize).Find(&pos).Error; err != nil {
+		return nil, 0, err
+	}
+
+	events := make([]*entity.SecurityEvent, 0, len(pos))
+	for i := range pos {
+		events = append(events, &entity.SecurityEvent{
+			ID:        pos[i].ID,
+			Type:      entity.SecurityEventType(pos[i].Type),
+			UserID:    pos[i].UserId,
+			Username:  pos[i].Username,
+			IP:        pos[i].IP,
+			ActorID:   pos[i].ActorId,
+			Detail:    pos[i].Detail,
+			CreatedAt: pos[i].CreatedAt,
+		})
+	}
+	return events, total, nil
+}
@@ -0,0 +1,166 @@
+package repository
+
+import (
+	"FLOWGO/internal/domain/entity"
+	"FLOWGO/internal/domain/repository"
+	"FLOWGO/internal/infrastructure/dao"
+	"context"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// tagUsageSelect 统计使用标签的未删除项目数
+const tagUsageSelect = `tags.*, (
+	SELECT COUNT(*) FROM project_tags pt
+	JOIN projects p ON p.id = pt.project_id AND p.deleted_at IS NULL
+	WHERE pt.tag_id = tags.id
+) AS usage_count`
+
+// likeEscaper 转义 LIKE 中的通配符，配合 ESCAPE '\' 使用
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// tagRow 标签及其使用次数
+type tagRow struct {
+	dao.TagPO
+	UsageCount int64
+}
+
+// tagRepository 标签仓储实现
+type tagRepository struct {
+	db *gorm.DB
+}
+
+// NewTagRepository 创建标签仓储实例
+func NewTagRepository(db *gorm.DB) repository.TagRepository {
+	return &tagRepository{db: db}
+}
+
+// FindByID 根据ID查找
+func (r *tagRepository) FindByID(ctx context.Context, id uint64) (*entity.Tag, error) {
+	return r.findOne(r.db.WithContext(ctx).Where("tags.id = ?", id))
+}
+
+// FindByName 根据名称查找
+func (r *tagRepository) FindByName(ctx context.Context, name string) (*entity.Tag, error) {
+	return r.findOne(r.db.WithContext(ctx).Where("tags.name = ?", name))
+}
+
+// Create 创建标签
+func (r *tagRepository) Create(ctx context.Context, tag *entity.Tag) error {
+	po := r.toPO(tag)
+	if err := r.db.WithContext(ctx).Create(po).Error; err != nil {
+		return err
+	}
+	tag.ID = po.ID
+	tag.CreatedAt = po.CreatedAt
+	tag.UpdatedAt = po.UpdatedAt
+	return nil
+}
+
+// Update 修改标签名称和颜色
+func (r *tagRepository) Update(ctx context.Context, tag *entity.Tag) error {
+	tag.UpdatedAt = time.Now()
+	return r.db.WithContext(ctx).Model(&dao.TagPO{BasePO: dao.BasePO{ID: tag.ID}}).
+		Select("name", "color", "updated_at").
+		Updates(r.toPO(tag)).Error
+}
+
+// Delete 删除标签（物理删除），同时解除与全部项目的关联
+func (r *tagRepository) Delete(ctx context.Context, id uint64) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Delete(&dao.ProjectTagPO{}, "tag_id = ?", id).Error; err != nil {
+			return err
+		}
+		return tx.Unscoped().Delete(&dao.TagPO{}, id).Error
+	})
+}
+
+// FindOrCreate 按名称查找标签，不存在的标签使用默认颜色创建，返回顺序与 names 一致
+// 并发创建同名标签时依赖唯一索引忽略冲突，随后重新查询
+func (r *tagRepository) FindOrCreate(ctx context.Context, names []string) ([]*entity.Tag, error) {
+	if len(names) == 0 {
+		return []*entity.Tag{}, nil
+	}
+	db := r.db.WithContext(ctx)
+
+	pos := make([]*dao.TagPO, len(names))
+	for i, name := range names {
+		pos[i] = r.toPO(entity.NewTag(name, ""))
+	}
+	if err := db.Clauses(clause.OnConflict{DoNothing: true}).Create(&pos).Error; err != nil {
+		return nil, err
+	}
+
+	var found []*dao.TagPO
+	if err := db.Where("name IN ?", names).Find(&found).Error; err != nil {
+		return nil, err
+	}
+	byName := make(map[string]*dao.TagPO, len(found))
+	for _, po := range found {
+		byName[po.Name] = po
+	}
+	tags := make([]*entity.Tag, 0, len(names))
+	for _, name := range names {
+		if po := byName[name]; po != nil {
+			tags = append(tags, r.toEntity(&tagRow{TagPO: *po}))
+		}
+	}
+	return tags, nil
+}
+
+// Suggest 按名称前缀查询标签，使用次数多的排在前面
+func (r *tagRepository) Suggest(ctx context.Context, prefix string, limit int) ([]*entity.Tag, error) {
+	query := r.db.WithContext(ctx).Model(&dao.TagPO{}).Select(tagUsageSelect)
+	if prefix != "" {
+		query = query.Where(`tags.name LIKE ? ESCAPE '\'`, likeEscaper.Replace(prefix)+"%")
+	}
+
+	var rows []*tagRow
+	if err := query.Order("usage_count DESC, tags.name ASC").Limit(limit).Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+	tags := make([]*entity.Tag, len(rows))
+	for i, row := range rows {
+		tags[i] = r.toEntity(row)
+	}
+	return tags, nil
+}
+
+func (r *tagRepository) findOne(query *gorm.DB) (*entity.Tag, error) {
+	var rows []*tagRow
+	if err := query.Model(&dao.TagPO{}).Select(tagUsageSelect).Limit(1).Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+	if len(rows) == 0 {
+		return nil, nil
+	}
+	return r.toEntity(rows[0]), nil
+}
+
+func (r *tagRepository) toPO(e *entity.Tag) *dao.TagPO {
+	return &dao.TagPO{
+		BasePO: dao.BasePO{
+			ID:        e.ID,
+			CreatedAt: e.CreatedAt,
+			UpdatedAt: e.UpdatedAt,
+		},
+		Name:  e.Name,
+		Color: e.Color,
+	}
+}
+
+func (r *tagRepository) toEntity(row *tagRow) *entity.Tag {
+	return &entity.Tag{
+		BaseEntity: entity.BaseEntity{
+			ID:        row.ID,
+			CreatedAt: row.CreatedAt,
+			UpdatedAt: row.UpdatedAt,
+		},
+		Name:       row.Name,
+		Color:      row.Color,
+		UsageCount: row.UsageCount,
+	}
+}
@@ -0,0 +1,287 @@
+package repository
+
+import (
+	"FLOWGO/internal/domain/entity"
+	"FLOWGO/internal/domain/repository"
+	"FLOWGO/internal/infrastructure/dao"
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// taskRepository 任务仓储实现
+type taskRepository struct {
+	db *gorm.DB
+}
+
+// NewTaskRepository 创建任务仓储实例
+func NewTaskRepository(db *gorm.DB) repository.TaskRepository {
+	return &taskRepository{db: db}
+}
+
+// Create 创建任务，同时重新计算项目进度
+func (r *taskRepository) Create(ctx context.Context, task *entity.Task) error {
+	po := r.toPO(task)
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(po).Error; err != nil {
+			return err
+		}
+		// 插入后在同一事务中检查上限：SQLite 的写事务互斥，计数包含其他已提交的新任务，超出上限时回滚
+		var exceeded int64
+		if err := tx.Model(&dao.BoardColumnPO{}).
+			Where(wipLimitExceeded, task.ProjectID, string(task.Status), task.ProjectID, string(task.Status)).
+			Count(&exceeded).Error; err != nil {
+			return err
+		}
+		if exceeded > 0 {
+			return entity.ErrWIPLimitReached
+		}
+		return recomputeProjectProgress(tx, task.ProjectID)
+	})
+	if err != nil {
+		return err
+	}
+	task.ID = po.ID
+	task.CreatedAt = po.CreatedAt
+	task.UpdatedAt = po.UpdatedAt
+	return nil
+}
+
+// FindByID 根据ID查找
+func (r *taskRepository) FindByID(ctx context.Context, id uint64) (*entity.Task, error) {
+	var po dao.TaskPO
+	err := r.db.WithContext(ctx).Where("id = ?", id).First(&po).Error
+	if err == gorm.ErrRecordNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return r.toEntity(&po), nil
+}
+
+// Update 更新任务，零值字段（未指派、无截止日期等）同样写入，同时重新计算项目进度
+func (r *taskRepository) Update(ctx context.Context, task *entity.Task) error {
+	task.UpdatedAt = time.Now()
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := r.updateWithinWIPLimit(tx, task,
+			"title", "description", "assignee_id", "status", "priority", "due_date", "estimate", "completed_at", "updated_at"); err != nil {
+			return err
+		}
+		return recomputeProjectProgress(tx, task.ProjectID)
+	})
+}
+
+// Delete 删除任务（软删除），同时重新计算项目进度
+func (r *taskRepository) Delete(ctx context.Context, id uint64) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var po dao.TaskPO
+		if err := tx.Select("id", "project_id").Where("id = ?", id).First(&po).Error; err != nil {
+			return err
+		}
+		if err := tx.Delete(&dao.TaskPO{}, id).Error; err != nil {
+			return err
+		}
+		return recomputeProjectProgress(tx, po.ProjectId)
+	})
+}
+
+// List 按过滤条件分页查询，按优先级从高到低、创建时间倒序排序
+func (r *taskRepository) List(ctx context.Context, filter repository.TaskFilter, page, pageSize int) ([]*entity.Task, int64, error) {
+	query := r.db.WithContext(ctx).Model(&dao.TaskPO{})
+	if filter.ProjectID != 0 {
+		query = query.Where("project_id = ?", filter.ProjectID)
+	}
+	if filter.Status != "" {
+		query = query.Where("status = ?", string(filter.Status))
+	}
+	if filter.Priority != nil {
+		query = query.Where("priority = ?", int(*filter.Priority))
+	}
+	if filter.Unassigned {
+		query = query.Where("assignee_id = 0")
+	} else if filter.AssigneeID != 0 {
+		query = query.Where("assignee_id = ?", filter.AssigneeID)
+	}
+	if filter.ReporterID != 0 {
+		query = query.Where("reporter_id = ?", filter.ReporterID)
+	}
+	query = applyKeyword(query, filter.Keyword, "title")
+	if !filter.OverdueAt.IsZero() {
+		query = query.Where("due_date IS NOT NULL AND due_date < ? AND status NOT IN ?", filter.OverdueAt,
+			[]string{string(entity.TaskStatusDone), string(entity.TaskStatusCancelled)})
+	}
+	query = query.Session(&gorm.Session{})
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	var pos []*dao.TaskPO
+	if err := query.
+		Offset((page - 1) * pageSize).
+		Limit(pageSize).
+		Order("priority ASC, created_at DESC").
+		Find(&pos).Error; err != nil {
+		return nil, 0, err
+	}
+
+	tasks := make([]*entity.Task, len(pos))
+	for i, po := range pos {
+		tasks[i] = r.toEntity(po)
+	}
+	return tasks, total, nil
+}
+
+// ListByStatuses 查询项目中处于给定状态的全部任务，按排序键升序排列
+func (r *taskRepository) ListByStatuses(ctx context.Context, projectID uint64, statuses []entity.TaskStatus) ([]*entity.Task, error) {
+	if len(statuses) == 0 {
+		return []*entity.Task{}, nil
+	}
+	values := make([]string, len(statuses))
+	for i, status := range statuses {
+		values[i] = string(status)
+	}
+
+	var pos []*dao.TaskPO
+	if err := r.db.WithContext(ctx).
+		Where("project_id = ? AND status IN ?", projectID, values).
+		Order("rank_key ASC, id ASC").
+		Find(&pos).Error; err != nil {
+		return nil, err
+	}
+	tasks := make([]*entity.Task, len(pos))
+	for i, po := range pos {
+		tasks[i] = r.toEntity(po)
+	}
+	return tasks, nil
+}
+
+// MaxRank 查询项目中最大的排序键，没有任务时返回空字符串
+func (r *taskRepository) MaxRank(ctx context.Context, projectID uint64) (string, error) {
+	var ranks []string
+	err := r.db.WithContext(ctx).Model(&dao.TaskPO{}).
+		Where("project_id = ?", projectID).
+		Order("rank_key DESC").
+		Limit(1).
+		Pluck("rank_key", &ranks).Error
+	if err != nil || len(ranks) == 0 {
+		return "", err
+	}
+	return ranks[0], nil
+}
+
+// NextRank 查询状态列中排在 after 之后的第一个排序键
+func (r *taskRepository) NextRank(ctx context.Context, projectID uint64, status entity.TaskStatus, after string, excludeID uint64) (string, error) {
+	var ranks []string
+	err := r.db.WithContext(ctx).Model(&dao.TaskPO{}).
+		Where("project_id = ? AND status = ? AND rank_key > ? AND id <> ?", projectID, string(status), after, excludeID).
+		Order("rank_key ASC").
+		Limit(1).
+		Pluck("rank_key", &ranks).Error
+	if err != nil || len(ranks) == 0 {
+		return "", err
+	}
+	return ranks[0], nil
+}
+
+// Move 保存任务的状态和排序键，与在制品上限检查、项目进度计算在同一事务中完成
+func (r *taskRepository) Move(ctx context.Context, task *entity.Task) error {
+	task.UpdatedAt = time.Now()
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := r.updateWithinWIPLimit(tx, task, "status", "rank_key", "completed_at", "updated_at"); err != nil {
+			return err
+		}
+		return recomputeProjectProgress(tx, task.ProjectID)
+	})
+}
+
+// wipLimitBlocks 目标状态对应的看板列中有列已满（任务数达到上限）
+// 参数依次为项目 ID、状态、项目 ID、状态
+const wipLimitBlocks = `EXISTS (SELECT 1 FROM board_columns c JOIN boards b ON b.id = c.board_id
+	WHERE b.project_id = ? AND b.deleted_at IS NULL AND c.status = ? AND c.wip_limit > 0
+	AND c.wip_limit <= (SELECT COUNT(*) FROM tasks t WHERE t.project_id = ? AND t.status = ? AND t.deleted_at IS NULL))`
+
+// wipLimitExceeded 查询项目中任务数超出上限的看板列，参数同 wipLimitBlocks
+const wipLimitExceeded = `board_id IN (SELECT id FROM boards WHERE project_id = ? AND deleted_at IS NULL) AND status = ? AND wip_limit > 0
+	AND wip_limit < (SELECT COUNT(*) FROM tasks t WHERE t.project_id = ? AND t.status = ? AND t.deleted_at IS NULL)`
+
+// updateWithinWIPLimit 条件更新任务的指定字段：状态不变，或目标状态的列都未满时才写入
+// 上限检查与写入在同一条语句中完成，并发修改不会超出上限；没有写入时区分任务已删除和列已满
+func (r *taskRepository) updateWithinWIPLimit(tx *gorm.DB, task *entity.Task, columns ...string) error {
+	status := string(task.Status)
+	res := tx.Model(&dao.TaskPO{}).
+		Where("id = ?", task.ID).
+		Where("status = ? OR NOT "+wipLimitBlocks, status, task.ProjectID, status, task.ProjectID, status).
+		Select(columns).
+		Updates(r.toPO(task))
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected > 0 {
+		return nil
+	}
+	var exists int64
+	if err := tx.Model(&dao.TaskPO{}).Where("id = ?", task.ID).Count(&exists).Error; err != nil {
+		return err
+	}
+	if exists == 0 {
+		return entity.ErrTaskNotFound
+	}
+	return entity.ErrWIPLimitReached
+}
+
+func (r *taskRepository) toPO(e *entity.Task) *dao.TaskPO {
+	var dueDate *time.Time
+	if !e.DueDate.IsZero() {
+		dueDate = &e.DueDate
+	}
+	return &dao.TaskPO{
+		BasePO: dao.BasePO{
+			ID:        e.ID,
+			CreatedAt: e.CreatedAt,
+			UpdatedAt: e.UpdatedAt,
+		},
+		ProjectId:   e.ProjectID,
+		Title:       e.Title,
+		Description: e.Description,
+		AssigneeId:  e.AssigneeID,
+		ReporterId:  e.ReporterID,
+		Status:      string(e.Status),
+		Priority:    int(e.Priority),
+		DueDate:     dueDate,
+		Estimate:    e.Estimate,
+		Rank:        e.Rank,
+		CompletedAt: e.CompletedAt,
+	}
+}
+
+func (r *taskRepository) toEntity(po *dao.TaskPO) *entity.Task {
+	var dueDate time.Time
+	if po.DueDate != nil {
+		dueDate = *po.DueDate
+	}
+	e := &entity.Task{
+		BaseEntity: entity.BaseEntity{
+			ID:        po.ID,
+			CreatedAt: po.CreatedAt,
+			UpdatedAt: po.UpdatedAt,
+		},
+		ProjectID:   po.ProjectId,
+		Title:       po.Title,
+		Description: po.Description,
+		AssigneeID:  po.AssigneeId,
+		ReporterID:  po.ReporterId,
+		Status:      entity.TaskStatus(po.Status),
+		Priority:    entity.TaskPriority(po.Priority),
+		DueDate:     dueDate,
+		Estimate:    po.Estimate,
+		Rank:        po.Rank,
+		CompletedAt: po.CompletedAt,
+	}
+	if po.DeletedAt.Valid {
+		e.DeletedAt = &po.DeletedAt.Time
+	}
+	return e
+}
@@ -0,0 +1,179 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"gorm.io/gorm"
+
+	"FLOWGO/internal/domain/entity"
+	"FLOWGO/internal/infrastructure/dao"
+)
+
+// setupWIPBoard 创建项目和一个看板，进行中列的在制品上限为 limit
+func setupWIPBoard(t *testing.T, db *gorm.DB, limit int) uint64 {
+	t.Helper()
+	project := createTestProject(t, db, 1)
+	board := &dao.BoardPO{ProjectId: project.ID, Name: "board"}
+	mustCreate(t, db, board)
+	columns := []dao.BoardColumnPO{
+		{BoardId: board.ID, Name: "Todo", Status: string(entity.TaskStatusTodo), Position: 0},
+		{BoardId: board.ID, Name: "Doing", Status: string(entity.TaskStatusInProgress), Position: 1, WIPLimit: limit},
+		{BoardId: board.ID, Name: "Review", Status: string(entity.TaskStatusInReview), Position: 2},
+	}
+	mustCreate(t, db, &columns)
+	return project.ID
+}
+
+// newTestTask 构造指定状态的任务，rank 决定看板内的顺序
+func newTestTask(projectID uint64, status entity.TaskStatus, rank string) *entity.Task {
+	task := entity.NewTask(projectID, "task "+rank, "", 1)
+	task.Status = status
+	task.Rank = rank
+	return task
+}
+
+// countTasks 统计项目中处于 status 的任务数
+func countTasks(t *testing.T, db *gorm.DB, projectID uint64, status entity.TaskStatus) int64 {
+	t.Helper()
+	var n int64
+	if err := db.Model(&dao.TaskPO{}).Where("project_id = ? AND status = ?", projectID, string(status)).Count(&n).Error; err != nil {
+		t.Fatalf("count tasks: %v", err)
+	}
+	return n
+}
+
+// TestTaskCreateWIPLimit 列满后不能再新建该状态的任务，其他状态不受影响
+func TestTaskCreateWIPLimit(t *testing.T) {
+	db := newTestDB(t)
+	repo := NewTaskRepository(db)
+	ctx := context.Background()
+	projectID := setupWIPBoard(t, db, 2)
+
+	for _, rank := range []string{"a", "b"} {
+		if err := repo.Create(ctx, newTestTask(projectID, entity.TaskStatusInProgress, rank)); err != nil {
+			t.Fatalf("create %s: %v", rank, err)
+		}
+	}
+	err := repo.Create(ctx, newTestTask(projectID, entity.TaskStatusInProgress, "c"))
+	if !errors.Is(err, entity.ErrWIPLimitReached) {
+		t.Fatalf("create into full column: expected ErrWIPLimitReached, got %v", err)
+	}
+	if n := countTasks(t, db, projectID, entity.TaskStatusInProgress); n != 2 {
+		t.Fatalf("rejected task must be rolled back, got %d tasks", n)
+	}
+	if err := repo.Create(ctx, newTestTask(projectID, entity.TaskStatusTodo, "d")); err != nil {
+		t.Fatalf("create into column without limit: %v", err)
+	}
+}
+
+// TestTaskMoveWIPLimit 移入已满的列被拒绝，在已满的列内调整顺序不受上限限制
+func TestTaskMoveWIPLimit(t *testing.T) {
+	db := newTestDB(t)
+	repo := NewTaskRepository(db)
+	ctx := context.Background()
+	projectID := setupWIPBoard(t, db, 2)
+
+	doing := []*entity.Task{
+		newTestTask(projectID, entity.TaskStatusInProgress, "a"),
+		newTestTask(projectID, entity.TaskStatusInProgress, "b"),
+	}
+	todo := newTestTask(projectID, entity.TaskStatusTodo, "c")
+	for _, task := range append(doing, todo) {
+		if err := repo.Create(ctx, task); err != nil {
+			t.Fatalf("create %s: %v", task.Rank, err)
+		}
+	}
+	now := time.Now()
+
+	doing[0].MoveTo(entity.TaskStatusInProgress, "bb", now)
+	if err := repo.Move(ctx, doing[0]); err != nil {
+		t.Fatalf("move within full column: %v", err)
+	}
+
+	todo.MoveTo(entity.TaskStatusInProgress, "d", now)
+	if err := repo.Move(ctx, todo); !errors.Is(err, entity.ErrWIPLimitReached) {
+		t.Fatalf("move into full column: expected ErrWIPLimitReached, got %v", err)
+	}
+	stored, err := repo.FindByID(ctx, todo.ID)
+	if err != nil {
+		t.Fatalf("find task: %v", err)
+	}
+	if stored.Status != entity.TaskStatusTodo || stored.Rank != "c" {
+		t.Fatalf("rejected move must not be written, got status %s rank %s", stored.Status, stored.Rank)
+	}
+
+	// 移出一张卡片后有了空位
+	doing[1].MoveTo(entity.TaskStatusInReview, "e", now)
+	if err := repo.Move(ctx, doing[1]); err != nil {
+		t.Fatalf("move out of full column: %v", err)
+	}
+	if err := repo.Move(ctx, todo); err != nil {
+		t.Fatalf("move into column with free slot: %v", err)
+	}
+}
+
+// TestTaskUpdateDeleted 任务已被删除时返回 ErrTaskNotFound，而不是误报列已满
+func TestTaskUpdateDeleted(t *testing.T) {
+	db := newTestDB(t)
+	repo := NewTaskRepository(db)
+	ctx := context.Background()
+	projectID := setupWIPBoard(t, db, 2)
+
+	task := newTestTask(projectID, entity.TaskStatusTodo, "a")
+	if err := repo.Create(ctx, task); err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	if err := repo.Delete(ctx, task.ID); err != nil {
+		t.Fatalf("delete: %v", err)
+	}
+
+	task.MoveTo(entity.TaskStatusInProgress, "b", time.Now())
+	if err := repo.Move(ctx, task); !errors.Is(err, entity.ErrTaskNotFound) {
+		t.Fatalf("move deleted task: expected ErrTaskNotFound, got %v", err)
+	}
+	task.Title = "renamed"
+	if err := repo.Update(ctx, task); !errors.Is(err, entity.ErrTaskNotFound) {
+		t.Fatalf("update deleted task: expected ErrTaskNotFound, got %v", err)
+	}
+}
+
+// TestTaskCreateWIPLimitConcurrent 并发新建任务时列中的任务数不会超过上限
+func TestTaskCreateWIPLimitConcurrent(t *testing.T) {
+	db := newTestDB(t)
+	repo := NewTaskRepository(db)
+	ctx := context.Background()
+	const limit, workers = 3, 10
+	projectID := setupWIPBoard(t, db, limit)
+
+	var wg sync.WaitGroup
+	errs := make([]error, workers)
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			errs[i] = repo.Create(ctx, newTestTask(projectID, entity.TaskStatusInProgress, fmt.Sprintf("r%02d", i)))
+		}(i)
+	}
+	wg.Wait()
+
+	created := 0
+	for i, err := range errs {
+		switch {
+		case err == nil:
+			created++
+		case !errors.Is(err, entity.ErrWIPLimitReached):
+			t.Fatalf("worker %d: unexpected error %v", i, err)
+		}
+	}
+	if created != limit {
+		t.Fatalf("expected %d tasks to be created, got %d", limit, created)
+	}
+	if n := countTasks(t, db, projectID, entity.TaskStatusInProgress); n != limit {
+		t.Fatalf("expected %d tasks in the column, got %d", limit, n)
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"FLOWGO/internal/domain/repository"
 	"FLOWGO/internal/infrastructure/dao"
 	"context"
+	"time"
 
 	"gorm.io/gorm"
 )
@@ -19,6 +20,102 @@ func NewTeamRepository(db *gorm.DB) repository.TeamRepository {
 	}
 }
 
+// Create 创建团队，负责人同时以 lead 角色加入团队
+func (r *teamRepository) Create(ctx context.Context, team *entity.Team) error {
+	po := r.toPO(team)
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(po).Error; err != nil {
+			return err
+		}
+		if team.OwnerId == 0 {
+			return nil
+		}
+		return tx.Create(&dao.TeamMemberPO{
+			TeamId: po.ID,
+			UserId: team.OwnerId,
+			Role:   string(entity.TeamRoleLead),
+		}).Error
+	})
+	if err != nil {
+		return err
+	}
+	team.ID = po.ID
+	team.CreatedAt = po.CreatedAt
+	team.UpdatedAt = po.UpdatedAt
+	return nil
+}
+
+// FindByID 根据ID查找
+func (r *teamRepository) FindByID(ctx context.Context, id uint64) (*entity.Team, error) {
+	var po dao.TeamPO
+	err := r.db.WithContext(ctx).Where("id = ?", id).First(&po).Error
+	if err == gorm.ErrRecordNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return r.toEntity(&po), nil
+}
+
+// Update 更新团队
+func (r *teamRepository) Update(ctx context.Context, team *entity.Team) error {
+	team.UpdatedAt = time.Now()
+	return r.db.WithContext(ctx).Model(&dao.TeamPO{BasePO: dao.BasePO{ID: team.ID}}).
+		Select("name", "description", "owner_id", "parent_id", "updated_at").
+		Updates(r.toPO(team)).Error
+}
+
+// Delete 删除团队（软删除），同时解除团队与项目的关联并移除全部成员
+// 子团队挂到被删除团队的上级团队下
+func (r *teamRepository) Delete(ctx context.Context, id uint64) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var po dao.TeamPO
+		if err := tx.Where("id = ?", id).First(&po).Error; err != nil {
+			return err
+		}
+		if err := tx.Model(&dao.TeamPO{}).Where("parent_id = ?", id).
+			Update("parent_id", po.ParentId).Error; err != nil {
+			return err
+		}
+		if err := tx.Delete(&dao.ProjectTeamPO{}, "team_id = ?", id).Error; err != nil {
+			return err
+		}
+		if err := tx.Unscoped().Delete(&dao.TeamMemberPO{}, "team_id = ?", id).Error; err != nil {
+			return err
+		}
+		if err := tx.Model(&entity.User{}).Where("team_id = ?", id).Update("team_id", 0).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&dao.TeamPO{}, id).Error
+	})
+}
+
+// List 列表查询
+func (r *teamRepository) List(ctx context.Context, page, pageSize int) ([]*entity.Team, int64, error) {
+	var pos []*dao.TeamPO
+	var total int64
+
+	offset := (page - 1) * pageSize
+
+	if err := r.db.WithContext(ctx).Model(&dao.TeamPO{}).Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	if err := r.db.WithContext(ctx).
+		Offset(offset).
+		Limit(pageSize).
+		Order("created_at DESC").
+		Find(&pos).Error; err != nil {
+		return nil, 0, err
+	}
+
+	teams := make([]*entity.Team, len(pos))
+	for i, po := range pos {
+		teams[i] = r.toEntity(po)
+	}
+	return teams, total, nil
+}
+
 // ListAvailableTeams 列表查询可用团队
 func (r *teamRepository) ListAvailableTeams(ctx context.Context) ([]*entity.Team, error) {
 	var pos []*dao.TeamPO
@@ -28,17 +125,191 @@ func (r *teamRepository) ListAvailableTeams(ctx context.Context) ([]*entity.Team
 	}
 	teams := make([]*entity.Team, len(pos))
 	for i, po := range pos {
-		teams[i] = &entity.Team{
-			BaseEntity: entity.BaseEntity{
-				ID:        po.ID,
-				CreatedAt: po.CreatedAt,
-				UpdatedAt: po.UpdatedAt,
-				DeletedAt: &po.DeletedAt.Time, // Assuming valid given query
-			},
-			Name:        po.Name,
-			Description: po.Description,
-			OwnerId:     po.OwnerId,
-		}
+		teams[i] = r.toEntity(po)
 	}
 	return teams, nil
 }
+
+// ExistsByName 检查团队名称是否已被其他团队使用
+func (r *teamRepository) ExistsByName(ctx context.Context, name string, excludeID uint64) (bool, error) {
+	var count int64
+	err := r.db.WithContext(ctx).Model(&dao.TeamPO{}).
+		Where("name = ? AND id <> ?", name, excludeID).
+		Count(&count).Error
+	return count > 0, err
+}
+
+// ListSubtreeIDs 查询团队及其全部下级团队的ID
+func (r *teamRepository) ListSubtreeIDs(ctx context.Context, teamID uint64) ([]uint64, error) {
+	return subtreeTeamIDs(r.db.WithContext(ctx), []uint64{teamID})
+}
+
+// ListMembers 查询团队成员
+func (r *teamRepository) ListMembers(ctx context.Context, teamID uint64) ([]*entity.User, error) {
+	var users []*entity.User
+	err := r.db.WithContext(ctx).
+		Joins("JOIN team_members ON team_members.user_id = users.id").
+		Where("team_members.team_id = ? AND team_members.deleted_at IS NULL AND users.deleted_at IS NULL", teamID).
+		Order("users.id").
+		Find(&users).Error
+	if err != nil {
+		return nil, err
+	}
+	return users, nil
+}
+
+// ListMemberRoles 查询团队全部成员的角色
+func (r *teamRepository) ListMemberRoles(ctx context.Context, teamID uint64) (map[uint64]entity.TeamRole, error) {
+	var pos []*dao.TeamMemberPO
+	if err := r.db.WithContext(ctx).Where("team_id = ?", teamID).Find(&pos).Error; err != nil {
+		return nil, err
+	}
+	roles := make(map[uint64]entity.TeamRole, len(pos))
+	for _, po := range pos {
+		roles[po.UserId] = entity.TeamRole(po.Role)
+	}
+	return roles, nil
+}
+
+// FindMemberRole 查询用户在团队中的角色，非成员返回空角色
+func (r *teamRepository) FindMemberRole(ctx context.Context, teamID, userID uint64) (entity.TeamRole, error) {
+	var po dao.TeamMemberPO
+	err := r.db.WithContext(ctx).
+		Where("team_id = ? AND user_id = ?", teamID, userID).
+		First(&po).Error
+	if err == gorm.ErrRecordNotFound {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+	return entity.TeamRole(po.Role), nil
+}
+
+// AddMembers 添加团队成员，已是成员的用户保持原角色不变
+func (r *teamRepository) AddMembers(ctx context.Context, teamID uint64, userIDs []uint64, role entity.TeamRole) error {
+	if len(userIDs) == 0 {
+		return nil
+	}
+	var existingUserIDs []uint64
+	if err := r.db.WithContext(ctx).Model(&dao.TeamMemberPO{}).
+		Where("team_id = ? AND user_id IN ?", teamID, userIDs).
+		Pluck("user_id", &existingUserIDs).Error; err != nil {
+		return err
+	}
+
+	existing := make(map[uint64]bool, len(existingUserIDs))
+	for _, id := range existingUserIDs {
+		existing[id] = true
+	}
+	var pos []*dao.TeamMemberPO
+	for _, uid := range userIDs {
+		if !existing[uid] {
+			existing[uid] = true
+			pos = append(pos, &dao.TeamMemberPO{
+				TeamId: teamID,
+				UserId: uid,
+				Role:   string(role),
+			})
+		}
+	}
+	if len(pos) == 0 {
+		return nil
+	}
+	return r.db.WithContext(ctx).Create(&pos).Error
+}
+
+// SetMemberRole 设置成员角色，用户不是成员时加入团队
+func (r *teamRepository) SetMemberRole(ctx context.Context, teamID, userID uint64, role entity.TeamRole) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return setTeamMemberRole(tx, teamID, userID, role)
+	})
+}
+
+// TransferOwner 更新团队负责人并把新负责人设为 lead，两步在同一事务中完成
+func (r *teamRepository) TransferOwner(ctx context.Context, team *entity.Team) error {
+	team.UpdatedAt = time.Now()
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&dao.TeamPO{BasePO: dao.BasePO{ID: team.ID}}).
+			Select("owner_id", "updated_at").
+			Updates(r.toPO(team)).Error; err != nil {
+			return err
+		}
+		return setTeamMemberRole(tx, team.ID, team.OwnerId, entity.TeamRoleLead)
+	})
+}
+
+// setTeamMemberRole 在事务中设置成员角色，用户不是成员时加入团队
+func setTeamMemberRole(tx *gorm.DB, teamID, userID uint64, role entity.TeamRole) error {
+	res := tx.Model(&dao.TeamMemberPO{}).
+		Where("team_id = ? AND user_id = ?", teamID, userID).
+		Update("role", string(role))
+	if res.Error != nil || res.RowsAffected > 0 {
+		return res.Error
+	}
+	return tx.Create(&dao.TeamMemberPO{
+		TeamId: teamID,
+		UserId: userID,
+		Role:   string(role),
+	}).Error
+}
+
+// RemoveMember 移除团队成员（物理删除，以便之后可以重新加入）
+func (r *teamRepository) RemoveMember(ctx context.Context, teamID, userID uint64) error {
+	return r.db.WithContext(ctx).Unscoped().
+		Where("team_id = ? AND user_id = ?", teamID, userID).
+		Delete(&dao.TeamMemberPO{}).Error
+}
+
+func (r *teamRepository) toPO(e *entity.Team) *dao.TeamPO {
+	return &dao.TeamPO{
+		BasePO: dao.BasePO{
+			ID:        e.ID,
+			CreatedAt: e.CreatedAt,
+			UpdatedAt: e.UpdatedAt,
+		},
+		Name:        e.Name,
+		Description: e.Description,
+		OwnerId:     e.OwnerId,
+		ParentId:    e.ParentId,
+	}
+}
+
+func (r *teamRepository) toEntity(po *dao.TeamPO) *entity.Team {
+	e := &entity.Team{
+		BaseEntity: entity.BaseEntity{
+			ID:        po.ID,
+			CreatedAt: po.CreatedAt,
+			UpdatedAt: po.UpdatedAt,
+		},
+		Name:        po.Name,
+		Description: po.Description,
+		OwnerId:     po.OwnerId,
+		ParentId:    po.ParentId,
+	}
+	if po.DeletedAt.Valid {
+		e.DeletedAt = &po.DeletedAt.Time
+	}
+	return e
+}
+
+// subtreeTeamIDs 递归查询给定团队及其全部下级团队的ID，已删除的团队不会被展开
+// 使用 UNION 去重，即使数据中存在环也能结束
+func subtreeTeamIDs(db *gorm.DB, teamIDs []uint64) ([]uint64, error) {
+	if len(teamIDs) == 0 {
+		return []uint64{}, nil
+	}
+	var ids []uint64
+	err := db.Raw(`
+		WITH RECURSIVE subtree(id) AS (
+			SELECT id FROM teams WHERE id IN ? AND deleted_at IS NULL
+			UNION
+			SELECT t.id FROM teams t JOIN subtree s ON t.parent_id = s.id WHERE t.deleted_at IS NULL
+		)
+		SELECT id FROM subtree`, teamIDs).
+		Scan(&ids).Error
+	if err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
@@ -0,0 +1,81 @@
+package repository
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/glebarez/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+
+	"FLOWGO/internal/domain/entity"
+	"FLOWGO/internal/infrastructure/dao"
+)
+
+// newTestDB 在临时目录中创建 SQLite 数据库并迁移全部表结构
+// 使用文件而不是内存数据库，多个连接才能看到同一份数据，用于并发测试
+func newTestDB(t *testing.T) *gorm.DB {
+	t.Helper()
+	dsn := filepath.Join(t.TempDir(), "flowgo.db") + "?_pragma=busy_timeout(5000)"
+	db, err := gorm.Open(sqlite.Open(dsn), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	if err != nil {
+		t.Fatalf("open database: %v", err)
+	}
+	if err := db.AutoMigrate(
+		&entity.User{},
+		&entity.Project{},
+		&entity.Team{},
+		&dao.ProjectTeamPO{},
+		&dao.ProjectUserPO{},
+		&dao.AccessTokenPO{},
+		&dao.OneTimeTokenPO{},
+		&dao.RecoveryCodePO{},
+		&dao.SecurityEventPO{},
+		&dao.UserIdentityPO{},
+		&dao.TeamMemberPO{},
+		&dao.InvitationPO{},
+		&dao.TaskPO{},
+		&dao.BoardPO{},
+		&dao.BoardColumnPO{},
+		&dao.TagPO{},
+		&dao.ProjectTagPO{},
+		&dao.ProjectStatusChangePO{},
+		&dao.MilestonePO{},
+	); err != nil {
+		t.Fatalf("migrate database: %v", err)
+	}
+	t.Cleanup(func() {
+		if sqlDB, err := db.DB(); err == nil {
+			sqlDB.Close()
+		}
+	})
+	return db
+}
+
+// mustCreate 插入测试数据，失败时终止测试
+func mustCreate(t *testing.T, db *gorm.DB, value interface{}) {
+	t.Helper()
+	if err := db.Create(value).Error; err != nil {
+		t.Fatalf("create %T: %v", value, err)
+	}
+}
+
+// createTestProject 创建由 ownerID 负责的项目
+func createTestProject(t *testing.T, db *gorm.DB, ownerID uint64) *dao.ProjectPO {
+	t.Helper()
+	project := &dao.ProjectPO{Name: "project", Description: "test", OwnerId: ownerID, Status: 1, ProgressMode: "tasks", Version: 1}
+	mustCreate(t, db, project)
+	return project
+}
+
+// createTestUser 创建用户，deletedAt 不为零时放入回收站
+func createTestUser(t *testing.T, db *gorm.DB, name string, deletedAt time.Time) *entity.User {
+	t.Helper()
+	user := &entity.User{Name: name, Email: name + "@flowgo.test", Password: "x", Status: entity.UserStatusActive, Role: "member"}
+	if !deletedAt.IsZero() {
+		user.DeletedAt = &deletedAt
+	}
+	mustCreate(t, db, user)
+	return user
+}
@@ -0,0 +1,186 @@
+package repository
+
+import (
+	"context"
+	"log"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+
+	domainRepo "FLOWGO/internal/domain/repository"
+)
+
+const (
+	revokedTokenKeyPrefix = "flowgo:revoked_token:"
+	revokedUserKeyPrefix  = "flowgo:revoked_user:"
+
+	// legacyRevokedUserMillis 小于该值的吊销时间点是旧版本按秒保存的（按毫秒解读会落在 1973 年之前）
+	legacyRevokedUserMillis = 1e11
+)
+
+// userRevocation 用户级别的吊销记录
+type userRevocation struct {
+	issuedBefore time.Time
+	expiresAt    time.Time
+}
+
+// tokenRepository Token吊销仓储实现
+// 吊销记录优先写入Redis，同时保存在进程内存中，Redis不可用时退化为内存存储
+type tokenRepository struct {
+	client *redis.Client
+
+	mu          sync.Mutex
+	revoked     map[string]time.Time
+	revokedUser map[uint64]userRevocation
+	lastPurge   time.Time
+}
+
+// NewTokenRepository 创建Token吊销仓储实例，client 可以为 nil
+func NewTokenRepository(client *redis.Client) domainRepo.TokenRepository {
+	return &tokenRepository{
+		client:      client,
+		revoked:     make(map[string]time.Time),
+		revokedUser: make(map[uint64]userRevocation),
+	}
+}
+
+// Revoke 吊销Token
+func (r *tokenRepository) Revoke(ctx context.Context, jti string, expiresAt time.Time) error {
+	ttl := time.Until(expiresAt)
+	if jti == "" || ttl <= 0 {
+		return nil
+	}
+
+	r.mu.Lock()
+	r.revoked[jti] = expiresAt
+	r.mu.Unlock()
+
+	if r.client != nil {
+		if err := r.client.Set(ctx, revokedTokenKeyPrefix+jti, 1, ttl).Err(); err != nil {
+			log.Printf("Warning: failed to store revoked token in redis, using memory fallback: %v", err)
+		}
+	}
+	return nil
+}
+
+// Consume 原子地吊销一次性Token，Redis 中使用 SET NX，并发使用同一Token时只有一方成功
+func (r *tokenRepository) Consume(ctx context.Context, jti string, expiresAt time.Time) (bool, error) {
+	ttl := time.Until(expiresAt)
+	if jti == "" || ttl <= 0 {
+		return false, nil
+	}
+
+	r.mu.Lock()
+	r.purgeExpired()
+	_, used := r.revoked[jti]
+	r.revoked[jti] = expiresAt
+	r.mu.Unlock()
+	if used {
+		return false, nil
+	}
+
+	if r.client != nil {
+		ok, err := r.client.SetNX(ctx, revokedTokenKeyPrefix+jti, 1, ttl).Result()
+		if err != nil {
+			log.Printf("Warning: failed to consume token in redis, using memory fallback: %v", err)
+			return true, nil
+		}
+		return ok, nil
+	}
+	return true, nil
+}
+
+// IsRevoked 检查Token是否已吊销
+func (r *tokenRepository) IsRevoked(ctx context.Context, jti string) (bool, error) {
+	if jti == "" {
+		return false, nil
+	}
+
+	r.mu.Lock()
+	r.purgeExpired()
+	_, ok := r.revoked[jti]
+	r.mu.Unlock()
+	if ok {
+		return true, nil
+	}
+
+	if r.client != nil {
+		n, err := r.client.Exists(ctx, revokedTokenKeyPrefix+jti).Result()
+		if err != nil {
+			log.Printf("Warning: failed to check revoked token in redis, using memory fallback: %v", err)
+			return false, nil
+		}
+		return n > 0, nil
+	}
+	return false, nil
+}
+
+// RevokeUserTokens 吊销用户在指定时间之前签发的全部Token，Redis 中按毫秒保存
+func (r *tokenRepository) RevokeUserTokens(ctx context.Context, userID uint64, issuedBefore, expiresAt time.Time) error {
+	ttl := time.Until(expiresAt)
+	if ttl <= 0 {
+		return nil
+	}
+
+	r.mu.Lock()
+	r.revokedUser[userID] = userRevocation{issuedBefore: issuedBefore, expiresAt: expiresAt}
+	r.mu.Unlock()
+
+	if r.client != nil {
+		key := revokedUserKeyPrefix + strconv.FormatUint(userID, 10)
+		if err := r.client.Set(ctx, key, issuedBefore.UnixMilli(), ttl).Err(); err != nil {
+			log.Printf("Warning: failed to store revoked user in redis, using memory fallback: %v", err)
+		}
+	}
+	return nil
+}
+
+// UserTokensRevokedAt 获取用户Token的吊销时间点
+func (r *tokenRepository) UserTokensRevokedAt(ctx context.Context, userID uint64) (time.Time, error) {
+	r.mu.Lock()
+	r.purgeExpired()
+	rev, ok := r.revokedUser[userID]
+	r.mu.Unlock()
+	if ok {
+		return rev.issuedBefore, nil
+	}
+
+	if r.client != nil {
+		key := revokedUserKeyPrefix + strconv.FormatUint(userID, 10)
+		ms, err := r.client.Get(ctx, key).Int64()
+		if err == redis.Nil {
+			return time.Time{}, nil
+		}
+		if err != nil {
+			log.Printf("Warning: failed to check revoked user in redis, using memory fallback: %v", err)
+			return time.Time{}, nil
+		}
+		// 旧版本按秒保存
+		if ms < legacyRevokedUserMillis {
+			return time.Unix(ms, 0), nil
+		}
+		return time.UnixMilli(ms), nil
+	}
+	return time.Time{}, nil
+}
+
+// purgeExpired 定期清理已自然过期的吊销记录，调用方需持有锁
+func (r *tokenRepository) purgeExpired() {
+	now := time.Now()
+	if now.Sub(r.lastPurge) < time.Minute {
+		return
+	}
+	r.lastPurge = now
+	for jti, expiresAt := range r.revoked {
+		if now.After(expiresAt) {
+			delete(r.revoked, jti)
+		}
+	}
+	for userID, rev := range r.revokedUser {
+		if now.After(rev.expiresAt) {
+			delete(r.revokedUser, userID)
+		}
+	}
+}
@@ -0,0 +1,62 @@
+package repository
+
+import (
+	"FLOWGO/internal/domain/entity"
+	"FLOWGO/internal/domain/repository"
+	"FLOWGO/internal/infrastructure/dao"
+	"context"
+
+	"gorm.io/gorm"
+)
+
+// userIdentityRepository 外部身份绑定仓储实现
+type userIdentityRepository struct {
+	db *gorm.DB
+}
+
+// NewUserIdentityRepository 创建外部身份绑定仓储实例
+func NewUserIdentityRepository(db *gorm.DB) repository.UserIdentityRepository {
+	return &userIdentityRepository{db: db}
+}
+
+// Create 创建绑定
+func (r *userIdentityRepository) Create(ctx context.Context, identity *entity.UserIdentity) error {
+	po := &dao.UserIdentityPO{
+		UserId:   identity.UserID,
+		Provider: identity.Provider,
+		Subject:  identity.Subject,
+		Email:    identity.Email,
+	}
+	if err := r.db.WithContext(ctx).Create(po).Error; err != nil {
+		return err
+	}
+	identity.ID = po.ID
+	identity.CreatedAt = po.CreatedAt
+	identity.UpdatedAt = po.UpdatedAt
+	return nil
+}
+
+// FindBySubject 根据提供方和 sub 查找绑定
+func (r *userIdentityRepository) FindBySubject(ctx context.Context, provider, subject string) (*entity.UserIdentity, error) {
+	var po dao.UserIdentityPO
+	err := r.db.WithContext(ctx).
+		Where("provider = ? AND subject = ?", provider, subject).
+		First(&po).Error
+	if err == gorm.ErrRecordNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &entity.UserIdentity{
+		BaseEntity: entity.BaseEntity{
+			ID:        po.ID,
+			CreatedAt: po.CreatedAt,
+			UpdatedAt: po.UpdatedAt,
+		},
+		UserID:   po.UserId,
+		Provider: po.Provider,
+		Subject:  po.Subject,
+		Email:    po.Email,
+	}, nil
+}
@@ -7,7 +7,9 @@ import (
 	"gorm.io/gorm"
 
 	"FLOWGO/internal/domain/entity"
+	"FLOWGO/internal/domain/permission"
 	domainRepo "FLOWGO/internal/domain/repository"
+	"FLOWGO/internal/infrastructure/dao"
 )
 
 // userRepository 用户仓储实现
@@ -35,10 +37,10 @@ func (r *userRepository) FindByID(ctx context.Context, id uint64) (*entity.User,
 	return &user, nil
 }
 
-// FindByUsername 根据用户名查找
-func (r *userRepository) FindByUsername(ctx context.Context, username string) (*entity.User, error) {
+// FindByName 根据用户名查找
+func (r *userRepository) FindByName(ctx context.Context, name string) (*entity.User, error) {
 	var user entity.User
-	err := r.db.WithContext(ctx).Where("username = ?", username).First(&user).Error
+	err := r.db.WithContext(ctx).Where("name = ? AND deleted_at IS NULL", name).First(&user).Error
 	if err == gorm.ErrRecordNotFound {
 		return nil, nil
 	}
@@ -51,7 +53,7 @@ func (r *userRepository) FindByUsername(ctx context.Context, username string) (*
 // FindByEmail 根据邮箱查找
 func (r *userRepository) FindByEmail(ctx context.Context, email string) (*entity.User, error) {
 	var user entity.User
-	err := r.db.WithContext(ctx).Where("email = ?", email).First(&user).Error
+	err := r.db.WithContext(ctx).Where("email = ? AND deleted_at IS NULL", email).First(&user).Error
 	if err == gorm.ErrRecordNotFound {
 		return nil, nil
 	}
@@ -61,20 +63,20 @@ func (r *userRepository) FindByEmail(ctx context.Context, email string) (*entity
 	return &user, nil
 }
 
-// ExistsByUsername 检查用户名是否存在
-func (r *userRepository) ExistsByUsername(ctx context.Context, username string) (bool, error) {
+// ExistsByName 检查用户名是否被未删除的用户使用
+func (r *userRepository) ExistsByName(ctx context.Context, name string) (bool, error) {
 	var count int64
 	err := r.db.WithContext(ctx).Model(&entity.User{}).
-		Where("username = ?", username).
+		Where("name = ? AND deleted_at IS NULL", name).
 		Count(&count).Error
 	return count > 0, err
 }
 
-// ExistsByEmail 检查邮箱是否存在
+// ExistsByEmail 检查邮箱是否被未删除的用户使用
 func (r *userRepository) ExistsByEmail(ctx context.Context, email string) (bool, error) {
 	var count int64
 	err := r.db.WithContext(ctx).Model(&entity.User{}).
-		Where("email = ?", email).
+		Where("email = ? AND deleted_at IS NULL", email).
 		Count(&count).Error
 	return count > 0, err
 }
@@ -93,6 +95,36 @@ func (r *userRepository) Update(ctx context.Context, user *entity.User) error {
 	return r.db.WithContext(ctx).Save(user).Error
 }
 
+// UpdateTwoFactor 只更新两步验证相关字段，避免覆盖并发修改的其他字段
+func (r *userRepository) UpdateTwoFactor(ctx context.Context, user *entity.User, recoveryCodeHashes []string) error {
+	user.UpdatedAt = time.Now()
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&entity.User{}).Where("id = ?", user.ID).Updates(map[string]interface{}{
+			"two_factor_enabled":   user.TwoFactorEnabled,
+			"two_factor_secret":    user.TwoFactorSecret,
+			"two_factor_last_step": user.TwoFactorLastStep,
+			"updated_at":           user.UpdatedAt,
+		}).Error; err != nil {
+			return err
+		}
+		if recoveryCodeHashes == nil {
+			return nil
+		}
+		return replaceRecoveryCodes(tx, user.ID, recoveryCodeHashes)
+	})
+}
+
+// AdvanceTwoFactorStep 通过条件更新记录时间步，同一时间步的验证码并发提交时只有一个成功
+func (r *userRepository) AdvanceTwoFactorStep(ctx context.Context, userID uint64, step int64) (bool, error) {
+	result := r.db.WithContext(ctx).Model(&entity.User{}).
+		Where("id = ? AND two_factor_last_step < ?", userID, step).
+		Update("two_factor_last_step", step)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
 // Delete 删除（软删除）
 func (r *userRepository) Delete(ctx context.Context, id uint64) error {
 	now := time.Now()
@@ -127,3 +159,140 @@ func (r *userRepository) List(ctx context.Context, page, pageSize int) ([]*entit
 
 	return users, total, nil
 }
+
+// userSortColumns 用户排序字段对应的列
+var userSortColumns = map[string]string{
+	"name":       "name",
+	"email":      "email",
+	"status":     "status",
+	"role":       "role",
+	"created_at": "created_at",
+	"updated_at": "updated_at",
+}
+
+// ListByFilter 按过滤条件分页查询用户
+func (r *userRepository) ListByFilter(ctx context.Context, filter domainRepo.UserFilter, page, pageSize int) ([]*entity.User, int64, error) {
+	query := r.db.WithContext(ctx).Model(&entity.User{}).Where("deleted_at IS NULL")
+	if filter.Status != 0 {
+		query = query.Where("status = ?", filter.Status)
+	}
+	if filter.Role != "" {
+		if permission.ParseRole("") == permission.Role(filter.Role) {
+			// 未设置角色的历史用户按默认角色处理
+			query = query.Where("(role = ? OR role = '' OR role IS NULL)", filter.Role)
+		} else {
+			query = query.Where("role = ?", filter.Role)
+		}
+	}
+	if filter.TeamID != 0 {
+		memberIDs := r.db.Model(&dao.TeamMemberPO{}).
+			Select("user_id").
+			Where("team_id = ?", filter.TeamID)
+		query = query.Where("id IN (?)", memberIDs)
+	}
+	query = applyKeyword(query, filter.Keyword, "name", "display_name", "email")
+	query = query.Session(&gorm.Session{})
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	var users []*entity.User
+	if err := applySort(query, filter.Sort, userSortColumns, createdAtDesc).
+		Offset((page - 1) * pageSize).
+		Limit(pageSize).
+		Find(&users).Error; err != nil {
+		return nil, 0, err
+	}
+	return users, total, nil
+}
+
+// ListDeleted 分页查询回收站中的用户，最近删除的在前
+func (r *userRepository) ListDeleted(ctx context.Context, page, pageSize int) ([]*entity.User, int64, error) {
+	query := r.db.WithContext(ctx).Model(&entity.User{}).Where("deleted_at IS NOT NULL")
+	query = query.Session(&gorm.Session{})
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	var users []*entity.User
+	if err := query.
+		Offset((page - 1) * pageSize).
+		Limit(pageSize).
+		Order("deleted_at DESC, id DESC").
+		Find(&users).Error; err != nil {
+		return nil, 0, err
+	}
+	return users, total, nil
+}
+
+// Restore 恢复已删除的用户
+func (r *userRepository) Restore(ctx context.Context, id uint64) error {
+	return r.db.WithContext(ctx).Model(&entity.User{}).
+		Where("id = ? AND deleted_at IS NOT NULL", id).
+		Update("deleted_at", nil).Error
+}
+
+// PurgeDeletedBefore 永久删除在 cutoff 之前删除的用户，连同访问令牌、一次性令牌、恢复码、外部身份和成员关系，分批提交
+// 同一事务内取消其任务指派、将其报告的任务转给项目负责人、清空其团队负责人身份，避免留下悬空引用
+// 仍是项目负责人（包括回收站中的项目）的用户跳过，避免项目失去负责人
+func (r *userRepository) PurgeDeletedBefore(ctx context.Context, cutoff time.Time) (int64, error) {
+	var purged int64
+	for {
+		var ids []uint64
+		owners := r.db.Unscoped().Model(&dao.ProjectPO{}).Select("owner_id")
+		if err := r.db.WithContext(ctx).Model(&entity.User{}).
+			Where("deleted_at IS NOT NULL AND deleted_at < ?", cutoff).
+			Where("id NOT IN (?)", owners).
+			Order("id").
+			Limit(purgeBatchSize).
+			Pluck("id", &ids).Error; err != nil {
+			return purged, err
+		}
+		if len(ids) == 0 {
+			return purged, nil
+		}
+		if err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+			children := []interface{}{
+				&dao.AccessTokenPO{},
+				&dao.OneTimeTokenPO{},
+				&dao.RecoveryCodePO{},
+				&dao.UserIdentityPO{},
+				&dao.TeamMemberPO{},
+				&dao.ProjectUserPO{},
+			}
+			for _, model := range children {
+				if err := tx.Unscoped().Where("user_id IN ?", ids).Delete(model).Error; err != nil {
+					return err
+				}
+			}
+			if err := tx.Unscoped().Model(&dao.TaskPO{}).
+				Where("assignee_id IN ?", ids).
+				UpdateColumn("assignee_id", 0).Error; err != nil {
+				return err
+			}
+			// 项目负责人不会被清理，任务的报告人转给所属项目的负责人
+			projectOwner := tx.Unscoped().Model(&dao.ProjectPO{}).
+				Select("owner_id").
+				Where("projects.id = tasks.project_id")
+			if err := tx.Unscoped().Model(&dao.TaskPO{}).
+				Where("reporter_id IN ?", ids).
+				UpdateColumn("reporter_id", projectOwner).Error; err != nil {
+				return err
+			}
+			if err := tx.Unscoped().Model(&dao.TeamPO{}).
+				Where("owner_id IN ?", ids).
+				UpdateColumn("owner_id", 0).Error; err != nil {
+				return err
+			}
+			return tx.Where("id IN ?", ids).Delete(&entity.User{}).Error
+		}); err != nil {
+			return purged, err
+		}
+		purged += int64(len(ids))
+		if len(ids) < purgeBatchSize {
+			return purged, nil
+		}
+	}
+}
@@ -0,0 +1,122 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"FLOWGO/internal/domain/entity"
+	"FLOWGO/internal/infrastructure/dao"
+)
+
+// TestUserPurgeDeletedBefore 永久删除过期用户及其凭据，清理任务和团队中的引用，跳过仍是项目负责人的用户
+func TestUserPurgeDeletedBefore(t *testing.T) {
+	db := newTestDB(t)
+	repo := NewUserRepository(db)
+	ctx := context.Background()
+	cutoff := time.Now().Add(-30 * 24 * time.Hour)
+	expiredAt := cutoff.Add(-time.Hour)
+
+	owner := createTestUser(t, db, "owner", time.Time{})
+	expired := createTestUser(t, db, "expired", expiredAt)
+	trashedOwner := createTestUser(t, db, "trashed-owner", expiredAt)
+	recent := createTestUser(t, db, "recent", time.Now())
+
+	project := createTestProject(t, db, owner.ID)
+	// 回收站中的项目同样需要负责人，其负责人不能被清理
+	trashedProject := createTestProject(t, db, trashedOwner.ID)
+	if err := db.Delete(trashedProject).Error; err != nil {
+		t.Fatalf("delete project: %v", err)
+	}
+
+	mustCreate(t, db, &dao.AccessTokenPO{UserId: expired.ID, Name: "ci", TokenHash: "hash"})
+	mustCreate(t, db, &dao.OneTimeTokenPO{UserId: expired.ID, Purpose: "password_reset", TokenHash: "hash", ExpiresAt: time.Now()})
+	mustCreate(t, db, &dao.RecoveryCodePO{UserId: expired.ID, CodeHash: "hash"})
+	mustCreate(t, db, &dao.UserIdentityPO{UserId: expired.ID, Provider: "idp", Subject: "sub"})
+	mustCreate(t, db, &dao.ProjectUserPO{ProjectId: project.ID, UserId: expired.ID, Role: "member"})
+	team := &dao.TeamPO{Name: "team", OwnerId: expired.ID}
+	mustCreate(t, db, team)
+	mustCreate(t, db, &dao.TeamMemberPO{TeamId: team.ID, UserId: expired.ID, Role: "lead"})
+	assigned := &dao.TaskPO{ProjectId: project.ID, Title: "assigned", AssigneeId: expired.ID, ReporterId: owner.ID, Status: "todo"}
+	reported := &dao.TaskPO{ProjectId: project.ID, Title: "reported", AssigneeId: recent.ID, ReporterId: expired.ID, Status: "todo"}
+	mustCreate(t, db, assigned)
+	mustCreate(t, db, reported)
+
+	purged, err := repo.PurgeDeletedBefore(ctx, cutoff)
+	if err != nil {
+		t.Fatalf("purge: %v", err)
+	}
+	if purged != 1 {
+		t.Fatalf("expected 1 purged user, got %d", purged)
+	}
+
+	for _, u := range []*entity.User{owner, trashedOwner, recent} {
+		if n := countUnscoped(t, db, &entity.User{}, "id = ?", u.ID); n != 1 {
+			t.Fatalf("user %s must be kept", u.Name)
+		}
+	}
+	if n := countUnscoped(t, db, &entity.User{}, "id = ?", expired.ID); n != 0 {
+		t.Fatalf("expired user must be purged")
+	}
+
+	children := []interface{}{
+		&dao.AccessTokenPO{},
+		&dao.OneTimeTokenPO{},
+		&dao.RecoveryCodePO{},
+		&dao.UserIdentityPO{},
+		&dao.TeamMemberPO{},
+		&dao.ProjectUserPO{},
+	}
+	for _, model := range children {
+		if n := countUnscoped(t, db, model, "user_id = ?", expired.ID); n != 0 {
+			t.Fatalf("%T of the purged user must be deleted, %d left", model, n)
+		}
+	}
+
+	var tasks []dao.TaskPO
+	if err := db.Order("id").Find(&tasks).Error; err != nil {
+		t.Fatalf("list tasks: %v", err)
+	}
+	if tasks[0].AssigneeId != 0 || tasks[0].ReporterId != owner.ID {
+		t.Fatalf("task assigned to the purged user must become unassigned, got %+v", tasks[0])
+	}
+	if tasks[1].ReporterId != owner.ID || tasks[1].AssigneeId != recent.ID {
+		t.Fatalf("task reported by the purged user must move to the project owner, got %+v", tasks[1])
+	}
+	var stored dao.TeamPO
+	if err := db.First(&stored, team.ID).Error; err != nil {
+		t.Fatalf("find team: %v", err)
+	}
+	if stored.OwnerId != 0 {
+		t.Fatalf("team owned by the purged user must have no owner, got %d", stored.OwnerId)
+	}
+}
+
+// TestUserPurgeBatches 超过一批的过期用户分多个事务全部删除
+func TestUserPurgeBatches(t *testing.T) {
+	db := newTestDB(t)
+	repo := NewUserRepository(db)
+	deletedAt := time.Now().Add(-48 * time.Hour)
+
+	total := purgeBatchSize*2 + 1
+	users := make([]*entity.User, total)
+	for i := range users {
+		users[i] = &entity.User{Name: fmt.Sprintf("user%d", i), Email: fmt.Sprintf("user%d@flowgo.test", i), Password: "x"}
+		users[i].DeletedAt = &deletedAt
+	}
+	if err := db.CreateInBatches(users, 100).Error; err != nil {
+		t.Fatalf("create users: %v", err)
+	}
+
+	purged, err := repo.PurgeDeletedBefore(context.Background(), time.Now().Add(-24*time.Hour))
+	if err != nil {
+		t.Fatalf("purge: %v", err)
+	}
+	if purged != int64(total) {
+		t.Fatalf("expected %d purged users, got %d", total, purged)
+	}
+	if n := countUnscoped(t, db, &entity.User{}, "1 = 1"); n != 0 {
+		t.Fatalf("all expired users must be purged, %d left", n)
+	}
+}
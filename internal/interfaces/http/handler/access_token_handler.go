@@ -0,0 +1,105 @@
+package handler
+
+import (
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"FLOWGO/internal/application/dto"
+	"FLOWGO/internal/application/service"
+	apperrors "FLOWGO/pkg/errors"
+)
+
+// AccessTokenHandler 个人访问令牌处理器
+type AccessTokenHandler struct {
+	BaseHandler
+	tokenService *service.AccessTokenService
+}
+
+// NewAccessTokenHandler 创建个人访问令牌处理器实例
+func NewAccessTokenHandler(tokenService *service.AccessTokenService) *AccessTokenHandler {
+	return &AccessTokenHandler{
+		tokenService: tokenService,
+	}
+}
+
+// CreateToken 创建个人访问令牌
+// @Summary 创建个人访问令牌
+// @Description 为当前用户创建个人访问令牌，明文令牌只在创建时返回一次
+// @Tags 个人访问令牌
+// @Accept json
+// @Produce json
+// @Param token body dto.CreateAccessTokenRequest true "令牌信息"
+// @Success 200 {object} dto.Response{data=dto.CreateAccessTokenResponse}
+// @Failure 400 {object} dto.Response
+// @Router /api/v1/users/me/tokens [post]
+func (h *AccessTokenHandler) CreateToken(c *gin.Context) {
+	var req dto.CreateAccessTokenRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.HandleBadRequest(c, err.Error())
+		return
+	}
+
+	result, err := h.tokenService.CreateToken(c.Request.Context(), req)
+	if err != nil {
+		if appErr, ok := err.(*apperrors.AppError); ok {
+			h.HandleError(c, appErr.Code, appErr.Message)
+		} else {
+			h.HandleInternalError(c, err.Error())
+		}
+		return
+	}
+
+	h.HandleSuccess(c, result)
+}
+
+// ListTokens 获取个人访问令牌列表
+// @Summary 获取个人访问令牌列表
+// @Description 获取当前用户的全部个人访问令牌，包含最后使用时间
+// @Tags 个人访问令牌
+// @Accept json
+// @Produce json
+// @Success 200 {object} dto.Response{data=dto.AccessTokenListResponse}
+// @Router /api/v1/users/me/tokens [get]
+func (h *AccessTokenHandler) ListTokens(c *gin.Context) {
+	result, err := h.tokenService.ListTokens(c.Request.Context())
+	if err != nil {
+		if appErr, ok := err.(*apperrors.AppError); ok {
+			h.HandleError(c, appErr.Code, appErr.Message)
+		} else {
+			h.HandleInternalError(c, err.Error())
+		}
+		return
+	}
+
+	h.HandleSuccess(c, result)
+}
+
+// RevokeToken 吊销个人访问令牌
+// @Summary 吊销个人访问令牌
+// @Description 吊销当前用户的个人访问令牌
+// @Tags 个人访问令牌
+// @Accept json
+// @Produce json
+// @Param id path int true "令牌ID"
+// @Success 200 {object} dto.Response
+// @Failure 404 {object} dto.Response
+// @Router /api/v1/users/me/tokens/{id} [delete]
+func (h *AccessTokenHandler) RevokeToken(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		h.HandleBadRequest(c, "无效的令牌ID")
+		return
+	}
+
+	if err := h.tokenService.RevokeToken(c.Request.Context(), id); err != nil {
+		if appErr, ok := err.(*apperrors.AppError); ok {
+			h.HandleError(c, appErr.Code, appErr.Message)
+		} else {
+			h.HandleInternalError(c, err.Error())
+		}
+		return
+	}
+
+	h.HandleSuccess(c, nil)
+}
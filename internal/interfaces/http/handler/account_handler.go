@@ -0,0 +1,129 @@
+package handler
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"FLOWGO/internal/application/dto"
+	"FLOWGO/internal/application/service"
+	apperrors "FLOWGO/pkg/errors"
+)
+
+// AccountHandler 账号处理器：找回密码、邮箱验证
+type AccountHandler struct {
+	BaseHandler
+	accountService *service.AccountService
+}
+
+// NewAccountHandler 创建账号处理器实例
+func NewAccountHandler(accountService *service.AccountService) *AccountHandler {
+	return &AccountHandler{
+		accountService: accountService,
+	}
+}
+
+// ForgotPassword 找回密码
+// @Summary 找回密码
+// @Description 向邮箱发送重置密码链接，邮箱不存在时同样返回成功
+// @Tags 认证
+// @Accept json
+// @Produce json
+// @Param body body dto.ForgotPasswordRequest true "邮箱"
+// @Success 200 {object} dto.Response
+// @Router /api/v1/auth/forgot-password [post]
+func (h *AccountHandler) ForgotPassword(c *gin.Context) {
+	var req dto.ForgotPasswordRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.HandleBadRequest(c, err.Error())
+		return
+	}
+
+	if err := h.accountService.ForgotPassword(c.Request.Context(), req); err != nil {
+		if appErr, ok := err.(*apperrors.AppError); ok {
+			h.HandleError(c, appErr.Code, appErr.Message)
+		} else {
+			h.HandleInternalError(c, err.Error())
+		}
+		return
+	}
+
+	h.HandleSuccess(c, nil)
+}
+
+// ResetPassword 重置密码
+// @Summary 重置密码
+// @Description 使用邮件中的一次性令牌重置密码，成功后该用户的全部登录会话失效
+// @Tags 认证
+// @Accept json
+// @Produce json
+// @Param body body dto.ResetPasswordRequest true "令牌和新密码"
+// @Success 200 {object} dto.Response
+// @Failure 400 {object} dto.Response
+// @Router /api/v1/auth/reset-password [post]
+func (h *AccountHandler) ResetPassword(c *gin.Context) {
+	var req dto.ResetPasswordRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.HandleBadRequest(c, err.Error())
+		return
+	}
+
+	if err := h.accountService.ResetPassword(c.Request.Context(), req); err != nil {
+		if appErr, ok := err.(*apperrors.AppError); ok {
+			h.HandleError(c, appErr.Code, appErr.Message)
+		} else {
+			h.HandleInternalError(c, err.Error())
+		}
+		return
+	}
+
+	h.HandleSuccess(c, nil)
+}
+
+// VerifyEmail 验证邮箱
+// @Summary 验证邮箱
+// @Description 使用邮件中的一次性令牌验证邮箱
+// @Tags 认证
+// @Accept json
+// @Produce json
+// @Param body body dto.VerifyEmailRequest true "令牌"
+// @Success 200 {object} dto.Response
+// @Failure 400 {object} dto.Response
+// @Router /api/v1/auth/verify-email [post]
+func (h *AccountHandler) VerifyEmail(c *gin.Context) {
+	var req dto.VerifyEmailRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.HandleBadRequest(c, err.Error())
+		return
+	}
+
+	if err := h.accountService.VerifyEmail(c.Request.Context(), req); err != nil {
+		if appErr, ok := err.(*apperrors.AppError); ok {
+			h.HandleError(c, appErr.Code, appErr.Message)
+		} else {
+			h.HandleInternalError(c, err.Error())
+		}
+		return
+	}
+
+	h.HandleSuccess(c, nil)
+}
+
+// SendEmailVerification 发送邮箱验证邮件
+// @Summary 发送邮箱验证邮件
+// @Description 向当前用户的邮箱发送验证链接
+// @Tags 认证
+// @Produce json
+// @Success 200 {object} dto.Response
+// @Failure 400 {object} dto.Response
+// @Router /api/v1/auth/verify-email/send [post]
+func (h *AccountHandler) SendEmailVerification(c *gin.Context) {
+	if err := h.accountService.SendEmailVerification(c.Request.Context()); err != nil {
+		if appErr, ok := err.(*apperrors.AppError); ok {
+			h.HandleError(c, appErr.Code, appErr.Message)
+		} else {
+			h.HandleInternalError(c, err.Error())
+		}
+		return
+	}
+
+	h.HandleSuccess(c, nil)
+}
@@ -1,6 +1,9 @@
 package handler
 
 import (
+	"math"
+	"strconv"
+
 	"github.com/gin-gonic/gin"
 
 	"FLOWGO/internal/application/dto"
@@ -30,6 +33,8 @@ func NewAuthHandler(authService *service.AuthService) *AuthHandler {
 // @Param login body dto.LoginRequest true "登录信息"
 // @Success 200 {object} dto.Response{data=dto.LoginResponse}
 // @Failure 401 {object} dto.Response
+// @Failure 423 {object} dto.Response "账号已被临时锁定"
+// @Failure 429 {object} dto.Response "登录尝试过于频繁"
 // @Router /api/v1/auth/login [post]
 func (h *AuthHandler) Login(c *gin.Context) {
 	var req dto.LoginRequest
@@ -38,9 +43,10 @@ func (h *AuthHandler) Login(c *gin.Context) {
 		return
 	}
 
-	result, err := h.authService.Login(c.Request.Context(), req)
+	result, err := h.authService.Login(c.Request.Context(), req, c.ClientIP())
 	if err != nil {
 		if appErr, ok := err.(*apperrors.AppError); ok {
+			setRetryAfter(c, appErr)
 			h.HandleError(c, appErr.Code, appErr.Message)
 		} else {
 			h.HandleInternalError(c, err.Error())
@@ -50,3 +56,104 @@ func (h *AuthHandler) Login(c *gin.Context) {
 
 	h.HandleSuccess(c, result)
 }
+
+// LoginTwoFactor 两步验证登录
+// @Summary 两步验证登录
+// @Description 使用登录返回的挑战Token和TOTP验证码（或恢复码）换取JWT Token
+// @Tags 认证
+// @Accept json
+// @Produce json
+// @Param body body dto.TwoFactorLoginRequest true "挑战Token和验证码"
+// @Success 200 {object} dto.Response{data=dto.LoginResponse}
+// @Failure 401 {object} dto.Response
+// @Failure 423 {object} dto.Response "账号已被临时锁定"
+// @Failure 429 {object} dto.Response "登录尝试过于频繁"
+// @Router /api/v1/auth/2fa [post]
+func (h *AuthHandler) LoginTwoFactor(c *gin.Context) {
+	var req dto.TwoFactorLoginRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.HandleBadRequest(c, err.Error())
+		return
+	}
+
+	result, err := h.authService.LoginTwoFactor(c.Request.Context(), req, c.ClientIP())
+	if err != nil {
+		if appErr, ok := err.(*apperrors.AppError); ok {
+			setRetryAfter(c, appErr)
+			h.HandleError(c, appErr.Code, appErr.Message)
+		} else {
+			h.HandleInternalError(c, err.Error())
+		}
+		return
+	}
+
+	h.HandleSuccess(c, result)
+}
+
+// Refresh 刷新Token
+// @Summary 刷新Token
+// @Description 使用刷新Token换取新的访问Token和刷新Token，旧的刷新Token随即失效
+// @Tags 认证
+// @Accept json
+// @Produce json
+// @Param refresh body dto.RefreshTokenRequest true "刷新Token"
+// @Success 200 {object} dto.Response{data=dto.LoginResponse}
+// @Failure 401 {object} dto.Response
+// @Router /api/v1/auth/refresh [post]
+func (h *AuthHandler) Refresh(c *gin.Context) {
+	var req dto.RefreshTokenRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.HandleBadRequest(c, err.Error())
+		return
+	}
+
+	result, err := h.authService.Refresh(c.Request.Context(), req)
+	if err != nil {
+		if appErr, ok := err.(*apperrors.AppError); ok {
+			h.HandleError(c, appErr.Code, appErr.Message)
+		} else {
+			h.HandleInternalError(c, err.Error())
+		}
+		return
+	}
+
+	h.HandleSuccess(c, result)
+}
+
+// Logout 退出登录
+// @Summary 退出登录
+// @Description 吊销当前访问Token，可同时吊销刷新Token
+// @Tags 认证
+// @Accept json
+// @Produce json
+// @Param logout body dto.LogoutRequest false "刷新Token"
+// @Success 200 {object} dto.Response
+// @Failure 401 {object} dto.Response
+// @Router /api/v1/auth/logout [post]
+func (h *AuthHandler) Logout(c *gin.Context) {
+	var req dto.LogoutRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			h.HandleBadRequest(c, err.Error())
+			return
+		}
+	}
+
+	if err := h.authService.Logout(c.Request.Context(), req); err != nil {
+		if appErr, ok := err.(*apperrors.AppError); ok {
+			h.HandleError(c, appErr.Code, appErr.Message)
+		} else {
+			h.HandleInternalError(c, err.Error())
+		}
+		return
+	}
+
+	h.HandleSuccess(c, nil)
+}
+
+// setRetryAfter 限流或锁定时写入 Retry-After 响应头（秒，向上取整）
+func setRetryAfter(c *gin.Context, appErr *apperrors.AppError) {
+	if appErr.RetryAfter > 0 {
+		c.Header("Retry-After", strconv.Itoa(int(math.Ceil(appErr.RetryAfter.Seconds()))))
+	}
+}
@@ -0,0 +1,221 @@
+package handler
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"FLOWGO/internal/application/dto"
+	"FLOWGO/internal/application/service"
+	apperrors "FLOWGO/pkg/errors"
+)
+
+// BoardHandler 看板处理器
+type BoardHandler struct {
+	BaseHandler
+	boardService *service.BoardService
+}
+
+// NewBoardHandler 创建看板处理器实例
+func NewBoardHandler(boardService *service.BoardService) *BoardHandler {
+	return &BoardHandler{
+		boardService: boardService,
+	}
+}
+
+// CreateBoard 创建看板
+// @Summary 创建看板
+// @Description 为项目创建看板，每一列对应一个任务状态，可设置在制品上限；不传列时使用默认列；需要项目 maintainer 及以上角色
+// @Tags 看板
+// @Accept json
+// @Produce json
+// @Param id path int true "项目ID"
+// @Param board body dto.CreateBoardRequest true "看板信息"
+// @Success 200 {object} dto.Response{data=dto.BoardResponse}
+// @Failure 400 {object} dto.Response
+// @Router /api/v1/projects/{id}/boards [post]
+func (h *BoardHandler) CreateBoard(c *gin.Context) {
+	var uri dto.BoardURI
+	if err := c.ShouldBindUri(&uri); err != nil {
+		h.HandleBadRequest(c, err.Error())
+		return
+	}
+	var req dto.CreateBoardRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.HandleBadRequest(c, err.Error())
+		return
+	}
+
+	board, err := h.boardService.CreateBoard(c.Request.Context(), uri.ProjectID, req)
+	if err != nil {
+		if appErr, ok := err.(*apperrors.AppError); ok {
+			h.HandleError(c, appErr.Code, appErr.Message)
+		} else {
+			h.HandleInternalError(c, err.Error())
+		}
+		return
+	}
+
+	h.HandleSuccess(c, board)
+}
+
+// ListBoards 获取看板列表
+// @Summary 获取看板列表
+// @Description 获取项目的全部看板及其列，不含卡片
+// @Tags 看板
+// @Produce json
+// @Param id path int true "项目ID"
+// @Success 200 {object} dto.Response{data=[]dto.BoardResponse}
+// @Router /api/v1/projects/{id}/boards [get]
+func (h *BoardHandler) ListBoards(c *gin.Context) {
+	var uri dto.BoardURI
+	if err := c.ShouldBindUri(&uri); err != nil {
+		h.HandleBadRequest(c, err.Error())
+		return
+	}
+
+	boards, err := h.boardService.ListBoards(c.Request.Context(), uri.ProjectID)
+	if err != nil {
+		if appErr, ok := err.(*apperrors.AppError); ok {
+			h.HandleError(c, appErr.Code, appErr.Message)
+		} else {
+			h.HandleInternalError(c, err.Error())
+		}
+		return
+	}
+
+	h.HandleSuccess(c, boards)
+}
+
+// GetBoard 获取看板
+// @Summary 获取看板
+// @Description 获取看板详情，每一列包含按顺序排列的卡片
+// @Tags 看板
+// @Produce json
+// @Param id path int true "项目ID"
+// @Param bid path int true "看板ID"
+// @Success 200 {object} dto.Response{data=dto.BoardDetailResponse}
+// @Failure 404 {object} dto.Response
+// @Router /api/v1/projects/{id}/boards/{bid} [get]
+func (h *BoardHandler) GetBoard(c *gin.Context) {
+	var uri dto.BoardURI
+	if err := c.ShouldBindUri(&uri); err != nil {
+		h.HandleBadRequest(c, err.Error())
+		return
+	}
+
+	board, err := h.boardService.GetBoard(c.Request.Context(), uri.ProjectID, uri.BoardID)
+	if err != nil {
+		if appErr, ok := err.(*apperrors.AppError); ok {
+			h.HandleError(c, appErr.Code, appErr.Message)
+		} else {
+			h.HandleInternalError(c, err.Error())
+		}
+		return
+	}
+
+	h.HandleSuccess(c, board)
+}
+
+// UpdateBoard 更新看板
+// @Summary 更新看板
+// @Description 修改看板名称和列，列的顺序以请求为准，未出现的已有列会被删除；需要项目 maintainer 及以上角色
+// @Tags 看板
+// @Accept json
+// @Produce json
+// @Param id path int true "项目ID"
+// @Param bid path int true "看板ID"
+// @Param board body dto.UpdateBoardRequest true "看板信息"
+// @Success 200 {object} dto.Response{data=dto.BoardResponse}
+// @Failure 400 {object} dto.Response
+// @Failure 404 {object} dto.Response
+// @Router /api/v1/projects/{id}/boards/{bid} [put]
+func (h *BoardHandler) UpdateBoard(c *gin.Context) {
+	var uri dto.BoardURI
+	if err := c.ShouldBindUri(&uri); err != nil {
+		h.HandleBadRequest(c, err.Error())
+		return
+	}
+	var req dto.UpdateBoardRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.HandleBadRequest(c, err.Error())
+		return
+	}
+
+	board, err := h.boardService.UpdateBoard(c.Request.Context(), uri.ProjectID, uri.BoardID, req)
+	if err != nil {
+		if appErr, ok := err.(*apperrors.AppError); ok {
+			h.HandleError(c, appErr.Code, appErr.Message)
+		} else {
+			h.HandleInternalError(c, err.Error())
+		}
+		return
+	}
+
+	h.HandleSuccess(c, board)
+}
+
+// DeleteBoard 删除看板
+// @Summary 删除看板
+// @Description 删除看板，看板中的任务不受影响；需要项目 maintainer 及以上角色
+// @Tags 看板
+// @Produce json
+// @Param id path int true "项目ID"
+// @Param bid path int true "看板ID"
+// @Success 200 {object} dto.Response
+// @Failure 404 {object} dto.Response
+// @Router /api/v1/projects/{id}/boards/{bid} [delete]
+func (h *BoardHandler) DeleteBoard(c *gin.Context) {
+	var uri dto.BoardURI
+	if err := c.ShouldBindUri(&uri); err != nil {
+		h.HandleBadRequest(c, err.Error())
+		return
+	}
+
+	if err := h.boardService.DeleteBoard(c.Request.Context(), uri.ProjectID, uri.BoardID); err != nil {
+		if appErr, ok := err.(*apperrors.AppError); ok {
+			h.HandleError(c, appErr.Code, appErr.Message)
+		} else {
+			h.HandleInternalError(c, err.Error())
+		}
+		return
+	}
+
+	h.HandleSuccess(c, nil)
+}
+
+// MoveCard 移动卡片
+// @Summary 移动卡片
+// @Description 将卡片移动到指定列中某张卡片之后，任务状态变为该列对应的状态；目标列达到在制品上限时返回 409
+// @Tags 看板
+// @Accept json
+// @Produce json
+// @Param id path int true "项目ID"
+// @Param bid path int true "看板ID"
+// @Param body body dto.MoveCardRequest true "目标位置"
+// @Success 200 {object} dto.Response{data=dto.TaskResponse}
+// @Failure 400 {object} dto.Response
+// @Failure 409 {object} dto.Response "该列已达到在制品上限"
+// @Router /api/v1/projects/{id}/boards/{bid}/move [post]
+func (h *BoardHandler) MoveCard(c *gin.Context) {
+	var uri dto.BoardURI
+	if err := c.ShouldBindUri(&uri); err != nil {
+		h.HandleBadRequest(c, err.Error())
+		return
+	}
+	var req dto.MoveCardRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.HandleBadRequest(c, err.Error())
+		return
+	}
+
+	task, err := h.boardService.MoveCard(c.Request.Context(), uri.ProjectID, uri.BoardID, req)
+	if err != nil {
+		if appErr, ok := err.(*apperrors.AppError); ok {
+			h.HandleError(c, appErr.Code, appErr.Message)
+		} else {
+			h.HandleInternalError(c, err.Error())
+		}
+		return
+	}
+
+	h.HandleSuccess(c, task)
+}
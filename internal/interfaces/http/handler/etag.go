@@ -0,0 +1,38 @@
+package handler
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	apperrors "FLOWGO/pkg/errors"
+)
+
+// setETag 把资源的版本号写入 ETag 响应头
+func setETag(c *gin.Context, version uint64) {
+	c.Header("ETag", strconv.Quote(strconv.FormatUint(version, 10)))
+}
+
+// ifMatchVersion 从 If-Match 请求头解析客户端读取时的版本号
+// "*" 只要求资源存在，返回 0，写入仍以服务端读取到的版本为条件
+// 未携带时 required 为 true 返回 428，否则同样返回 0；无法解析（包括弱校验的 W/ 标签）时不可能与当前版本一致，返回 412
+func ifMatchVersion(c *gin.Context, required bool) (uint64, error) {
+	value := strings.TrimSpace(c.GetHeader("If-Match"))
+	if value == "" {
+		if !required {
+			return 0, nil
+		}
+		return 0, apperrors.NewAppError(428, "缺少 If-Match 请求头，请先获取项目的 ETag", nil)
+	}
+	if value == "*" {
+		return 0, nil
+	}
+	tag, err := strconv.Unquote(value)
+	if err == nil {
+		if version, err := strconv.ParseUint(tag, 10, 64); err == nil && version > 0 {
+			return version, nil
+		}
+	}
+	return 0, apperrors.NewAppError(412, "项目已被其他人修改，请刷新后重试", nil)
+}
@@ -0,0 +1,205 @@
+package handler
+
+import (
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"FLOWGO/internal/application/dto"
+	"FLOWGO/internal/application/service"
+	apperrors "FLOWGO/pkg/errors"
+)
+
+// InvitationHandler 邀请处理器
+type InvitationHandler struct {
+	BaseHandler
+	invitationService *service.InvitationService
+}
+
+// NewInvitationHandler 创建邀请处理器实例
+func NewInvitationHandler(invitationService *service.InvitationService) *InvitationHandler {
+	return &InvitationHandler{
+		invitationService: invitationService,
+	}
+}
+
+// CreateInvitation 邀请用户
+// @Summary 邀请用户
+// @Description 向邮箱发送邀请链接，可同时指定加入的团队和项目；管理员可邀请任意角色，项目负责人只能邀请成员加入自己的项目
+// @Tags 邀请
+// @Accept json
+// @Produce json
+// @Param body body dto.CreateInvitationRequest true "邀请信息"
+// @Success 200 {object} dto.Response{data=dto.InvitationResponse}
+// @Failure 400 {object} dto.Response
+// @Failure 409 {object} dto.Response "该邮箱已有待接受的邀请"
+// @Router /api/v1/invitations [post]
+func (h *InvitationHandler) CreateInvitation(c *gin.Context) {
+	var req dto.CreateInvitationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.HandleBadRequest(c, err.Error())
+		return
+	}
+
+	invitation, err := h.invitationService.CreateInvitation(c.Request.Context(), req)
+	if err != nil {
+		if appErr, ok := err.(*apperrors.AppError); ok {
+			h.HandleError(c, appErr.Code, appErr.Message)
+		} else {
+			h.HandleInternalError(c, err.Error())
+		}
+		return
+	}
+
+	h.HandleSuccess(c, invitation)
+}
+
+// ListInvitations 获取邀请列表
+// @Summary 获取邀请列表
+// @Description 分页获取邀请列表，可按状态过滤
+// @Tags 邀请
+// @Produce json
+// @Param page query int false "页码" default(1)
+// @Param page_size query int false "每页数量" default(10)
+// @Param status query string false "状态" Enums(pending, accepted, revoked, expired)
+// @Success 200 {object} dto.Response{data=dto.InvitationListResponse}
+// @Router /api/v1/invitations [get]
+func (h *InvitationHandler) ListInvitations(c *gin.Context) {
+	var req dto.InvitationListRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		h.HandleBadRequest(c, err.Error())
+		return
+	}
+
+	result, err := h.invitationService.ListInvitations(c.Request.Context(), req)
+	if err != nil {
+		if appErr, ok := err.(*apperrors.AppError); ok {
+			h.HandleError(c, appErr.Code, appErr.Message)
+		} else {
+			h.HandleInternalError(c, err.Error())
+		}
+		return
+	}
+
+	h.HandleSuccessWithPage(c, result.List, result.Page.Page, result.Page.PageSize, result.Page.Total)
+}
+
+// ResendInvitation 重新发送邀请
+// @Summary 重新发送邀请
+// @Description 生成新的邀请链接并重新发送邮件，旧链接失效
+// @Tags 邀请
+// @Produce json
+// @Param id path int true "邀请ID"
+// @Success 200 {object} dto.Response{data=dto.InvitationResponse}
+// @Failure 400 {object} dto.Response
+// @Failure 404 {object} dto.Response
+// @Router /api/v1/invitations/{id}/resend [post]
+func (h *InvitationHandler) ResendInvitation(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		h.HandleBadRequest(c, "无效的邀请ID")
+		return
+	}
+
+	invitation, err := h.invitationService.ResendInvitation(c.Request.Context(), id)
+	if err != nil {
+		if appErr, ok := err.(*apperrors.AppError); ok {
+			h.HandleError(c, appErr.Code, appErr.Message)
+		} else {
+			h.HandleInternalError(c, err.Error())
+		}
+		return
+	}
+
+	h.HandleSuccess(c, invitation)
+}
+
+// RevokeInvitation 撤销邀请
+// @Summary 撤销邀请
+// @Description 撤销尚未接受的邀请，邀请链接立即失效
+// @Tags 邀请
+// @Produce json
+// @Param id path int true "邀请ID"
+// @Success 200 {object} dto.Response{data=dto.InvitationResponse}
+// @Failure 400 {object} dto.Response
+// @Failure 404 {object} dto.Response
+// @Router /api/v1/invitations/{id}/revoke [post]
+func (h *InvitationHandler) RevokeInvitation(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		h.HandleBadRequest(c, "无效的邀请ID")
+		return
+	}
+
+	invitation, err := h.invitationService.RevokeInvitation(c.Request.Context(), id)
+	if err != nil {
+		if appErr, ok := err.(*apperrors.AppError); ok {
+			h.HandleError(c, appErr.Code, appErr.Message)
+		} else {
+			h.HandleInternalError(c, err.Error())
+		}
+		return
+	}
+
+	h.HandleSuccess(c, invitation)
+}
+
+// PreviewInvitation 查看邀请
+// @Summary 查看邀请
+// @Description 根据邀请链接中的令牌获取邀请信息，供接受页面展示
+// @Tags 认证
+// @Produce json
+// @Param token query string true "邀请令牌"
+// @Success 200 {object} dto.Response{data=dto.InvitationPreviewResponse}
+// @Failure 400 {object} dto.Response
+// @Router /api/v1/auth/invitations/preview [get]
+func (h *InvitationHandler) PreviewInvitation(c *gin.Context) {
+	token := c.Query("token")
+	if token == "" {
+		h.HandleBadRequest(c, "缺少邀请令牌")
+		return
+	}
+
+	preview, err := h.invitationService.PreviewInvitation(c.Request.Context(), token)
+	if err != nil {
+		if appErr, ok := err.(*apperrors.AppError); ok {
+			h.HandleError(c, appErr.Code, appErr.Message)
+		} else {
+			h.HandleInternalError(c, err.Error())
+		}
+		return
+	}
+
+	h.HandleSuccess(c, preview)
+}
+
+// AcceptInvitation 接受邀请
+// @Summary 接受邀请
+// @Description 设置用户名和密码完成注册，加入邀请中的团队和项目，并返回登录Token
+// @Tags 认证
+// @Accept json
+// @Produce json
+// @Param body body dto.AcceptInvitationRequest true "令牌、用户名和密码"
+// @Success 200 {object} dto.Response{data=dto.LoginResponse}
+// @Failure 400 {object} dto.Response
+// @Failure 409 {object} dto.Response "该邮箱已注册"
+// @Router /api/v1/auth/invitations/accept [post]
+func (h *InvitationHandler) AcceptInvitation(c *gin.Context) {
+	var req dto.AcceptInvitationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.HandleBadRequest(c, err.Error())
+		return
+	}
+
+	result, err := h.invitationService.AcceptInvitation(c.Request.Context(), req)
+	if err != nil {
+		if appErr, ok := err.(*apperrors.AppError); ok {
+			h.HandleError(c, appErr.Code, appErr.Message)
+		} else {
+			h.HandleInternalError(c, err.Error())
+		}
+		return
+	}
+
+	h.HandleSuccess(c, result)
+}
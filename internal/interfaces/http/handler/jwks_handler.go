@@ -0,0 +1,30 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"FLOWGO/pkg/jwt"
+)
+
+// JWKSHandler 公钥集合处理器
+type JWKSHandler struct{}
+
+// NewJWKSHandler 创建公钥集合处理器实例
+func NewJWKSHandler() *JWKSHandler {
+	return &JWKSHandler{}
+}
+
+// GetJWKS 获取用于验证FlowGo Token的公钥集合
+// @Summary 获取JWKS
+// @Description 返回当前密钥环中的全部非对称公钥，供其他服务验证FlowGo签发的Token
+// @Tags 认证
+// @Produce json
+// @Success 200 {object} jwt.JWKS
+// @Router /.well-known/jwks.json [get]
+func (h *JWKSHandler) GetJWKS(c *gin.Context) {
+	// 按 RFC 7517 直接返回 JWK Set，不包裹统一响应结构
+	c.Header("Cache-Control", "public, max-age=300")
+	c.JSON(http.StatusOK, jwt.GetJWKS())
+}
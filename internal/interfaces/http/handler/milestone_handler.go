@@ -0,0 +1,218 @@
+package handler
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"FLOWGO/internal/application/dto"
+	"FLOWGO/internal/application/service"
+	apperrors "FLOWGO/pkg/errors"
+)
+
+// MilestoneHandler 里程碑处理器
+type MilestoneHandler struct {
+	BaseHandler
+	milestoneService *service.MilestoneService
+}
+
+// NewMilestoneHandler 创建里程碑处理器实例
+func NewMilestoneHandler(milestoneService *service.MilestoneService) *MilestoneHandler {
+	return &MilestoneHandler{
+		milestoneService: milestoneService,
+	}
+}
+
+// CreateMilestone 创建里程碑
+// @Summary 创建里程碑
+// @Description 在项目中创建里程碑，排在现有里程碑之后；需要项目 maintainer 及以上角色
+// @Tags 里程碑
+// @Accept json
+// @Produce json
+// @Param id path int true "项目ID"
+// @Param milestone body dto.CreateMilestoneRequest true "里程碑信息"
+// @Success 200 {object} dto.Response{data=dto.MilestoneResponse}
+// @Failure 400 {object} dto.Response
+// @Router /api/v1/projects/{id}/milestones [post]
+func (h *MilestoneHandler) CreateMilestone(c *gin.Context) {
+	var uri dto.MilestoneURI
+	if err := c.ShouldBindUri(&uri); err != nil {
+		h.HandleBadRequest(c, err.Error())
+		return
+	}
+	var req dto.CreateMilestoneRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.HandleBadRequest(c, err.Error())
+		return
+	}
+
+	milestone, err := h.milestoneService.CreateMilestone(c.Request.Context(), uri.ProjectID, req)
+	if err != nil {
+		if appErr, ok := err.(*apperrors.AppError); ok {
+			h.HandleError(c, appErr.Code, appErr.Message)
+		} else {
+			h.HandleInternalError(c, err.Error())
+		}
+		return
+	}
+
+	h.HandleSuccess(c, milestone)
+}
+
+// ListMilestones 获取里程碑列表
+// @Summary 获取里程碑列表
+// @Description 获取项目的全部里程碑，按顺序排列；目标日期已过但仍未关闭的里程碑标记为逾期
+// @Tags 里程碑
+// @Produce json
+// @Param id path int true "项目ID"
+// @Success 200 {object} dto.Response{data=[]dto.MilestoneResponse}
+// @Router /api/v1/projects/{id}/milestones [get]
+func (h *MilestoneHandler) ListMilestones(c *gin.Context) {
+	var uri dto.MilestoneURI
+	if err := c.ShouldBindUri(&uri); err != nil {
+		h.HandleBadRequest(c, err.Error())
+		return
+	}
+
+	milestones, err := h.milestoneService.ListMilestones(c.Request.Context(), uri.ProjectID)
+	if err != nil {
+		if appErr, ok := err.(*apperrors.AppError); ok {
+			h.HandleError(c, appErr.Code, appErr.Message)
+		} else {
+			h.HandleInternalError(c, err.Error())
+		}
+		return
+	}
+
+	h.HandleSuccess(c, milestones)
+}
+
+// ReorderMilestones 调整里程碑顺序
+// @Summary 调整里程碑顺序
+// @Description 按传入的顺序排列里程碑，需要包含项目的全部里程碑；需要项目 maintainer 及以上角色
+// @Tags 里程碑
+// @Accept json
+// @Produce json
+// @Param id path int true "项目ID"
+// @Param order body dto.ReorderMilestonesRequest true "里程碑ID列表"
+// @Success 200 {object} dto.Response{data=[]dto.MilestoneResponse}
+// @Failure 400 {object} dto.Response
+// @Router /api/v1/projects/{id}/milestones/order [put]
+func (h *MilestoneHandler) ReorderMilestones(c *gin.Context) {
+	var uri dto.MilestoneURI
+	if err := c.ShouldBindUri(&uri); err != nil {
+		h.HandleBadRequest(c, err.Error())
+		return
+	}
+	var req dto.ReorderMilestonesRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.HandleBadRequest(c, err.Error())
+		return
+	}
+
+	milestones, err := h.milestoneService.ReorderMilestones(c.Request.Context(), uri.ProjectID, req)
+	if err != nil {
+		if appErr, ok := err.(*apperrors.AppError); ok {
+			h.HandleError(c, appErr.Code, appErr.Message)
+		} else {
+			h.HandleInternalError(c, err.Error())
+		}
+		return
+	}
+
+	h.HandleSuccess(c, milestones)
+}
+
+// GetMilestone 获取里程碑
+// @Summary 获取里程碑
+// @Description 获取项目内的里程碑详情
+// @Tags 里程碑
+// @Produce json
+// @Param id path int true "项目ID"
+// @Param mid path int true "里程碑ID"
+// @Success 200 {object} dto.Response{data=dto.MilestoneResponse}
+// @Failure 404 {object} dto.Response
+// @Router /api/v1/projects/{id}/milestones/{mid} [get]
+func (h *MilestoneHandler) GetMilestone(c *gin.Context) {
+	var uri dto.MilestoneURI
+	if err := c.ShouldBindUri(&uri); err != nil {
+		h.HandleBadRequest(c, err.Error())
+		return
+	}
+
+	milestone, err := h.milestoneService.GetMilestone(c.Request.Context(), uri.ProjectID, uri.MilestoneID)
+	if err != nil {
+		if appErr, ok := err.(*apperrors.AppError); ok {
+			h.HandleError(c, appErr.Code, appErr.Message)
+		} else {
+			h.HandleInternalError(c, err.Error())
+		}
+		return
+	}
+
+	h.HandleSuccess(c, milestone)
+}
+
+// UpdateMilestone 更新里程碑
+// @Summary 更新里程碑
+// @Description 更新里程碑的名称、描述、目标日期和状态；需要项目 maintainer 及以上角色
+// @Tags 里程碑
+// @Accept json
+// @Produce json
+// @Param id path int true "项目ID"
+// @Param mid path int true "里程碑ID"
+// @Param milestone body dto.UpdateMilestoneRequest true "里程碑信息"
+// @Success 200 {object} dto.Response{data=dto.MilestoneResponse}
+// @Failure 404 {object} dto.Response
+// @Router /api/v1/projects/{id}/milestones/{mid} [put]
+func (h *MilestoneHandler) UpdateMilestone(c *gin.Context) {
+	var uri dto.MilestoneURI
+	if err := c.ShouldBindUri(&uri); err != nil {
+		h.HandleBadRequest(c, err.Error())
+		return
+	}
+	var req dto.UpdateMilestoneRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.HandleBadRequest(c, err.Error())
+		return
+	}
+
+	milestone, err := h.milestoneService.UpdateMilestone(c.Request.Context(), uri.ProjectID, uri.MilestoneID, req)
+	if err != nil {
+		if appErr, ok := err.(*apperrors.AppError); ok {
+			h.HandleError(c, appErr.Code, appErr.Message)
+		} else {
+			h.HandleInternalError(c, err.Error())
+		}
+		return
+	}
+
+	h.HandleSuccess(c, milestone)
+}
+
+// DeleteMilestone 删除里程碑
+// @Summary 删除里程碑
+// @Description 删除项目内的里程碑；需要项目 maintainer 及以上角色
+// @Tags 里程碑
+// @Produce json
+// @Param id path int true "项目ID"
+// @Param mid path int true "里程碑ID"
+// @Success 200 {object} dto.Response
+// @Failure 404 {object} dto.Response
+// @Router /api/v1/projects/{id}/milestones/{mid} [delete]
+func (h *MilestoneHandler) DeleteMilestone(c *gin.Context) {
+	var uri dto.MilestoneURI
+	if err := c.ShouldBindUri(&uri); err != nil {
+		h.HandleBadRequest(c, err.Error())
+		return
+	}
+
+	if err := h.milestoneService.DeleteMilestone(c.Request.Context(), uri.ProjectID, uri.MilestoneID); err != nil {
+		if appErr, ok := err.(*apperrors.AppError); ok {
+			h.HandleError(c, appErr.Code, appErr.Message)
+		} else {
+			h.HandleInternalError(c, err.Error())
+		}
+		return
+	}
+
+	h.HandleSuccess(c, nil)
+}
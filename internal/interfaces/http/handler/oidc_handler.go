@@ -0,0 +1,141 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"FLOWGO/internal/application/dto"
+	"FLOWGO/internal/application/service"
+	apperrors "FLOWGO/pkg/errors"
+)
+
+const (
+	// oidcStateCookie 保存发起登录时的 state，回调时与参数比对
+	oidcStateCookie = "flowgo_oidc_state"
+	oidcStatePath   = "/api/v1/auth/oidc/"
+	oidcStateMaxAge = 10 * 60
+)
+
+// OIDCHandler 单点登录处理器
+type OIDCHandler struct {
+	BaseHandler
+	oidcService *service.OIDCService
+}
+
+// NewOIDCHandler 创建单点登录处理器实例
+func NewOIDCHandler(oidcService *service.OIDCService) *OIDCHandler {
+	return &OIDCHandler{
+		oidcService: oidcService,
+	}
+}
+
+// ListProviders 获取单点登录提供方
+// @Summary 获取单点登录提供方
+// @Description 获取已启用的 OpenID Connect 身份提供方，用于登录页展示
+// @Tags 认证
+// @Produce json
+// @Success 200 {object} dto.Response{data=[]dto.OIDCProviderResponse}
+// @Router /api/v1/auth/oidc/providers [get]
+func (h *OIDCHandler) ListProviders(c *gin.Context) {
+	h.HandleSuccess(c, h.oidcService.ListProviders())
+}
+
+// Login 跳转到身份提供方
+// @Summary 单点登录
+// @Description 重定向到身份提供方的授权页面（授权码流程，PKCE），同时把 state 写入 Cookie
+// @Tags 认证
+// @Param provider path string true "提供方名称"
+// @Success 302
+// @Failure 404 {object} dto.Response
+// @Router /api/v1/auth/oidc/{provider}/login [get]
+func (h *OIDCHandler) Login(c *gin.Context) {
+	authURL, state, err := h.oidcService.AuthorizationURL(c.Request.Context(), c.Param("provider"))
+	if err != nil {
+		if appErr, ok := err.(*apperrors.AppError); ok {
+			h.HandleError(c, appErr.Code, appErr.Message)
+		} else {
+			h.HandleInternalError(c, err.Error())
+		}
+		return
+	}
+
+	setOIDCStateCookie(c, state, oidcStateMaxAge)
+	c.Redirect(http.StatusFound, authURL)
+}
+
+// Callback 身份提供方回调
+// @Summary 单点登录回调
+// @Description 校验 state 与 Cookie 一致和授权结果，首次登录的用户会自动创建
+// @Description 成功时重定向到前端地址并在 fragment 中携带一次性登录代码，前端调用 /auth/oidc/exchange 换取 Token
+// @Tags 认证
+// @Produce json
+// @Param provider path string true "提供方名称"
+// @Param code query string false "授权码"
+// @Param state query string true "登录状态"
+// @Success 302
+// @Failure 400 {object} dto.Response
+// @Failure 401 {object} dto.Response
+// @Router /api/v1/auth/oidc/{provider}/callback [get]
+func (h *OIDCHandler) Callback(c *gin.Context) {
+	var req dto.OIDCCallbackRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		h.HandleBadRequest(c, err.Error())
+		return
+	}
+
+	// Cookie 中的 state 只用于这一次回调，无论成功与否都清除
+	cookieState, _ := c.Cookie(oidcStateCookie)
+	setOIDCStateCookie(c, "", -1)
+
+	redirectURL, err := h.oidcService.Callback(c.Request.Context(), c.Param("provider"), req, cookieState)
+	if err != nil {
+		if appErr, ok := err.(*apperrors.AppError); ok {
+			h.HandleError(c, appErr.Code, appErr.Message)
+		} else {
+			h.HandleInternalError(c, err.Error())
+		}
+		return
+	}
+
+	c.Redirect(http.StatusFound, redirectURL)
+}
+
+// Exchange 用一次性登录代码换取 Token
+// @Summary 单点登录换取Token
+// @Description 用回调重定向中携带的一次性登录代码换取 FlowGo Token，代码一分钟内有效且只能使用一次
+// @Tags 认证
+// @Accept json
+// @Produce json
+// @Param request body dto.OIDCExchangeRequest true "登录代码"
+// @Success 200 {object} dto.Response{data=dto.LoginResponse}
+// @Failure 400 {object} dto.Response
+// @Failure 403 {object} dto.Response
+// @Router /api/v1/auth/oidc/exchange [post]
+func (h *OIDCHandler) Exchange(c *gin.Context) {
+	var req dto.OIDCExchangeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.HandleBadRequest(c, err.Error())
+		return
+	}
+
+	result, err := h.oidcService.ExchangeLoginCode(c.Request.Context(), req)
+	if err != nil {
+		if appErr, ok := err.(*apperrors.AppError); ok {
+			h.HandleError(c, appErr.Code, appErr.Message)
+		} else {
+			h.HandleInternalError(c, err.Error())
+		}
+		return
+	}
+
+	h.HandleSuccess(c, result)
+}
+
+// setOIDCStateCookie 写入或清除（maxAge < 0）state Cookie
+// SameSite=Lax 允许身份提供方重定向回来时携带，但跨站的子请求不会携带
+func setOIDCStateCookie(c *gin.Context, state string, maxAge int) {
+	secure := c.Request.TLS != nil || c.GetHeader("X-Forwarded-Proto") == "https"
+	c.SetSameSite(http.SameSiteLaxMode)
+	c.SetCookie(oidcStateCookie, state, maxAge, oidcStatePath, "", secure, true)
+}
@@ -0,0 +1,31 @@
+package handler
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	apperrors "FLOWGO/pkg/errors"
+	"FLOWGO/pkg/jsonpatch"
+)
+
+// acceptPatch 支持的补丁格式，415 时通过 Accept-Patch 响应头告知客户端
+var acceptPatch = strings.Join([]string{jsonpatch.MergePatchMediaType, jsonpatch.JSONPatchMediaType}, ", ")
+
+// bindPatch 按 Content-Type 解析请求体中的 JSON Merge Patch 或 JSON Patch
+func bindPatch(c *gin.Context) (jsonpatch.Patch, error) {
+	data, err := c.GetRawData()
+	if err != nil {
+		return nil, apperrors.NewAppError(400, "读取请求体失败", err)
+	}
+	patch, err := jsonpatch.Decode(c.GetHeader("Content-Type"), data)
+	if errors.Is(err, jsonpatch.ErrUnsupportedMediaType) {
+		c.Header("Accept-Patch", acceptPatch)
+		return nil, apperrors.NewAppError(415, "不支持的补丁格式，请使用 "+acceptPatch, err)
+	}
+	if err != nil {
+		return nil, apperrors.NewAppError(400, err.Error(), err)
+	}
+	return patch, nil
+}
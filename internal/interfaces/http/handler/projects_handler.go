@@ -55,15 +55,36 @@ func (h *ProjectsHandler) UpdateProject(c *gin.Context) {
 		return
 	}
 
+	var uri dto.GetProjectRequest
+	if err := c.ShouldBindUri(&uri); err != nil {
+		h.HandleBadRequest(c, err.Error())
+		return
+	}
 	var req dto.UpdateProjectRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
 		h.HandleBadRequest(c, err.Error())
 		return
 	}
+	// ETag 针对路径中的项目，不允许通过请求体改写其他项目
+	if req.ID != 0 && req.ID != uri.ID {
+		h.HandleBadRequest(c, "请求体中的项目ID与路径不一致")
+		return
+	}
+	req.ID = uri.ID
 	req.OwnerID = userID
+	// 必须携带读取项目时得到的 ETag，避免覆盖其他人的修改
+	version, err := ifMatchVersion(c, true)
+	if err != nil {
+		if appErr, ok := err.(*apperrors.AppError); ok {
+			h.HandleError(c, appErr.Code, appErr.Message)
+		} else {
+			h.HandleInternalError(c, err.Error())
+		}
+		return
+	}
 	// 将用户ID设置到请求中（如果需要验证权限）
 	// 或者直接传递给 UseCase
-	project, err := h.projectService.UpdateProject(c.Request.Context(), req)
+	project, err := h.projectService.UpdateProject(c.Request.Context(), req, version)
 	if err != nil {
 		if appErr, ok := err.(*apperrors.AppError); ok {
 			h.HandleError(c, appErr.Code, appErr.Message)
@@ -73,6 +94,63 @@ func (h *ProjectsHandler) UpdateProject(c *gin.Context) {
 		return
 	}
 
+	setETag(c, project.Version)
+	h.HandleSuccess(c, project)
+}
+
+// PatchProject 部分更新项目
+// @Summary 部分更新项目
+// @Description 使用 JSON Merge Patch（application/merge-patch+json）或 JSON Patch（application/json-patch+json）只修改需要改动的字段；
+// @Description 可修改 name、description、deadline、start_date、priority、cover_image、team_ids、tags、progress_mode、progress；
+// @Description 携带 If-Match 时版本不一致返回 412；需要项目 maintainer 及以上角色
+// @Tags 项目
+// @Accept json
+// @Produce json
+// @Param id path int true "项目ID"
+// @Param If-Match header string false "获取项目时返回的 ETag"
+// @Success 200 {object} dto.Response{data=dto.GetProjectResponse}
+// @Failure 400 {object} dto.Response
+// @Failure 409 {object} dto.Response
+// @Failure 412 {object} dto.Response
+// @Failure 415 {object} dto.Response
+// @Router /api/v1/projects/{id} [patch]
+func (h *ProjectsHandler) PatchProject(c *gin.Context) {
+	var uri dto.GetProjectRequest
+	if err := c.ShouldBindUri(&uri); err != nil {
+		h.HandleBadRequest(c, err.Error())
+		return
+	}
+	// If-Match 可选，携带时检查版本
+	version, err := ifMatchVersion(c, false)
+	if err != nil {
+		if appErr, ok := err.(*apperrors.AppError); ok {
+			h.HandleError(c, appErr.Code, appErr.Message)
+		} else {
+			h.HandleInternalError(c, err.Error())
+		}
+		return
+	}
+	patch, err := bindPatch(c)
+	if err != nil {
+		if appErr, ok := err.(*apperrors.AppError); ok {
+			h.HandleError(c, appErr.Code, appErr.Message)
+		} else {
+			h.HandleInternalError(c, err.Error())
+		}
+		return
+	}
+
+	project, err := h.projectService.PatchProject(c.Request.Context(), uri.ID, patch, version)
+	if err != nil {
+		if appErr, ok := err.(*apperrors.AppError); ok {
+			h.HandleError(c, appErr.Code, appErr.Message)
+		} else {
+			h.HandleInternalError(c, err.Error())
+		}
+		return
+	}
+
+	setETag(c, project.Version)
 	h.HandleSuccess(c, project)
 }
 
@@ -113,11 +191,12 @@ func (h *ProjectsHandler) GetProject(c *gin.Context) {
 		return
 	}
 
+	setETag(c, project.Version)
 	h.HandleSuccess(c, project)
 }
 
 func (h *ProjectsHandler) ListProjects(c *gin.Context) {
-	var req dto.PageRequest
+	var req dto.ListProjectsRequest
 	if err := c.ShouldBindQuery(&req); err != nil {
 		h.HandleBadRequest(c, err.Error())
 		return
@@ -156,7 +235,12 @@ func (h *ProjectsHandler) ProjectAvailableUsers(c *gin.Context) {
 		h.HandleBadRequest(c, err.Error())
 		return
 	}
-	users, err := h.projectService.GetProjectAvailableUsers(c.Request.Context(), req.ID)
+	var query dto.ProjectAvailableUsersQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		h.HandleBadRequest(c, err.Error())
+		return
+	}
+	users, err := h.projectService.GetProjectAvailableUsers(c.Request.Context(), req.ID, query.IncludeDescendants)
 	if err != nil {
 		if appErr, ok := err.(*apperrors.AppError); ok {
 			h.HandleError(c, appErr.Code, appErr.Message)
@@ -186,7 +270,11 @@ func (h *ProjectsHandler) AddProjectUsers(c *gin.Context) {
 
 	resp, err := h.projectService.AddProjectUsers(c.Request.Context(), req, uriReq.ID)
 	if err != nil {
-		h.HandleInternalError(c, err.Error())
+		if appErr, ok := err.(*apperrors.AppError); ok {
+			h.HandleError(c, appErr.Code, appErr.Message)
+		} else {
+			h.HandleInternalError(c, err.Error())
+		}
 		return
 	}
 
@@ -205,9 +293,108 @@ func (h *ProjectsHandler) RemoveProjectUser(c *gin.Context) {
 
 	err := h.projectService.RemoveProjectUser(c.Request.Context(), uriReq.ID, uriReq.UserID)
 	if err != nil {
-		h.HandleInternalError(c, err.Error())
+		if appErr, ok := err.(*apperrors.AppError); ok {
+			h.HandleError(c, appErr.Code, appErr.Message)
+		} else {
+			h.HandleInternalError(c, err.Error())
+		}
 		return
 	}
 
 	h.HandleSuccess(c, nil)
 }
+
+func (h *ProjectsHandler) UpdateProjectUserRole(c *gin.Context) {
+	var uriReq struct {
+		ID     uint64 `uri:"id" binding:"required"`
+		UserID uint64 `uri:"uid" binding:"required"`
+	}
+	if err := c.ShouldBindUri(&uriReq); err != nil {
+		h.HandleBadRequest(c, err.Error())
+		return
+	}
+
+	var req dto.UpdateProjectUserRoleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.HandleBadRequest(c, err.Error())
+		return
+	}
+
+	resp, err := h.projectService.UpdateProjectUserRole(c.Request.Context(), req, uriReq.ID, uriReq.UserID)
+	if err != nil {
+		if appErr, ok := err.(*apperrors.AppError); ok {
+			h.HandleError(c, appErr.Code, appErr.Message)
+		} else {
+			h.HandleInternalError(c, err.Error())
+		}
+		return
+	}
+
+	h.HandleSuccess(c, resp)
+}
+
+// ChangeProjectStatus 变更项目状态
+// @Summary 变更项目状态
+// @Description 进行中 → 已完成 → 已归档，已完成或已归档的项目可以重新打开（需要填写原因）；已归档的项目只读；需要项目 maintainer 及以上角色；变更后项目版本号加一，通过 ETag 返回
+// @Tags 项目
+// @Accept json
+// @Produce json
+// @Param id path int true "项目ID"
+// @Param status body dto.ChangeProjectStatusRequest true "目标状态"
+// @Success 200 {object} dto.Response{data=dto.ChangeProjectStatusResponse}
+// @Failure 400 {object} dto.Response
+// @Failure 409 {object} dto.Response
+// @Router /api/v1/projects/{id}/status [post]
+func (h *ProjectsHandler) ChangeProjectStatus(c *gin.Context) {
+	var uri dto.GetProjectRequest
+	if err := c.ShouldBindUri(&uri); err != nil {
+		h.HandleBadRequest(c, err.Error())
+		return
+	}
+	var req dto.ChangeProjectStatusRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.HandleBadRequest(c, err.Error())
+		return
+	}
+
+	change, err := h.projectService.ChangeProjectStatus(c.Request.Context(), uri.ID, req)
+	if err != nil {
+		if appErr, ok := err.(*apperrors.AppError); ok {
+			h.HandleError(c, appErr.Code, appErr.Message)
+		} else {
+			h.HandleInternalError(c, err.Error())
+		}
+		return
+	}
+
+	setETag(c, change.Version)
+	h.HandleSuccess(c, change)
+}
+
+// ListProjectStatusChanges 获取项目状态变更历史
+// @Summary 获取项目状态变更历史
+// @Description 获取项目的状态变更记录，最近的在前
+// @Tags 项目
+// @Produce json
+// @Param id path int true "项目ID"
+// @Success 200 {object} dto.Response{data=[]dto.ProjectStatusChangeResponse}
+// @Router /api/v1/projects/{id}/status/history [get]
+func (h *ProjectsHandler) ListProjectStatusChanges(c *gin.Context) {
+	var uri dto.GetProjectRequest
+	if err := c.ShouldBindUri(&uri); err != nil {
+		h.HandleBadRequest(c, err.Error())
+		return
+	}
+
+	changes, err := h.projectService.ListProjectStatusChanges(c.Request.Context(), uri.ID)
+	if err != nil {
+		if appErr, ok := err.(*apperrors.AppError); ok {
+			h.HandleError(c, appErr.Code, appErr.Message)
+		} else {
+			h.HandleInternalError(c, err.Error())
+		}
+		return
+	}
+
+	h.HandleSuccess(c, changes)
+}
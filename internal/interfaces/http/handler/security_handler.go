@@ -0,0 +1,82 @@
+package handler
+
+import (
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"FLOWGO/internal/application/dto"
+	"FLOWGO/internal/application/service"
+	apperrors "FLOWGO/pkg/errors"
+)
+
+// SecurityHandler 安全管理处理器
+type SecurityHandler struct {
+	BaseHandler
+	securityService *service.SecurityService
+}
+
+// NewSecurityHandler 创建安全管理处理器实例
+func NewSecurityHandler(securityService *service.SecurityService) *SecurityHandler {
+	return &SecurityHandler{
+		securityService: securityService,
+	}
+}
+
+// UnlockUser 解除账号锁定
+// @Summary 解除账号锁定
+// @Description 清除账号的登录失败记录，解除临时锁定和退避
+// @Tags 用户
+// @Produce json
+// @Param id path int true "用户ID"
+// @Success 200 {object} dto.Response
+// @Failure 404 {object} dto.Response
+// @Router /api/v1/users/{id}/unlock [post]
+func (h *SecurityHandler) UnlockUser(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		h.HandleBadRequest(c, "无效的用户ID")
+		return
+	}
+
+	if err := h.securityService.UnlockUser(c.Request.Context(), id); err != nil {
+		if appErr, ok := err.(*apperrors.AppError); ok {
+			h.HandleError(c, appErr.Code, appErr.Message)
+		} else {
+			h.HandleInternalError(c, err.Error())
+		}
+		return
+	}
+
+	h.HandleSuccess(c, nil)
+}
+
+// ListEvents 获取安全事件列表
+// @Summary 获取安全事件列表
+// @Description 分页获取账号锁定、解锁、IP限流等安全事件，按时间倒序
+// @Tags 安全
+// @Produce json
+// @Param page query int false "页码" default(1)
+// @Param page_size query int false "每页数量" default(10)
+// @Param type query string false "事件类型：account_locked, account_unlocked, ip_throttled"
+// @Success 200 {object} dto.Response{data=dto.SecurityEventListResponse}
+// @Router /api/v1/security/events [get]
+func (h *SecurityHandler) ListEvents(c *gin.Context) {
+	var req dto.SecurityEventListRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		h.HandleBadRequest(c, err.Error())
+		return
+	}
+
+	result, err := h.securityService.ListEvents(c.Request.Context(), req)
+	if err != nil {
+		if appErr, ok := err.(*apperrors.AppError); ok {
+			h.HandleError(c, appErr.Code, appErr.Message)
+		} else {
+			h.HandleInternalError(c, err.Error())
+		}
+		return
+	}
+
+	h.HandleSuccessWithPage(c, result.List, result.Page.Page, result.Page.PageSize, result.Page.Total)
+}
@@ -0,0 +1,148 @@
+package handler
+
+import (
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"FLOWGO/internal/application/dto"
+	"FLOWGO/internal/application/service"
+	apperrors "FLOWGO/pkg/errors"
+)
+
+// TagHandler 标签处理器
+type TagHandler struct {
+	BaseHandler
+	tagService *service.TagService
+}
+
+// NewTagHandler 创建标签处理器实例
+func NewTagHandler(tagService *service.TagService) *TagHandler {
+	return &TagHandler{
+		tagService: tagService,
+	}
+}
+
+// SuggestTags 标签自动补全
+// @Summary 标签自动补全
+// @Description 按名称前缀查询标签，使用次数多的排在前面；不传前缀时返回最常用的标签
+// @Tags 标签
+// @Produce json
+// @Param q query string false "名称前缀"
+// @Param limit query int false "返回数量，默认 10，最多 50"
+// @Success 200 {object} dto.Response{data=[]dto.TagResponse}
+// @Router /api/v1/tags [get]
+func (h *TagHandler) SuggestTags(c *gin.Context) {
+	var req dto.SuggestTagsRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		h.HandleBadRequest(c, err.Error())
+		return
+	}
+
+	tags, err := h.tagService.SuggestTags(c.Request.Context(), req)
+	if err != nil {
+		if appErr, ok := err.(*apperrors.AppError); ok {
+			h.HandleError(c, appErr.Code, appErr.Message)
+		} else {
+			h.HandleInternalError(c, err.Error())
+		}
+		return
+	}
+
+	h.HandleSuccess(c, tags)
+}
+
+// CreateTag 创建标签
+// @Summary 创建标签
+// @Description 预先创建标签并指定颜色；给项目设置不存在的标签时也会自动创建
+// @Tags 标签
+// @Accept json
+// @Produce json
+// @Param tag body dto.CreateTagRequest true "标签信息"
+// @Success 200 {object} dto.Response{data=dto.TagResponse}
+// @Failure 409 {object} dto.Response
+// @Router /api/v1/tags [post]
+func (h *TagHandler) CreateTag(c *gin.Context) {
+	var req dto.CreateTagRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.HandleBadRequest(c, err.Error())
+		return
+	}
+
+	tag, err := h.tagService.CreateTag(c.Request.Context(), req)
+	if err != nil {
+		if appErr, ok := err.(*apperrors.AppError); ok {
+			h.HandleError(c, appErr.Code, appErr.Message)
+		} else {
+			h.HandleInternalError(c, err.Error())
+		}
+		return
+	}
+
+	h.HandleSuccess(c, tag)
+}
+
+// UpdateTag 修改标签
+// @Summary 修改标签
+// @Description 修改标签名称和颜色，使用该标签的项目随之变化
+// @Tags 标签
+// @Accept json
+// @Produce json
+// @Param id path int true "标签ID"
+// @Param tag body dto.UpdateTagRequest true "标签信息"
+// @Success 200 {object} dto.Response{data=dto.TagResponse}
+// @Failure 404 {object} dto.Response
+// @Failure 409 {object} dto.Response
+// @Router /api/v1/tags/{id} [put]
+func (h *TagHandler) UpdateTag(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		h.HandleBadRequest(c, "无效的标签ID")
+		return
+	}
+	var req dto.UpdateTagRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.HandleBadRequest(c, err.Error())
+		return
+	}
+
+	tag, err := h.tagService.UpdateTag(c.Request.Context(), id, req)
+	if err != nil {
+		if appErr, ok := err.(*apperrors.AppError); ok {
+			h.HandleError(c, appErr.Code, appErr.Message)
+		} else {
+			h.HandleInternalError(c, err.Error())
+		}
+		return
+	}
+
+	h.HandleSuccess(c, tag)
+}
+
+// DeleteTag 删除标签
+// @Summary 删除标签
+// @Description 删除标签并从全部项目中移除
+// @Tags 标签
+// @Produce json
+// @Param id path int true "标签ID"
+// @Success 200 {object} dto.Response
+// @Failure 404 {object} dto.Response
+// @Router /api/v1/tags/{id} [delete]
+func (h *TagHandler) DeleteTag(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		h.HandleBadRequest(c, "无效的标签ID")
+		return
+	}
+
+	if err := h.tagService.DeleteTag(c.Request.Context(), id); err != nil {
+		if appErr, ok := err.(*apperrors.AppError); ok {
+			h.HandleError(c, appErr.Code, appErr.Message)
+		} else {
+			h.HandleInternalError(c, err.Error())
+		}
+		return
+	}
+
+	h.HandleSuccess(c, nil)
+}
@@ -0,0 +1,199 @@
+package handler
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"FLOWGO/internal/application/dto"
+	"FLOWGO/internal/application/service"
+	apperrors "FLOWGO/pkg/errors"
+)
+
+// TaskHandler 任务处理器
+type TaskHandler struct {
+	BaseHandler
+	taskService *service.TaskService
+}
+
+// NewTaskHandler 创建任务处理器实例
+func NewTaskHandler(taskService *service.TaskService) *TaskHandler {
+	return &TaskHandler{
+		taskService: taskService,
+	}
+}
+
+// CreateTask 创建任务
+// @Summary 创建任务
+// @Description 在项目中创建任务，当前用户为任务创建人，需要项目 member 及以上角色
+// @Tags 任务
+// @Accept json
+// @Produce json
+// @Param id path int true "项目ID"
+// @Param task body dto.CreateTaskRequest true "任务信息"
+// @Success 200 {object} dto.Response{data=dto.TaskResponse}
+// @Failure 400 {object} dto.Response
+// @Failure 404 {object} dto.Response
+// @Router /api/v1/projects/{id}/tasks [post]
+func (h *TaskHandler) CreateTask(c *gin.Context) {
+	var uri dto.TaskURI
+	if err := c.ShouldBindUri(&uri); err != nil {
+		h.HandleBadRequest(c, err.Error())
+		return
+	}
+	var req dto.CreateTaskRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.HandleBadRequest(c, err.Error())
+		return
+	}
+
+	task, err := h.taskService.CreateTask(c.Request.Context(), uri.ProjectID, req)
+	if err != nil {
+		if appErr, ok := err.(*apperrors.AppError); ok {
+			h.HandleError(c, appErr.Code, appErr.Message)
+		} else {
+			h.HandleInternalError(c, err.Error())
+		}
+		return
+	}
+
+	h.HandleSuccess(c, task)
+}
+
+// ListTasks 获取任务列表
+// @Summary 获取任务列表
+// @Description 分页获取项目内的任务，可按状态、优先级、处理人等过滤
+// @Tags 任务
+// @Produce json
+// @Param id path int true "项目ID"
+// @Param page query int false "页码" default(1)
+// @Param page_size query int false "每页数量" default(10)
+// @Param status query string false "状态" Enums(todo, in_progress, in_review, done, cancelled)
+// @Param priority query int false "优先级 0-3"
+// @Param assignee_id query int false "处理人ID"
+// @Param unassigned query bool false "只查询未指派的任务"
+// @Param reporter_id query int false "创建人ID"
+// @Param q query string false "标题关键字"
+// @Param overdue query bool false "只查询已逾期的任务"
+// @Success 200 {object} dto.Response{data=dto.TaskListResponse}
+// @Router /api/v1/projects/{id}/tasks [get]
+func (h *TaskHandler) ListTasks(c *gin.Context) {
+	var uri dto.TaskURI
+	if err := c.ShouldBindUri(&uri); err != nil {
+		h.HandleBadRequest(c, err.Error())
+		return
+	}
+	var req dto.ListTasksRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		h.HandleBadRequest(c, err.Error())
+		return
+	}
+
+	result, err := h.taskService.ListTasks(c.Request.Context(), uri.ProjectID, req)
+	if err != nil {
+		if appErr, ok := err.(*apperrors.AppError); ok {
+			h.HandleError(c, appErr.Code, appErr.Message)
+		} else {
+			h.HandleInternalError(c, err.Error())
+		}
+		return
+	}
+
+	h.HandleSuccessWithPage(c, result.List, result.Page.Page, result.Page.PageSize, result.Page.Total)
+}
+
+// GetTask 获取任务
+// @Summary 获取任务
+// @Description 获取项目内的任务详情
+// @Tags 任务
+// @Produce json
+// @Param id path int true "项目ID"
+// @Param tid path int true "任务ID"
+// @Success 200 {object} dto.Response{data=dto.TaskResponse}
+// @Failure 404 {object} dto.Response
+// @Router /api/v1/projects/{id}/tasks/{tid} [get]
+func (h *TaskHandler) GetTask(c *gin.Context) {
+	var uri dto.TaskURI
+	if err := c.ShouldBindUri(&uri); err != nil {
+		h.HandleBadRequest(c, err.Error())
+		return
+	}
+
+	task, err := h.taskService.GetTask(c.Request.Context(), uri.ProjectID, uri.TaskID)
+	if err != nil {
+		if appErr, ok := err.(*apperrors.AppError); ok {
+			h.HandleError(c, appErr.Code, appErr.Message)
+		} else {
+			h.HandleInternalError(c, err.Error())
+		}
+		return
+	}
+
+	h.HandleSuccess(c, task)
+}
+
+// UpdateTask 更新任务
+// @Summary 更新任务
+// @Description 更新任务信息，需要项目 member 及以上角色
+// @Tags 任务
+// @Accept json
+// @Produce json
+// @Param id path int true "项目ID"
+// @Param tid path int true "任务ID"
+// @Param task body dto.UpdateTaskRequest true "任务信息"
+// @Success 200 {object} dto.Response{data=dto.TaskResponse}
+// @Failure 400 {object} dto.Response
+// @Failure 404 {object} dto.Response
+// @Router /api/v1/projects/{id}/tasks/{tid} [put]
+func (h *TaskHandler) UpdateTask(c *gin.Context) {
+	var uri dto.TaskURI
+	if err := c.ShouldBindUri(&uri); err != nil {
+		h.HandleBadRequest(c, err.Error())
+		return
+	}
+	var req dto.UpdateTaskRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.HandleBadRequest(c, err.Error())
+		return
+	}
+
+	task, err := h.taskService.UpdateTask(c.Request.Context(), uri.ProjectID, uri.TaskID, req)
+	if err != nil {
+		if appErr, ok := err.(*apperrors.AppError); ok {
+			h.HandleError(c, appErr.Code, appErr.Message)
+		} else {
+			h.HandleInternalError(c, err.Error())
+		}
+		return
+	}
+
+	h.HandleSuccess(c, task)
+}
+
+// DeleteTask 删除任务
+// @Summary 删除任务
+// @Description 删除任务，需要项目 maintainer 及以上角色或为任务创建人
+// @Tags 任务
+// @Produce json
+// @Param id path int true "项目ID"
+// @Param tid path int true "任务ID"
+// @Success 200 {object} dto.Response
+// @Failure 403 {object} dto.Response
+// @Failure 404 {object} dto.Response
+// @Router /api/v1/projects/{id}/tasks/{tid} [delete]
+func (h *TaskHandler) DeleteTask(c *gin.Context) {
+	var uri dto.TaskURI
+	if err := c.ShouldBindUri(&uri); err != nil {
+		h.HandleBadRequest(c, err.Error())
+		return
+	}
+
+	if err := h.taskService.DeleteTask(c.Request.Context(), uri.ProjectID, uri.TaskID); err != nil {
+		if appErr, ok := err.(*apperrors.AppError); ok {
+			h.HandleError(c, appErr.Code, appErr.Message)
+		} else {
+			h.HandleInternalError(c, err.Error())
+		}
+		return
+	}
+
+	h.HandleSuccess(c, nil)
+}
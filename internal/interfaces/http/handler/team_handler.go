@@ -0,0 +1,376 @@
+package handler
+
+import (
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"FLOWGO/internal/application/dto"
+	"FLOWGO/internal/application/service"
+	apperrors "FLOWGO/pkg/errors"
+)
+
+// TeamHandler 团队处理器
+type TeamHandler struct {
+	BaseHandler
+	teamService *service.TeamService
+}
+
+// NewTeamHandler 创建团队处理器实例
+func NewTeamHandler(teamService *service.TeamService) *TeamHandler {
+	return &TeamHandler{
+		teamService: teamService,
+	}
+}
+
+// CreateTeam 创建团队
+// @Summary 创建团队
+// @Description 创建新团队，创建者成为团队负责人
+// @Tags 团队
+// @Accept json
+// @Produce json
+// @Param team body dto.CreateTeamRequest true "团队信息"
+// @Success 200 {object} dto.Response{data=dto.TeamDetailResponse}
+// @Failure 400 {object} dto.Response
+// @Router /api/v1/teams [post]
+func (h *TeamHandler) CreateTeam(c *gin.Context) {
+	var req dto.CreateTeamRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.HandleBadRequest(c, err.Error())
+		return
+	}
+
+	team, err := h.teamService.CreateTeam(c.Request.Context(), req)
+	if err != nil {
+		if appErr, ok := err.(*apperrors.AppError); ok {
+			h.HandleError(c, appErr.Code, appErr.Message)
+		} else {
+			h.HandleInternalError(c, err.Error())
+		}
+		return
+	}
+
+	h.HandleSuccess(c, team)
+}
+
+// GetTeam 获取团队
+// @Summary 获取团队
+// @Description 根据ID获取团队信息
+// @Tags 团队
+// @Produce json
+// @Param id path int true "团队ID"
+// @Success 200 {object} dto.Response{data=dto.TeamDetailResponse}
+// @Failure 404 {object} dto.Response
+// @Router /api/v1/teams/{id} [get]
+func (h *TeamHandler) GetTeam(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		h.HandleBadRequest(c, "无效的团队ID")
+		return
+	}
+
+	team, err := h.teamService.GetTeam(c.Request.Context(), id)
+	if err != nil {
+		if appErr, ok := err.(*apperrors.AppError); ok {
+			h.HandleError(c, appErr.Code, appErr.Message)
+		} else {
+			h.HandleInternalError(c, err.Error())
+		}
+		return
+	}
+
+	h.HandleSuccess(c, team)
+}
+
+// ListTeams 获取团队列表
+// @Summary 获取团队列表
+// @Description 分页获取团队列表
+// @Tags 团队
+// @Produce json
+// @Param page query int false "页码" default(1)
+// @Param page_size query int false "每页数量" default(10)
+// @Success 200 {object} dto.Response{data=dto.TeamListResponse}
+// @Router /api/v1/teams [get]
+func (h *TeamHandler) ListTeams(c *gin.Context) {
+	var req dto.PageRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		h.HandleBadRequest(c, err.Error())
+		return
+	}
+
+	result, err := h.teamService.ListTeams(c.Request.Context(), req)
+	if err != nil {
+		if appErr, ok := err.(*apperrors.AppError); ok {
+			h.HandleError(c, appErr.Code, appErr.Message)
+		} else {
+			h.HandleInternalError(c, err.Error())
+		}
+		return
+	}
+
+	h.HandleSuccessWithPage(c, result.List, result.Page.Page, result.Page.PageSize, result.Page.Total)
+}
+
+// GetTeamTree 获取团队树
+// @Summary 获取团队树
+// @Description 按上下级关系返回全部团队（部门 → 团队 → 小组）
+// @Tags 团队
+// @Produce json
+// @Success 200 {object} dto.Response{data=dto.TeamTreeResponse}
+// @Router /api/v1/teams/tree [get]
+func (h *TeamHandler) GetTeamTree(c *gin.Context) {
+	tree, err := h.teamService.GetTeamTree(c.Request.Context())
+	if err != nil {
+		if appErr, ok := err.(*apperrors.AppError); ok {
+			h.HandleError(c, appErr.Code, appErr.Message)
+		} else {
+			h.HandleInternalError(c, err.Error())
+		}
+		return
+	}
+
+	h.HandleSuccess(c, tree)
+}
+
+// UpdateTeam 更新团队
+// @Summary 更新团队
+// @Description 更新团队名称、描述和上级团队，仅团队负责人和管理员可操作；调整上级团队时还需能管理新的上级团队
+// @Tags 团队
+// @Accept json
+// @Produce json
+// @Param id path int true "团队ID"
+// @Param team body dto.UpdateTeamRequest true "团队信息"
+// @Success 200 {object} dto.Response{data=dto.TeamDetailResponse}
+// @Failure 403 {object} dto.Response
+// @Failure 404 {object} dto.Response
+// @Router /api/v1/teams/{id} [put]
+func (h *TeamHandler) UpdateTeam(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		h.HandleBadRequest(c, "无效的团队ID")
+		return
+	}
+	var req dto.UpdateTeamRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.HandleBadRequest(c, err.Error())
+		return
+	}
+
+	team, err := h.teamService.UpdateTeam(c.Request.Context(), id, req)
+	if err != nil {
+		if appErr, ok := err.(*apperrors.AppError); ok {
+			h.HandleError(c, appErr.Code, appErr.Message)
+		} else {
+			h.HandleInternalError(c, err.Error())
+		}
+		return
+	}
+
+	h.HandleSuccess(c, team)
+}
+
+// DeleteTeam 删除团队
+// @Summary 删除团队
+// @Description 删除团队并解除其与项目、成员的关联，仅团队负责人和管理员可操作
+// @Tags 团队
+// @Produce json
+// @Param id path int true "团队ID"
+// @Success 200 {object} dto.Response
+// @Failure 403 {object} dto.Response
+// @Failure 404 {object} dto.Response
+// @Router /api/v1/teams/{id} [delete]
+func (h *TeamHandler) DeleteTeam(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		h.HandleBadRequest(c, "无效的团队ID")
+		return
+	}
+
+	if err := h.teamService.DeleteTeam(c.Request.Context(), id); err != nil {
+		if appErr, ok := err.(*apperrors.AppError); ok {
+			h.HandleError(c, appErr.Code, appErr.Message)
+		} else {
+			h.HandleInternalError(c, err.Error())
+		}
+		return
+	}
+
+	h.HandleSuccess(c, nil)
+}
+
+// TransferOwner 转移团队负责人
+// @Summary 转移团队负责人
+// @Description 将团队负责人转移给其他启用状态的用户，仅团队负责人和管理员可操作
+// @Tags 团队
+// @Accept json
+// @Produce json
+// @Param id path int true "团队ID"
+// @Param body body dto.TransferTeamOwnerRequest true "新负责人"
+// @Success 200 {object} dto.Response{data=dto.TeamDetailResponse}
+// @Failure 400 {object} dto.Response
+// @Failure 403 {object} dto.Response
+// @Router /api/v1/teams/{id}/transfer [post]
+func (h *TeamHandler) TransferOwner(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		h.HandleBadRequest(c, "无效的团队ID")
+		return
+	}
+	var req dto.TransferTeamOwnerRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.HandleBadRequest(c, err.Error())
+		return
+	}
+
+	team, err := h.teamService.TransferOwner(c.Request.Context(), id, req)
+	if err != nil {
+		if appErr, ok := err.(*apperrors.AppError); ok {
+			h.HandleError(c, appErr.Code, appErr.Message)
+		} else {
+			h.HandleInternalError(c, err.Error())
+		}
+		return
+	}
+
+	h.HandleSuccess(c, team)
+}
+
+// ListMembers 获取团队成员
+// @Summary 获取团队成员
+// @Description 获取团队下的全部成员
+// @Tags 团队
+// @Produce json
+// @Param id path int true "团队ID"
+// @Success 200 {object} dto.Response{data=dto.TeamMembersResponse}
+// @Failure 404 {object} dto.Response
+// @Router /api/v1/teams/{id}/members [get]
+func (h *TeamHandler) ListMembers(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		h.HandleBadRequest(c, "无效的团队ID")
+		return
+	}
+
+	members, err := h.teamService.ListMembers(c.Request.Context(), id)
+	if err != nil {
+		if appErr, ok := err.(*apperrors.AppError); ok {
+			h.HandleError(c, appErr.Code, appErr.Message)
+		} else {
+			h.HandleInternalError(c, err.Error())
+		}
+		return
+	}
+
+	h.HandleSuccess(c, members)
+}
+
+// AddMembers 添加团队成员
+// @Summary 添加团队成员
+// @Description 将用户加入团队，已是成员的用户保持原角色，仅团队负责人、lead 和管理员可操作
+// @Tags 团队
+// @Accept json
+// @Produce json
+// @Param id path int true "团队ID"
+// @Param body body dto.AddTeamMembersRequest true "成员信息"
+// @Success 200 {object} dto.Response{data=dto.TeamMembersResponse}
+// @Failure 400 {object} dto.Response
+// @Failure 403 {object} dto.Response
+// @Router /api/v1/teams/{id}/members [post]
+func (h *TeamHandler) AddMembers(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		h.HandleBadRequest(c, "无效的团队ID")
+		return
+	}
+	var req dto.AddTeamMembersRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.HandleBadRequest(c, err.Error())
+		return
+	}
+
+	members, err := h.teamService.AddMembers(c.Request.Context(), id, req)
+	if err != nil {
+		if appErr, ok := err.(*apperrors.AppError); ok {
+			h.HandleError(c, appErr.Code, appErr.Message)
+		} else {
+			h.HandleInternalError(c, err.Error())
+		}
+		return
+	}
+
+	h.HandleSuccess(c, members)
+}
+
+// UpdateMemberRole 修改团队成员角色
+// @Summary 修改团队成员角色
+// @Description 修改成员在团队中的角色（lead、member），仅团队负责人、lead 和管理员可操作
+// @Tags 团队
+// @Accept json
+// @Produce json
+// @Param id path int true "团队ID"
+// @Param uid path int true "用户ID"
+// @Param body body dto.UpdateTeamMemberRoleRequest true "角色"
+// @Success 200 {object} dto.Response{data=dto.TeamMembersResponse}
+// @Failure 403 {object} dto.Response
+// @Failure 404 {object} dto.Response
+// @Router /api/v1/teams/{id}/members/{uid}/role [put]
+func (h *TeamHandler) UpdateMemberRole(c *gin.Context) {
+	var uriReq struct {
+		ID     uint64 `uri:"id" binding:"required"`
+		UserID uint64 `uri:"uid" binding:"required"`
+	}
+	if err := c.ShouldBindUri(&uriReq); err != nil {
+		h.HandleBadRequest(c, err.Error())
+		return
+	}
+	var req dto.UpdateTeamMemberRoleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.HandleBadRequest(c, err.Error())
+		return
+	}
+
+	members, err := h.teamService.UpdateMemberRole(c.Request.Context(), uriReq.ID, uriReq.UserID, req)
+	if err != nil {
+		if appErr, ok := err.(*apperrors.AppError); ok {
+			h.HandleError(c, appErr.Code, appErr.Message)
+		} else {
+			h.HandleInternalError(c, err.Error())
+		}
+		return
+	}
+
+	h.HandleSuccess(c, members)
+}
+
+// RemoveMember 移除团队成员
+// @Summary 移除团队成员
+// @Description 将用户移出团队，团队负责人不能被移除，仅团队负责人、lead 和管理员可操作
+// @Tags 团队
+// @Produce json
+// @Param id path int true "团队ID"
+// @Param uid path int true "用户ID"
+// @Success 200 {object} dto.Response
+// @Failure 403 {object} dto.Response
+// @Failure 404 {object} dto.Response
+// @Router /api/v1/teams/{id}/members/{uid} [delete]
+func (h *TeamHandler) RemoveMember(c *gin.Context) {
+	var uriReq struct {
+		ID     uint64 `uri:"id" binding:"required"`
+		UserID uint64 `uri:"uid" binding:"required"`
+	}
+	if err := c.ShouldBindUri(&uriReq); err != nil {
+		h.HandleBadRequest(c, err.Error())
+		return
+	}
+
+	if err := h.teamService.RemoveMember(c.Request.Context(), uriReq.ID, uriReq.UserID); err != nil {
+		if appErr, ok := err.(*apperrors.AppError); ok {
+			h.HandleError(c, appErr.Code, appErr.Message)
+		} else {
+			h.HandleInternalError(c, err.Error())
+		}
+		return
+	}
+
+	h.HandleSuccess(c, nil)
+}
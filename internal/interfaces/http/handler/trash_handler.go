@@ -0,0 +1,138 @@
+package handler
+
+import (
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"FLOWGO/internal/application/dto"
+	"FLOWGO/internal/application/service"
+	apperrors "FLOWGO/pkg/errors"
+)
+
+// TrashHandler 回收站处理器
+type TrashHandler struct {
+	BaseHandler
+	trashService *service.TrashService
+}
+
+// NewTrashHandler 创建回收站处理器实例
+func NewTrashHandler(trashService *service.TrashService) *TrashHandler {
+	return &TrashHandler{
+		trashService: trashService,
+	}
+}
+
+// ListProjects 获取回收站中的项目
+// @Summary 获取回收站中的项目
+// @Description 分页获取已删除的项目，最近删除的在前；管理员可以看到全部，其他用户只能看到自己负责的项目
+// @Tags 回收站
+// @Produce json
+// @Param page query int false "页码" default(1)
+// @Param page_size query int false "每页数量" default(10)
+// @Success 200 {object} dto.Response{data=[]dto.TrashProjectResponse}
+// @Router /api/v1/trash/projects [get]
+func (h *TrashHandler) ListProjects(c *gin.Context) {
+	var req dto.ListTrashRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		h.HandleBadRequest(c, err.Error())
+		return
+	}
+
+	result, err := h.trashService.ListProjects(c.Request.Context(), req)
+	if err != nil {
+		if appErr, ok := err.(*apperrors.AppError); ok {
+			h.HandleError(c, appErr.Code, appErr.Message)
+		} else {
+			h.HandleInternalError(c, err.Error())
+		}
+		return
+	}
+
+	h.HandleSuccessWithPage(c, result.List, result.Page.Page, result.Page.PageSize, result.Page.Total)
+}
+
+// RestoreProject 恢复项目
+// @Summary 恢复项目
+// @Description 从回收站恢复项目，删除时一起移除的团队和成员关联随之恢复；需要是项目负责人或管理员
+// @Tags 回收站
+// @Produce json
+// @Param id path int true "项目ID"
+// @Success 200 {object} dto.Response
+// @Failure 404 {object} dto.Response
+// @Router /api/v1/trash/projects/{id}/restore [post]
+func (h *TrashHandler) RestoreProject(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		h.HandleBadRequest(c, "无效的项目ID")
+		return
+	}
+
+	if err := h.trashService.RestoreProject(c.Request.Context(), id); err != nil {
+		if appErr, ok := err.(*apperrors.AppError); ok {
+			h.HandleError(c, appErr.Code, appErr.Message)
+		} else {
+			h.HandleInternalError(c, err.Error())
+		}
+		return
+	}
+
+	h.HandleSuccess(c, nil)
+}
+
+// ListUsers 获取回收站中的用户
+// @Summary 获取回收站中的用户
+// @Description 分页获取已删除的用户，最近删除的在前
+// @Tags 回收站
+// @Produce json
+// @Param page query int false "页码" default(1)
+// @Param page_size query int false "每页数量" default(10)
+// @Success 200 {object} dto.Response{data=[]dto.TrashUserResponse}
+// @Router /api/v1/trash/users [get]
+func (h *TrashHandler) ListUsers(c *gin.Context) {
+	var req dto.ListTrashRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		h.HandleBadRequest(c, err.Error())
+		return
+	}
+
+	result, err := h.trashService.ListUsers(c.Request.Context(), req)
+	if err != nil {
+		if appErr, ok := err.(*apperrors.AppError); ok {
+			h.HandleError(c, appErr.Code, appErr.Message)
+		} else {
+			h.HandleInternalError(c, err.Error())
+		}
+		return
+	}
+
+	h.HandleSuccessWithPage(c, result.List, result.Page.Page, result.Page.PageSize, result.Page.Total)
+}
+
+// RestoreUser 恢复用户
+// @Summary 恢复用户
+// @Description 从回收站恢复用户，恢复后需要重新登录
+// @Tags 回收站
+// @Produce json
+// @Param id path int true "用户ID"
+// @Success 200 {object} dto.Response
+// @Failure 404 {object} dto.Response
+// @Router /api/v1/trash/users/{id}/restore [post]
+func (h *TrashHandler) RestoreUser(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		h.HandleBadRequest(c, "无效的用户ID")
+		return
+	}
+
+	if err := h.trashService.RestoreUser(c.Request.Context(), id); err != nil {
+		if appErr, ok := err.(*apperrors.AppError); ok {
+			h.HandleError(c, appErr.Code, appErr.Message)
+		} else {
+			h.HandleInternalError(c, err.Error())
+		}
+		return
+	}
+
+	h.HandleSuccess(c, nil)
+}
@@ -0,0 +1,133 @@
+package handler
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"FLOWGO/internal/application/dto"
+	"FLOWGO/internal/application/service"
+	apperrors "FLOWGO/pkg/errors"
+)
+
+// TwoFactorHandler 两步验证处理器
+type TwoFactorHandler struct {
+	BaseHandler
+	twoFactorService *service.TwoFactorService
+}
+
+// NewTwoFactorHandler 创建两步验证处理器实例
+func NewTwoFactorHandler(twoFactorService *service.TwoFactorService) *TwoFactorHandler {
+	return &TwoFactorHandler{
+		twoFactorService: twoFactorService,
+	}
+}
+
+// Setup 设置两步验证
+// @Summary 设置两步验证
+// @Description 生成TOTP密钥和 otpauth 链接，调用确认接口后生效
+// @Tags 认证
+// @Produce json
+// @Success 200 {object} dto.Response{data=dto.TwoFactorSetupResponse}
+// @Failure 400 {object} dto.Response
+// @Router /api/v1/auth/2fa/setup [post]
+func (h *TwoFactorHandler) Setup(c *gin.Context) {
+	result, err := h.twoFactorService.Setup(c.Request.Context())
+	if err != nil {
+		if appErr, ok := err.(*apperrors.AppError); ok {
+			h.HandleError(c, appErr.Code, appErr.Message)
+		} else {
+			h.HandleInternalError(c, err.Error())
+		}
+		return
+	}
+
+	h.HandleSuccess(c, result)
+}
+
+// Confirm 确认开启两步验证
+// @Summary 确认开启两步验证
+// @Description 校验验证器 App 生成的验证码后开启两步验证，返回一次性恢复码
+// @Tags 认证
+// @Accept json
+// @Produce json
+// @Param body body dto.TwoFactorConfirmRequest true "验证码"
+// @Success 200 {object} dto.Response{data=dto.RecoveryCodesResponse}
+// @Failure 400 {object} dto.Response
+// @Router /api/v1/auth/2fa/confirm [post]
+func (h *TwoFactorHandler) Confirm(c *gin.Context) {
+	var req dto.TwoFactorConfirmRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.HandleBadRequest(c, err.Error())
+		return
+	}
+
+	result, err := h.twoFactorService.Confirm(c.Request.Context(), req)
+	if err != nil {
+		if appErr, ok := err.(*apperrors.AppError); ok {
+			h.HandleError(c, appErr.Code, appErr.Message)
+		} else {
+			h.HandleInternalError(c, err.Error())
+		}
+		return
+	}
+
+	h.HandleSuccess(c, result)
+}
+
+// Disable 关闭两步验证
+// @Summary 关闭两步验证
+// @Description 校验密码和验证码（或恢复码）后关闭两步验证
+// @Tags 认证
+// @Accept json
+// @Produce json
+// @Param body body dto.TwoFactorDisableRequest true "密码和验证码"
+// @Success 200 {object} dto.Response
+// @Failure 400 {object} dto.Response
+// @Router /api/v1/auth/2fa/disable [post]
+func (h *TwoFactorHandler) Disable(c *gin.Context) {
+	var req dto.TwoFactorDisableRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.HandleBadRequest(c, err.Error())
+		return
+	}
+
+	if err := h.twoFactorService.Disable(c.Request.Context(), req); err != nil {
+		if appErr, ok := err.(*apperrors.AppError); ok {
+			h.HandleError(c, appErr.Code, appErr.Message)
+		} else {
+			h.HandleInternalError(c, err.Error())
+		}
+		return
+	}
+
+	h.HandleSuccess(c, nil)
+}
+
+// RegenerateRecoveryCodes 重新生成恢复码
+// @Summary 重新生成恢复码
+// @Description 校验验证码后生成新的恢复码，原有恢复码全部失效
+// @Tags 认证
+// @Accept json
+// @Produce json
+// @Param body body dto.RecoveryCodesRequest true "验证码"
+// @Success 200 {object} dto.Response{data=dto.RecoveryCodesResponse}
+// @Failure 400 {object} dto.Response
+// @Router /api/v1/auth/2fa/recovery-codes [post]
+func (h *TwoFactorHandler) RegenerateRecoveryCodes(c *gin.Context) {
+	var req dto.RecoveryCodesRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.HandleBadRequest(c, err.Error())
+		return
+	}
+
+	result, err := h.twoFactorService.RegenerateRecoveryCodes(c.Request.Context(), req)
+	if err != nil {
+		if appErr, ok := err.(*apperrors.AppError); ok {
+			h.HandleError(c, appErr.Code, appErr.Message)
+		} else {
+			h.HandleInternalError(c, err.Error())
+		}
+		return
+	}
+
+	h.HandleSuccess(c, result)
+}
@@ -86,16 +86,21 @@ func (h *UserHandler) GetUser(c *gin.Context) {
 
 // ListUsers 获取用户列表
 // @Summary 获取用户列表
-// @Description 分页获取用户列表
+// @Description 分页获取用户列表，可按状态、角色和团队过滤，支持关键字搜索和排序
 // @Tags 用户
 // @Accept json
 // @Produce json
 // @Param page query int false "页码" default(1)
 // @Param page_size query int false "每页数量" default(10)
+// @Param q query string false "按用户名、显示名称和邮箱搜索"
+// @Param status query int false "状态：1 正常，2 禁用"
+// @Param role query string false "角色"
+// @Param team_id query int false "团队ID"
+// @Param sort query string false "排序，如 name,-created_at；可选字段 name、email、status、role、created_at、updated_at"
 // @Success 200 {object} dto.Response{data=dto.UserListResponse}
 // @Router /api/v1/users [get]
 func (h *UserHandler) ListUsers(c *gin.Context) {
-	var req dto.PageRequest
+	var req dto.ListUsersRequest
 	if err := c.ShouldBindQuery(&req); err != nil {
 		h.HandleBadRequest(c, err.Error())
 		return
@@ -113,3 +118,253 @@ func (h *UserHandler) ListUsers(c *gin.Context) {
 
 	h.HandleSuccessWithPage(c, result.List, result.Page.Page, result.Page.PageSize, result.Page.Total)
 }
+
+// UpdateUser 更新用户
+// @Summary 更新用户
+// @Description 管理员修改用户邮箱、显示名称、角色和状态，修改角色或禁用后该用户需要重新登录
+// @Tags 用户
+// @Accept json
+// @Produce json
+// @Param id path int true "用户ID"
+// @Param user body dto.UpdateUserRequest true "用户信息"
+// @Success 200 {object} dto.Response{data=dto.UserResponse}
+// @Failure 400 {object} dto.Response
+// @Failure 404 {object} dto.Response
+// @Router /api/v1/users/{id} [put]
+func (h *UserHandler) UpdateUser(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		h.HandleBadRequest(c, "无效的用户ID")
+		return
+	}
+	var req dto.UpdateUserRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.HandleBadRequest(c, err.Error())
+		return
+	}
+
+	user, err := h.userService.UpdateUser(c.Request.Context(), id, req)
+	if err != nil {
+		if appErr, ok := err.(*apperrors.AppError); ok {
+			h.HandleError(c, appErr.Code, appErr.Message)
+		} else {
+			h.HandleInternalError(c, err.Error())
+		}
+		return
+	}
+
+	h.HandleSuccess(c, user)
+}
+
+// PatchUser 部分更新用户
+// @Summary 部分更新用户
+// @Description 使用 JSON Merge Patch（application/merge-patch+json）或 JSON Patch（application/json-patch+json）修改用户的 email、display_name、role、status；
+// @Description 修改角色或禁用后该用户需要重新登录
+// @Tags 用户
+// @Accept json
+// @Produce json
+// @Param id path int true "用户ID"
+// @Success 200 {object} dto.Response{data=dto.UserResponse}
+// @Failure 400 {object} dto.Response
+// @Failure 404 {object} dto.Response
+// @Failure 409 {object} dto.Response
+// @Failure 415 {object} dto.Response
+// @Router /api/v1/users/{id} [patch]
+func (h *UserHandler) PatchUser(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		h.HandleBadRequest(c, "无效的用户ID")
+		return
+	}
+	patch, err := bindPatch(c)
+	if err != nil {
+		if appErr, ok := err.(*apperrors.AppError); ok {
+			h.HandleError(c, appErr.Code, appErr.Message)
+		} else {
+			h.HandleInternalError(c, err.Error())
+		}
+		return
+	}
+
+	user, err := h.userService.PatchUser(c.Request.Context(), id, patch)
+	if err != nil {
+		if appErr, ok := err.(*apperrors.AppError); ok {
+			h.HandleError(c, appErr.Code, appErr.Message)
+		} else {
+			h.HandleInternalError(c, err.Error())
+		}
+		return
+	}
+
+	h.HandleSuccess(c, user)
+}
+
+// DisableUser 禁用用户
+// @Summary 禁用用户
+// @Description 禁用用户并立即吊销其全部登录会话
+// @Tags 用户
+// @Produce json
+// @Param id path int true "用户ID"
+// @Success 200 {object} dto.Response{data=dto.UserResponse}
+// @Failure 400 {object} dto.Response
+// @Failure 404 {object} dto.Response
+// @Router /api/v1/users/{id}/disable [post]
+func (h *UserHandler) DisableUser(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		h.HandleBadRequest(c, "无效的用户ID")
+		return
+	}
+
+	user, err := h.userService.DisableUser(c.Request.Context(), id)
+	if err != nil {
+		if appErr, ok := err.(*apperrors.AppError); ok {
+			h.HandleError(c, appErr.Code, appErr.Message)
+		} else {
+			h.HandleInternalError(c, err.Error())
+		}
+		return
+	}
+
+	h.HandleSuccess(c, user)
+}
+
+// EnableUser 启用用户
+// @Summary 启用用户
+// @Description 重新启用已禁用的用户
+// @Tags 用户
+// @Produce json
+// @Param id path int true "用户ID"
+// @Success 200 {object} dto.Response{data=dto.UserResponse}
+// @Failure 400 {object} dto.Response
+// @Failure 404 {object} dto.Response
+// @Router /api/v1/users/{id}/enable [post]
+func (h *UserHandler) EnableUser(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		h.HandleBadRequest(c, "无效的用户ID")
+		return
+	}
+
+	user, err := h.userService.EnableUser(c.Request.Context(), id)
+	if err != nil {
+		if appErr, ok := err.(*apperrors.AppError); ok {
+			h.HandleError(c, appErr.Code, appErr.Message)
+		} else {
+			h.HandleInternalError(c, err.Error())
+		}
+		return
+	}
+
+	h.HandleSuccess(c, user)
+}
+
+// DeleteUser 删除用户
+// @Summary 删除用户
+// @Description 软删除用户并吊销其全部登录会话
+// @Tags 用户
+// @Produce json
+// @Param id path int true "用户ID"
+// @Success 200 {object} dto.Response
+// @Failure 400 {object} dto.Response
+// @Failure 404 {object} dto.Response
+// @Router /api/v1/users/{id} [delete]
+func (h *UserHandler) DeleteUser(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		h.HandleBadRequest(c, "无效的用户ID")
+		return
+	}
+
+	if err := h.userService.DeleteUser(c.Request.Context(), id); err != nil {
+		if appErr, ok := err.(*apperrors.AppError); ok {
+			h.HandleError(c, appErr.Code, appErr.Message)
+		} else {
+			h.HandleInternalError(c, err.Error())
+		}
+		return
+	}
+
+	h.HandleSuccess(c, nil)
+}
+
+// GetProfile 获取个人资料
+// @Summary 获取个人资料
+// @Description 获取当前登录用户的信息
+// @Tags 用户
+// @Produce json
+// @Success 200 {object} dto.Response{data=dto.UserResponse}
+// @Router /api/v1/users/me [get]
+func (h *UserHandler) GetProfile(c *gin.Context) {
+	user, err := h.userService.GetProfile(c.Request.Context())
+	if err != nil {
+		if appErr, ok := err.(*apperrors.AppError); ok {
+			h.HandleError(c, appErr.Code, appErr.Message)
+		} else {
+			h.HandleInternalError(c, err.Error())
+		}
+		return
+	}
+
+	h.HandleSuccess(c, user)
+}
+
+// UpdateProfile 更新个人资料
+// @Summary 更新个人资料
+// @Description 修改当前登录用户的显示名称和头像
+// @Tags 用户
+// @Accept json
+// @Produce json
+// @Param profile body dto.UpdateProfileRequest true "个人资料"
+// @Success 200 {object} dto.Response{data=dto.UserResponse}
+// @Failure 400 {object} dto.Response
+// @Router /api/v1/users/me [put]
+func (h *UserHandler) UpdateProfile(c *gin.Context) {
+	var req dto.UpdateProfileRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.HandleBadRequest(c, err.Error())
+		return
+	}
+
+	user, err := h.userService.UpdateProfile(c.Request.Context(), req)
+	if err != nil {
+		if appErr, ok := err.(*apperrors.AppError); ok {
+			h.HandleError(c, appErr.Code, appErr.Message)
+		} else {
+			h.HandleInternalError(c, err.Error())
+		}
+		return
+	}
+
+	h.HandleSuccess(c, user)
+}
+
+// ChangePassword 修改密码
+// @Summary 修改密码
+// @Description 校验当前密码后修改密码，其他设备上的登录会话全部失效，返回新的Token
+// @Tags 用户
+// @Accept json
+// @Produce json
+// @Param body body dto.ChangePasswordRequest true "密码"
+// @Success 200 {object} dto.Response{data=dto.LoginResponse}
+// @Failure 400 {object} dto.Response
+// @Router /api/v1/users/me/password [post]
+func (h *UserHandler) ChangePassword(c *gin.Context) {
+	var req dto.ChangePasswordRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.HandleBadRequest(c, err.Error())
+		return
+	}
+
+	resp, err := h.userService.ChangePassword(c.Request.Context(), req)
+	if err != nil {
+		if appErr, ok := err.(*apperrors.AppError); ok {
+			h.HandleError(c, appErr.Code, appErr.Message)
+		} else {
+			h.HandleInternalError(c, err.Error())
+		}
+		return
+	}
+
+	h.HandleSuccess(c, resp)
+}
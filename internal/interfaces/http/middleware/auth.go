@@ -6,13 +6,15 @@ import (
 	"strings"
 
 	"FLOWGO/internal/application/dto"
-	"FLOWGO/pkg/jwt"
+	"FLOWGO/internal/application/service"
+	"FLOWGO/pkg/contextutil"
+	apperrors "FLOWGO/pkg/errors"
 
 	"github.com/gin-gonic/gin"
 )
 
 // Auth JWT认证中间件
-func Auth() gin.HandlerFunc {
+func Auth(authService *service.AuthService) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		// 从Header中获取Token
 		authHeader := c.GetHeader("Authorization")
@@ -30,18 +32,47 @@ func Auth() gin.HandlerFunc {
 			return
 		}
 
-		// 解析Token
-		claims, err := jwt.ParseToken(parts[1])
+		// 解析Token（JWT或个人访问令牌）并检查是否已被吊销
+		principal, err := authService.Authenticate(c.Request.Context(), parts[1])
 		if err != nil {
-			c.JSON(http.StatusUnauthorized, dto.Error(401, "Token无效或已过期"))
+			code, message := http.StatusUnauthorized, "Token无效或已过期"
+			if appErr, ok := err.(*apperrors.AppError); ok {
+				code, message = appErr.Code, appErr.Message
+			}
+			c.JSON(code, dto.Error(code, message))
 			c.Abort()
 			return
 		}
 
-		// 将用户信息存储到上下文中
-		c.Set("user_id", claims.UserID)
-		c.Set("username", claims.Username)
+		if principal.Scopes != nil {
+			// 个人访问令牌默认拒绝：只有 RequirePermission 按权限范围放行后才写入用户身份，
+			// 未声明权限的路由（如个人资料、退出登录）取不到当前用户
+			c.Set(patPrincipalKey, principal)
+			c.Next()
+			if _, ok := c.Get(scopeCheckedKey); !ok && !c.Writer.Written() {
+				c.JSON(http.StatusForbidden, dto.Error(apperrors.ErrForbidden.Code, "个人访问令牌不能访问该接口"))
+			}
+			return
+		}
 
+		setPrincipal(c, principal)
 		c.Next()
 	}
 }
+
+// setPrincipal 将认证主体写入 gin 上下文和请求上下文，应用服务通过 c.Request.Context() 读取
+func setPrincipal(c *gin.Context, principal *service.Principal) {
+	c.Set(contextutil.UserIDKey, principal.UserID)
+	c.Set("username", principal.Username)
+	c.Set(contextutil.TokenIDKey, principal.TokenID)
+	c.Set(contextutil.RoleKey, principal.Role)
+
+	ctx := contextutil.WithUserID(c.Request.Context(), principal.UserID)
+	ctx = contextutil.WithTokenID(ctx, principal.TokenID)
+	ctx = contextutil.WithRole(ctx, principal.Role)
+	if principal.Scopes != nil {
+		c.Set(contextutil.ScopesKey, principal.Scopes)
+		ctx = contextutil.WithScopes(ctx, principal.Scopes)
+	}
+	c.Request = c.Request.WithContext(ctx)
+}
@@ -0,0 +1,52 @@
+package middleware
+
+import (
+	"net/http"
+	"slices"
+
+	"FLOWGO/internal/application/dto"
+	"FLOWGO/internal/application/service"
+	"FLOWGO/internal/domain/permission"
+	"FLOWGO/pkg/contextutil"
+	apperrors "FLOWGO/pkg/errors"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	// patPrincipalKey Auth 认证个人访问令牌后暂存的认证主体，RequirePermission 校验权限范围后才写入用户身份
+	patPrincipalKey = "pat_principal"
+
+	// scopeCheckedKey RequirePermission 放行个人访问令牌后写入的标记，值为路由声明的权限
+	scopeCheckedKey = "scope_checked"
+)
+
+// RequirePermission 权限校验中间件，需放在 Auth 之后
+// 使用个人访问令牌时，还要求令牌的权限范围包含该权限，通过后才把令牌的用户身份写入上下文
+func RequirePermission(perm permission.Permission) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if value, ok := c.Get(patPrincipalKey); ok {
+			principal := value.(*service.Principal)
+			if !permission.ParseRole(principal.Role).Can(perm) || !slices.Contains(principal.Scopes, string(perm)) {
+				forbid(c)
+				return
+			}
+			setPrincipal(c, principal)
+			c.Set(scopeCheckedKey, perm)
+			c.Next()
+			return
+		}
+
+		role := permission.ParseRole(c.GetString(contextutil.RoleKey))
+		if !role.Can(perm) {
+			forbid(c)
+			return
+		}
+		c.Next()
+	}
+}
+
+func forbid(c *gin.Context) {
+	c.JSON(http.StatusForbidden, dto.Error(apperrors.ErrForbidden.Code, apperrors.ErrForbidden.Message))
+	c.Abort()
+}
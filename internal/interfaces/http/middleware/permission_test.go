@@ -0,0 +1,124 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"FLOWGO/internal/application/service"
+	"FLOWGO/internal/domain/entity"
+	"FLOWGO/internal/domain/permission"
+	"FLOWGO/internal/domain/repository"
+	"FLOWGO/pkg/contextutil"
+	"FLOWGO/pkg/utils"
+)
+
+const (
+	testUserID   = 7
+	testPATPlain = service.AccessTokenPrefix + "test-token"
+)
+
+// fakeUserRepo 只实现令牌认证用到的方法
+type fakeUserRepo struct {
+	repository.UserRepository
+	user *entity.User
+}
+
+func (r *fakeUserRepo) FindByID(ctx context.Context, id uint64) (*entity.User, error) {
+	if r.user != nil && r.user.ID == id {
+		return r.user, nil
+	}
+	return nil, nil
+}
+
+// fakeAccessTokenRepo 只保存一个个人访问令牌
+type fakeAccessTokenRepo struct {
+	repository.AccessTokenRepository
+	token *entity.AccessToken
+}
+
+func (r *fakeAccessTokenRepo) FindByHash(ctx context.Context, tokenHash string) (*entity.AccessToken, error) {
+	if r.token != nil && r.token.TokenHash == tokenHash {
+		return r.token, nil
+	}
+	return nil, nil
+}
+
+func (r *fakeAccessTokenRepo) TouchLastUsed(ctx context.Context, id uint64, usedAt time.Time) error {
+	r.token.LastUsedAt = &usedAt
+	return nil
+}
+
+// newTestRouter 注册一个声明了 project:read 的路由和两个未声明权限的路由，
+// 处理函数和应用服务一样先从请求上下文读取用户ID，读不到时返回401
+func newTestRouter(scopes []string) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+
+	user := &entity.User{Name: "alice", Status: entity.UserStatusActive, Role: string(permission.RoleMember)}
+	user.ID = testUserID
+	token := &entity.AccessToken{UserID: testUserID, Name: "ci", TokenHash: utils.HashToken(testPATPlain), Scopes: scopes}
+	authService := service.NewAuthService(&fakeUserRepo{user: user}, nil, &fakeAccessTokenRepo{token: token}, nil, nil)
+
+	handler := func(c *gin.Context) {
+		userID, err := contextutil.GetUserID(c.Request.Context())
+		if err != nil {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusOK, gin.H{"user_id": userID})
+	}
+
+	r := gin.New()
+	authRequired := r.Group("/", Auth(authService))
+	authRequired.GET("/projects", RequirePermission(permission.ProjectRead), handler)
+	authRequired.GET("/users/me", handler)
+	authRequired.GET("/ping", func(c *gin.Context) {})
+	return r
+}
+
+func doRequest(r *gin.Engine, path string) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, path, nil)
+	req.Header.Set("Authorization", "Bearer "+testPATPlain)
+	r.ServeHTTP(w, req)
+	return w
+}
+
+// TestPATDeclaredRoute 个人访问令牌的权限范围包含路由声明的权限时放行，并写入用户身份
+func TestPATDeclaredRoute(t *testing.T) {
+	r := newTestRouter([]string{string(permission.ProjectRead)})
+
+	w := doRequest(r, "/projects")
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	if body := w.Body.String(); body != `{"user_id":7}` {
+		t.Fatalf("handler must see the token user, got %s", body)
+	}
+}
+
+// TestPATMissingScope 权限范围不包含路由声明的权限时拒绝
+func TestPATMissingScope(t *testing.T) {
+	r := newTestRouter([]string{string(permission.TaskRead)})
+
+	if w := doRequest(r, "/projects"); w.Code != http.StatusForbidden {
+		t.Fatalf("expected 403, got %d: %s", w.Code, w.Body.String())
+	}
+}
+
+// TestPATUndeclaredRoute 未声明权限的路由取不到令牌的用户身份，处理函数没有响应时由 Auth 返回403
+func TestPATUndeclaredRoute(t *testing.T) {
+	r := newTestRouter([]string{string(permission.ProjectRead)})
+
+	w := doRequest(r, "/users/me")
+	if w.Code != http.StatusUnauthorized {
+		t.Fatalf("handler must not see the token user, got %d: %s", w.Code, w.Body.String())
+	}
+	if w := doRequest(r, "/ping"); w.Code != http.StatusForbidden {
+		t.Fatalf("expected 403, got %d: %s", w.Code, w.Body.String())
+	}
+}
@@ -1,6 +1,8 @@
 package router
 
 import (
+	"FLOWGO/internal/application/service"
+	"FLOWGO/internal/domain/permission"
 	"FLOWGO/internal/interfaces/http/handler"
 	"FLOWGO/internal/interfaces/http/middleware"
 
@@ -9,10 +11,24 @@ import (
 
 // SetupRouter 设置路由
 func SetupRouter(
+	authService *service.AuthService,
 	authHandler *handler.AuthHandler,
 	userHandler *handler.UserHandler,
 	projectHandler *handler.ProjectsHandler,
 	statsHandler *handler.StatsHandler,
+	accessTokenHandler *handler.AccessTokenHandler,
+	jwksHandler *handler.JWKSHandler,
+	accountHandler *handler.AccountHandler,
+	twoFactorHandler *handler.TwoFactorHandler,
+	securityHandler *handler.SecurityHandler,
+	oidcHandler *handler.OIDCHandler,
+	teamHandler *handler.TeamHandler,
+	invitationHandler *handler.InvitationHandler,
+	taskHandler *handler.TaskHandler,
+	boardHandler *handler.BoardHandler,
+	tagHandler *handler.TagHandler,
+	milestoneHandler *handler.MilestoneHandler,
+	trashHandler *handler.TrashHandler,
 ) *gin.Engine {
 	r := gin.New()
 
@@ -22,11 +38,16 @@ func SetupRouter(
 	r.Use(middleware.CORS())
 	r.Use(middleware.VisitLogger())
 
+	authRequired := middleware.Auth(authService)
+
 	// 健康检查
 	r.GET("/health", func(c *gin.Context) {
 		c.JSON(200, gin.H{"status": "ok"})
 	})
 
+	// 公钥集合，供其他服务验证Token
+	r.GET("/.well-known/jwks.json", jwksHandler.GetJWKS)
+
 	// API v1 路由组
 	v1 := r.Group("/api/v1")
 	{
@@ -34,37 +55,151 @@ func SetupRouter(
 		auth := v1.Group("/auth")
 		{
 			auth.POST("/login", authHandler.Login)
+			auth.POST("/refresh", authHandler.Refresh)
+			auth.POST("/logout", authRequired, authHandler.Logout)
+			auth.POST("/forgot-password", accountHandler.ForgotPassword)
+			auth.POST("/reset-password", accountHandler.ResetPassword)
+			auth.POST("/verify-email", accountHandler.VerifyEmail)
+			auth.POST("/verify-email/send", authRequired, accountHandler.SendEmailVerification)
+			auth.POST("/2fa", authHandler.LoginTwoFactor)
+			auth.POST("/2fa/setup", authRequired, twoFactorHandler.Setup)
+			auth.POST("/2fa/confirm", authRequired, twoFactorHandler.Confirm)
+			auth.POST("/2fa/disable", authRequired, twoFactorHandler.Disable)
+			auth.POST("/2fa/recovery-codes", authRequired, twoFactorHandler.RegenerateRecoveryCodes)
+			auth.GET("/oidc/providers", oidcHandler.ListProviders)
+			auth.GET("/oidc/:provider/login", oidcHandler.Login)
+			auth.GET("/oidc/:provider/callback", oidcHandler.Callback)
+			auth.POST("/oidc/exchange", oidcHandler.Exchange)
+			auth.GET("/invitations/preview", invitationHandler.PreviewInvitation)
+			auth.POST("/invitations/accept", invitationHandler.AcceptInvitation)
 		}
 
 		// 项目相关路由
 		projects := v1.Group("/projects")
-		projects.Use(middleware.Auth())
+		projects.Use(authRequired)
 		{
-			projects.GET("", projectHandler.ListProjects)
-			projects.POST("", projectHandler.CreateProject)
-			projects.GET("/:id", projectHandler.GetProject)
-			projects.PUT("/:id", projectHandler.UpdateProject)
-			projects.DELETE("/:id", projectHandler.DeleteProject)
-			projects.GET("/teams/available", projectHandler.ProjectTeams)
-			projects.GET("/users/available/:id", projectHandler.ProjectAvailableUsers)
-			projects.POST("/:id/users", projectHandler.AddProjectUsers)
-			projects.DELETE("/:id/users/:uid", projectHandler.RemoveProjectUser)
+			projects.GET("", middleware.RequirePermission(permission.ProjectRead), projectHandler.ListProjects)
+			projects.POST("", middleware.RequirePermission(permission.ProjectCreate), projectHandler.CreateProject)
+			projects.GET("/:id", middleware.RequirePermission(permission.ProjectRead), projectHandler.GetProject)
+			projects.PUT("/:id", middleware.RequirePermission(permission.ProjectUpdate), projectHandler.UpdateProject)
+			projects.PATCH("/:id", middleware.RequirePermission(permission.ProjectUpdate), projectHandler.PatchProject)
+			projects.DELETE("/:id", middleware.RequirePermission(permission.ProjectDelete), projectHandler.DeleteProject)
+			projects.POST("/:id/status", middleware.RequirePermission(permission.ProjectUpdate), projectHandler.ChangeProjectStatus)
+			projects.GET("/:id/status/history", middleware.RequirePermission(permission.ProjectRead), projectHandler.ListProjectStatusChanges)
+			projects.GET("/teams/available", middleware.RequirePermission(permission.TeamRead), projectHandler.ProjectTeams)
+			projects.GET("/users/available/:id", middleware.RequirePermission(permission.ProjectManageMembers), projectHandler.ProjectAvailableUsers)
+			projects.POST("/:id/users", middleware.RequirePermission(permission.ProjectManageMembers), projectHandler.AddProjectUsers)
+			projects.DELETE("/:id/users/:uid", middleware.RequirePermission(permission.ProjectManageMembers), projectHandler.RemoveProjectUser)
+			projects.PUT("/:id/users/:uid/role", middleware.RequirePermission(permission.ProjectManageMembers), projectHandler.UpdateProjectUserRole)
+
+			// 项目内的任务
+			projects.GET("/:id/tasks", middleware.RequirePermission(permission.TaskRead), taskHandler.ListTasks)
+			projects.POST("/:id/tasks", middleware.RequirePermission(permission.TaskWrite), taskHandler.CreateTask)
+			projects.GET("/:id/tasks/:tid", middleware.RequirePermission(permission.TaskRead), taskHandler.GetTask)
+			projects.PUT("/:id/tasks/:tid", middleware.RequirePermission(permission.TaskWrite), taskHandler.UpdateTask)
+			projects.DELETE("/:id/tasks/:tid", middleware.RequirePermission(permission.TaskWrite), taskHandler.DeleteTask)
+
+			// 项目看板
+			projects.GET("/:id/boards", middleware.RequirePermission(permission.TaskRead), boardHandler.ListBoards)
+			projects.POST("/:id/boards", middleware.RequirePermission(permission.TaskWrite), boardHandler.CreateBoard)
+			projects.GET("/:id/boards/:bid", middleware.RequirePermission(permission.TaskRead), boardHandler.GetBoard)
+			projects.PUT("/:id/boards/:bid", middleware.RequirePermission(permission.TaskWrite), boardHandler.UpdateBoard)
+			projects.DELETE("/:id/boards/:bid", middleware.RequirePermission(permission.TaskWrite), boardHandler.DeleteBoard)
+			projects.POST("/:id/boards/:bid/move", middleware.RequirePermission(permission.TaskWrite), boardHandler.MoveCard)
+
+			// 项目里程碑
+			projects.GET("/:id/milestones", middleware.RequirePermission(permission.ProjectRead), milestoneHandler.ListMilestones)
+			projects.POST("/:id/milestones", middleware.RequirePermission(permission.ProjectUpdate), milestoneHandler.CreateMilestone)
+			projects.PUT("/:id/milestones/order", middleware.RequirePermission(permission.ProjectUpdate), milestoneHandler.ReorderMilestones)
+			projects.GET("/:id/milestones/:mid", middleware.RequirePermission(permission.ProjectRead), milestoneHandler.GetMilestone)
+			projects.PUT("/:id/milestones/:mid", middleware.RequirePermission(permission.ProjectUpdate), milestoneHandler.UpdateMilestone)
+			projects.DELETE("/:id/milestones/:mid", middleware.RequirePermission(permission.ProjectUpdate), milestoneHandler.DeleteMilestone)
 		}
 
 		// 用户相关路由
 		users := v1.Group("/users")
-		users.Use(middleware.Auth())
+		users.Use(authRequired)
+		{
+			users.POST("", middleware.RequirePermission(permission.UserCreate), userHandler.CreateUser)
+			users.GET("", middleware.RequirePermission(permission.UserRead), userHandler.ListUsers)
+			users.GET("/:id", middleware.RequirePermission(permission.UserRead), userHandler.GetUser)
+			users.PUT("/:id", middleware.RequirePermission(permission.UserUpdate), userHandler.UpdateUser)
+			users.PATCH("/:id", middleware.RequirePermission(permission.UserUpdate), userHandler.PatchUser)
+			users.DELETE("/:id", middleware.RequirePermission(permission.UserDelete), userHandler.DeleteUser)
+			users.POST("/:id/disable", middleware.RequirePermission(permission.UserUpdate), userHandler.DisableUser)
+			users.POST("/:id/enable", middleware.RequirePermission(permission.UserUpdate), userHandler.EnableUser)
+			users.POST("/:id/unlock", middleware.RequirePermission(permission.UserUpdate), securityHandler.UnlockUser)
+
+			// 个人资料
+			users.GET("/me", userHandler.GetProfile)
+			users.PUT("/me", userHandler.UpdateProfile)
+			users.POST("/me/password", userHandler.ChangePassword)
+
+			// 个人访问令牌
+			users.GET("/me/tokens", accessTokenHandler.ListTokens)
+			users.POST("/me/tokens", accessTokenHandler.CreateToken)
+			users.DELETE("/me/tokens/:id", accessTokenHandler.RevokeToken)
+		}
+
+		// 标签相关路由，给项目设置标签走项目更新接口
+		tags := v1.Group("/tags")
+		tags.Use(authRequired)
+		{
+			tags.GET("", middleware.RequirePermission(permission.ProjectRead), tagHandler.SuggestTags)
+			tags.POST("", middleware.RequirePermission(permission.ProjectUpdate), tagHandler.CreateTag)
+			tags.PUT("/:id", middleware.RequirePermission(permission.TagManage), tagHandler.UpdateTag)
+			tags.DELETE("/:id", middleware.RequirePermission(permission.TagManage), tagHandler.DeleteTag)
+		}
+
+		// 回收站相关路由，删除项目和用户后在保留期内可以恢复
+		trash := v1.Group("/trash")
+		trash.Use(authRequired)
+		{
+			trash.GET("/projects", middleware.RequirePermission(permission.ProjectDelete), trashHandler.ListProjects)
+			trash.POST("/projects/:id/restore", middleware.RequirePermission(permission.ProjectDelete), trashHandler.RestoreProject)
+			trash.GET("/users", middleware.RequirePermission(permission.UserDelete), trashHandler.ListUsers)
+			trash.POST("/users/:id/restore", middleware.RequirePermission(permission.UserDelete), trashHandler.RestoreUser)
+		}
+
+		// 团队相关路由
+		teams := v1.Group("/teams")
+		teams.Use(authRequired)
 		{
-			users.POST("", userHandler.CreateUser)
-			users.GET("", userHandler.ListUsers)
-			users.GET("/:id", userHandler.GetUser)
+			teams.GET("", middleware.RequirePermission(permission.TeamRead), teamHandler.ListTeams)
+			teams.POST("", middleware.RequirePermission(permission.TeamManage), teamHandler.CreateTeam)
+			teams.GET("/tree", middleware.RequirePermission(permission.TeamRead), teamHandler.GetTeamTree)
+			teams.GET("/:id", middleware.RequirePermission(permission.TeamRead), teamHandler.GetTeam)
+			teams.PUT("/:id", middleware.RequirePermission(permission.TeamManage), teamHandler.UpdateTeam)
+			teams.DELETE("/:id", middleware.RequirePermission(permission.TeamManage), teamHandler.DeleteTeam)
+			teams.POST("/:id/transfer", middleware.RequirePermission(permission.TeamManage), teamHandler.TransferOwner)
+			teams.GET("/:id/members", middleware.RequirePermission(permission.TeamRead), teamHandler.ListMembers)
+			teams.POST("/:id/members", middleware.RequirePermission(permission.TeamManage), teamHandler.AddMembers)
+			teams.PUT("/:id/members/:uid/role", middleware.RequirePermission(permission.TeamManage), teamHandler.UpdateMemberRole)
+			teams.DELETE("/:id/members/:uid", middleware.RequirePermission(permission.TeamManage), teamHandler.RemoveMember)
+		}
+
+		// 邀请相关路由，项目负责人也可以发出邀请，具体权限在服务中校验
+		invitations := v1.Group("/invitations")
+		invitations.Use(authRequired)
+		{
+			invitations.POST("", invitationHandler.CreateInvitation)
+			invitations.GET("", middleware.RequirePermission(permission.UserCreate), invitationHandler.ListInvitations)
+			invitations.POST("/:id/resend", middleware.RequirePermission(permission.UserCreate), invitationHandler.ResendInvitation)
+			invitations.POST("/:id/revoke", middleware.RequirePermission(permission.UserCreate), invitationHandler.RevokeInvitation)
 		}
 
 		// 统计 API
 		stats := v1.Group("/stats")
-		stats.Use(middleware.Auth())
+		stats.Use(authRequired)
+		{
+			stats.GET("/visits", middleware.RequirePermission(permission.StatsRead), statsHandler.GetVisitStats)
+		}
+
+		// 安全审计
+		security := v1.Group("/security")
+		security.Use(authRequired)
 		{
-			stats.GET("/visits", statsHandler.GetVisitStats)
+			security.GET("/events", middleware.RequirePermission(permission.SecurityRead), securityHandler.ListEvents)
 		}
 	}
 
@@ -8,9 +8,57 @@ import (
 )
 
 const (
-	UserIDKey = "user_id"
+	UserIDKey  = "user_id"
+	TokenIDKey = "token_id"
+	RoleKey    = "role"
+	ScopesKey  = "scopes"
 )
 
+// WithUserID 将 UserID 写入标准 context.Context，供应用服务层读取
+func WithUserID(ctx context.Context, userID uint64) context.Context {
+	return context.WithValue(ctx, UserIDKey, userID)
+}
+
+// WithTokenID 将当前访问Token的 jti 写入标准 context.Context
+func WithTokenID(ctx context.Context, tokenID string) context.Context {
+	return context.WithValue(ctx, TokenIDKey, tokenID)
+}
+
+// WithRole 将当前用户的全局角色写入标准 context.Context
+func WithRole(ctx context.Context, role string) context.Context {
+	return context.WithValue(ctx, RoleKey, role)
+}
+
+// GetRole retrieves the global role of the current user from the context.
+func GetRole(ctx context.Context) string {
+	if role, ok := ctx.Value(RoleKey).(string); ok {
+		return role
+	}
+	return ""
+}
+
+// WithScopes 将个人访问令牌的权限范围写入标准 context.Context
+func WithScopes(ctx context.Context, scopes []string) context.Context {
+	return context.WithValue(ctx, ScopesKey, scopes)
+}
+
+// GetScopes retrieves the token scopes from the context.
+// A nil result means the request is not restricted by scopes (e.g. a JWT session).
+func GetScopes(ctx context.Context) []string {
+	if scopes, ok := ctx.Value(ScopesKey).([]string); ok {
+		return scopes
+	}
+	return nil
+}
+
+// GetTokenID retrieves the access token ID (jti) from the context.
+func GetTokenID(ctx context.Context) string {
+	if id, ok := ctx.Value(TokenIDKey).(string); ok {
+		return id
+	}
+	return ""
+}
+
 // GetUserID retrieves the UserID from the context.
 // It checks both gin.Context (Keys) and standard context.Context (Values).
 func GetUserID(ctx context.Context) (uint64, error) {
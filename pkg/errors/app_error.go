@@ -1,12 +1,18 @@
 package errors
 
-import "fmt"
+import (
+	"fmt"
+	"time"
+)
 
 // AppError 应用错误
 type AppError struct {
 	Code    int
 	Message string
 	Err     error
+
+	// RetryAfter 大于0时，处理器会写入 Retry-After 响应头（限流、锁定）
+	RetryAfter time.Duration
 }
 
 // Error 实现error接口
@@ -26,6 +32,15 @@ func NewAppError(code int, message string, err error) *AppError {
 	}
 }
 
+// NewRetryAfterError 创建需要客户端稍后重试的错误
+func NewRetryAfterError(code int, message string, retryAfter time.Duration) *AppError {
+	return &AppError{
+		Code:       code,
+		Message:    message,
+		RetryAfter: retryAfter,
+	}
+}
+
 // 常用错误
 var (
 	ErrNotFound     = NewAppError(404, "资源未找到", nil)
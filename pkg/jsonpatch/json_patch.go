@@ -0,0 +1,386 @@
+package jsonpatch
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// Operation JSON Patch 中的单个操作
+type Operation struct {
+	Op    string
+	Path  string
+	From  string      // move、copy 的来源路径
+	Value interface{} // add、replace、test 的值
+}
+
+// JSONPatch RFC 6902 补丁：按顺序执行的操作列表，任一操作失败时整个补丁不生效
+type JSONPatch []Operation
+
+// NewJSONPatch 解析 JSON Patch 文档并检查每个操作的必填成员，文档必须是数组
+func NewJSONPatch(data []byte) (JSONPatch, error) {
+	var raw []map[string]json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidPatch, err)
+	}
+	// null 也能解码为空切片，需要单独拒绝
+	if raw == nil {
+		return nil, fmt.Errorf("%w: patch document must be an array", ErrInvalidPatch)
+	}
+	patch := make(JSONPatch, len(raw))
+	for i, members := range raw {
+		op := &patch[i]
+		if err := decodeMember(members, "op", &op.Op); err != nil {
+			return nil, fmt.Errorf("%w: operation %d: %v", ErrInvalidPatch, i, err)
+		}
+		if err := decodeMember(members, "path", &op.Path); err != nil {
+			return nil, fmt.Errorf("%w: operation %d: %v", ErrInvalidPatch, i, err)
+		}
+		if _, err := parsePointer(op.Path); err != nil {
+			return nil, fmt.Errorf("%w: operation %d: %v", ErrInvalidPatch, i, err)
+		}
+		switch op.Op {
+		case "add", "replace", "test":
+			value, ok := members["value"]
+			if !ok {
+				return nil, fmt.Errorf("%w: operation %d: missing value", ErrInvalidPatch, i)
+			}
+			v, err := decodeValue(value)
+			if err != nil {
+				return nil, fmt.Errorf("%w: operation %d: %v", ErrInvalidPatch, i, err)
+			}
+			op.Value = v
+		case "move", "copy":
+			if err := decodeMember(members, "from", &op.From); err != nil {
+				return nil, fmt.Errorf("%w: operation %d: %v", ErrInvalidPatch, i, err)
+			}
+			if _, err := parsePointer(op.From); err != nil {
+				return nil, fmt.Errorf("%w: operation %d: %v", ErrInvalidPatch, i, err)
+			}
+		case "remove":
+		default:
+			return nil, fmt.Errorf("%w: operation %d: unknown op %q", ErrInvalidPatch, i, op.Op)
+		}
+	}
+	return patch, nil
+}
+
+// decodeMember 解析操作中必须存在的字符串成员
+func decodeMember(members map[string]json.RawMessage, name string, v *string) error {
+	raw, ok := members[name]
+	if !ok {
+		return fmt.Errorf("missing %s", name)
+	}
+	if err := json.Unmarshal(raw, v); err != nil {
+		return fmt.Errorf("%s must be a string", name)
+	}
+	return nil
+}
+
+// Apply 按顺序执行全部操作
+func (p JSONPatch) Apply(doc []byte) ([]byte, error) {
+	root, err := decodeValue(doc)
+	if err != nil {
+		return nil, err
+	}
+	for i, op := range p {
+		root, err = op.apply(root)
+		if err != nil {
+			return nil, fmt.Errorf("operation %d (%s %s): %w", i, op.Op, op.Path, err)
+		}
+	}
+	return json.Marshal(root)
+}
+
+func (op Operation) apply(root interface{}) (interface{}, error) {
+	path, _ := parsePointer(op.Path)
+	switch op.Op {
+	case "add":
+		return add(root, path, copyValue(op.Value))
+	case "remove":
+		root, _, err := remove(root, path)
+		return root, err
+	case "replace":
+		if _, err := get(root, path); err != nil {
+			return nil, err
+		}
+		return replace(root, path, copyValue(op.Value))
+	case "move":
+		from, _ := parsePointer(op.From)
+		if from.isPrefixOf(path) && len(from) < len(path) {
+			return nil, fmt.Errorf("%w: cannot move %q into its own child", ErrInvalidPatch, op.From)
+		}
+		root, value, err := remove(root, from)
+		if err != nil {
+			return nil, err
+		}
+		return add(root, path, value)
+	case "copy":
+		from, _ := parsePointer(op.From)
+		value, err := get(root, from)
+		if err != nil {
+			return nil, err
+		}
+		return add(root, path, copyValue(value))
+	case "test":
+		value, err := get(root, path)
+		if err != nil {
+			return nil, err
+		}
+		if !equal(value, op.Value) {
+			return nil, ErrTestFailed
+		}
+		return root, nil
+	}
+	return nil, fmt.Errorf("%w: unknown op %q", ErrInvalidPatch, op.Op)
+}
+
+// pointer 解析后的 JSON Pointer（RFC 6901），空切片表示整个文档
+type pointer []string
+
+var pointerUnescaper = strings.NewReplacer("~1", "/", "~0", "~")
+
+func parsePointer(path string) (pointer, error) {
+	if path == "" {
+		return pointer{}, nil
+	}
+	if path[0] != '/' {
+		return nil, fmt.Errorf("invalid JSON pointer %q", path)
+	}
+	tokens := strings.Split(path[1:], "/")
+	for i, token := range tokens {
+		tokens[i] = pointerUnescaper.Replace(token)
+	}
+	return tokens, nil
+}
+
+func (p pointer) isPrefixOf(other pointer) bool {
+	if len(p) > len(other) {
+		return false
+	}
+	for i := range p {
+		if p[i] != other[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func get(node interface{}, path pointer) (interface{}, error) {
+	for _, token := range path {
+		switch n := node.(type) {
+		case map[string]interface{}:
+			child, ok := n[token]
+			if !ok {
+				return nil, fmt.Errorf("%w: path not found", ErrInvalidPatch)
+			}
+			node = child
+		case []interface{}:
+			i, err := arrayIndex(token, len(n)-1)
+			if err != nil {
+				return nil, err
+			}
+			node = n[i]
+		default:
+			return nil, fmt.Errorf("%w: path not found", ErrInvalidPatch)
+		}
+	}
+	return node, nil
+}
+
+// update 找到 path 的父容器交给 fn 修改，返回修改后的文档
+func update(node interface{}, path pointer, fn func(parent interface{}, token string) (interface{}, error)) (interface{}, error) {
+	if len(path) == 1 {
+		return fn(node, path[0])
+	}
+	switch n := node.(type) {
+	case map[string]interface{}:
+		child, ok := n[path[0]]
+		if !ok {
+			return nil, fmt.Errorf("%w: path not found", ErrInvalidPatch)
+		}
+		updated, err := update(child, path[1:], fn)
+		if err != nil {
+			return nil, err
+		}
+		n[path[0]] = updated
+		return n, nil
+	case []interface{}:
+		i, err := arrayIndex(path[0], len(n)-1)
+		if err != nil {
+			return nil, err
+		}
+		updated, err := update(n[i], path[1:], fn)
+		if err != nil {
+			return nil, err
+		}
+		n[i] = updated
+		return n, nil
+	}
+	return nil, fmt.Errorf("%w: path not found", ErrInvalidPatch)
+}
+
+// add 向对象添加或替换字段，向数组插入元素，"-" 表示追加到末尾
+func add(root interface{}, path pointer, value interface{}) (interface{}, error) {
+	if len(path) == 0 {
+		return value, nil
+	}
+	return update(root, path, func(parent interface{}, token string) (interface{}, error) {
+		switch n := parent.(type) {
+		case map[string]interface{}:
+			n[token] = value
+			return n, nil
+		case []interface{}:
+			i := len(n)
+			if token != "-" {
+				var err error
+				if i, err = arrayIndex(token, len(n)); err != nil {
+					return nil, err
+				}
+			}
+			n = append(n, nil)
+			copy(n[i+1:], n[i:])
+			n[i] = value
+			return n, nil
+		}
+		return nil, fmt.Errorf("%w: path not found", ErrInvalidPatch)
+	})
+}
+
+// remove 删除 path 指向的值并返回被删除的值
+func remove(root interface{}, path pointer) (interface{}, interface{}, error) {
+	if len(path) == 0 {
+		return nil, nil, fmt.Errorf("%w: cannot remove the whole document", ErrInvalidPatch)
+	}
+	var removed interface{}
+	root, err := update(root, path, func(parent interface{}, token string) (interface{}, error) {
+		switch n := parent.(type) {
+		case map[string]interface{}:
+			value, ok := n[token]
+			if !ok {
+				return nil, fmt.Errorf("%w: path not found", ErrInvalidPatch)
+			}
+			removed = value
+			delete(n, token)
+			return n, nil
+		case []interface{}:
+			i, err := arrayIndex(token, len(n)-1)
+			if err != nil {
+				return nil, err
+			}
+			removed = n[i]
+			return append(n[:i], n[i+1:]...), nil
+		}
+		return nil, fmt.Errorf("%w: path not found", ErrInvalidPatch)
+	})
+	return root, removed, err
+}
+
+// replace 替换 path 指向的已有值
+func replace(root interface{}, path pointer, value interface{}) (interface{}, error) {
+	if len(path) == 0 {
+		return value, nil
+	}
+	return update(root, path, func(parent interface{}, token string) (interface{}, error) {
+		switch n := parent.(type) {
+		case map[string]interface{}:
+			n[token] = value
+			return n, nil
+		case []interface{}:
+			i, err := arrayIndex(token, len(n)-1)
+			if err != nil {
+				return nil, err
+			}
+			n[i] = value
+			return n, nil
+		}
+		return nil, fmt.Errorf("%w: path not found", ErrInvalidPatch)
+	})
+}
+
+// arrayIndex 解析数组下标，只接受 RFC 6901 规定的 0 或不以 0 开头的十进制数字，max 为允许的最大下标
+func arrayIndex(token string, max int) (int, error) {
+	if !isArrayIndex(token) {
+		return 0, fmt.Errorf("%w: invalid array index %q", ErrInvalidPatch, token)
+	}
+	i, err := strconv.Atoi(token)
+	if err != nil {
+		return 0, fmt.Errorf("%w: invalid array index %q", ErrInvalidPatch, token)
+	}
+	if i > max {
+		return 0, fmt.Errorf("%w: array index %d out of range", ErrInvalidPatch, i)
+	}
+	return i, nil
+}
+
+// isArrayIndex 检查 token 是否匹配 0|[1-9][0-9]*，strconv.Atoi 还接受 "+1"、"-0" 等写法
+func isArrayIndex(token string) bool {
+	if token == "" || (len(token) > 1 && token[0] == '0') {
+		return false
+	}
+	for _, c := range token {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// copyValue 深拷贝 JSON 值，避免同一个值出现在文档的多个位置
+func copyValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		object := make(map[string]interface{}, len(v))
+		for name, child := range v {
+			object[name] = copyValue(child)
+		}
+		return object
+	case []interface{}:
+		array := make([]interface{}, len(v))
+		for i, child := range v {
+			array[i] = copyValue(child)
+		}
+		return array
+	}
+	return value
+}
+
+// equal 按 RFC 6902 test 操作的规则比较两个 JSON 值，数字按数值比较
+func equal(a, b interface{}) bool {
+	switch x := a.(type) {
+	case map[string]interface{}:
+		y, ok := b.(map[string]interface{})
+		if !ok || len(x) != len(y) {
+			return false
+		}
+		for name, value := range x {
+			other, ok := y[name]
+			if !ok || !equal(value, other) {
+				return false
+			}
+		}
+		return true
+	case []interface{}:
+		y, ok := b.([]interface{})
+		if !ok || len(x) != len(y) {
+			return false
+		}
+		for i := range x {
+			if !equal(x[i], y[i]) {
+				return false
+			}
+		}
+		return true
+	case json.Number:
+		y, ok := b.(json.Number)
+		if !ok {
+			return false
+		}
+		rx, okx := new(big.Rat).SetString(x.String())
+		ry, oky := new(big.Rat).SetString(y.String())
+		return okx && oky && rx.Cmp(ry) == 0
+	}
+	return a == b
+}
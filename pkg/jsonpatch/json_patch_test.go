@@ -0,0 +1,230 @@
+package jsonpatch
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+// assertJSONEqual 按 JSON 语义比较两个文档，忽略对象成员顺序
+func assertJSONEqual(t *testing.T, got []byte, want string) {
+	t.Helper()
+	var g, w interface{}
+	if err := json.Unmarshal(got, &g); err != nil {
+		t.Fatalf("invalid result %s: %v", got, err)
+	}
+	if err := json.Unmarshal([]byte(want), &w); err != nil {
+		t.Fatalf("invalid expectation %s: %v", want, err)
+	}
+	if !reflect.DeepEqual(g, w) {
+		t.Fatalf("got %s, want %s", got, want)
+	}
+}
+
+// TestJSONPatchRFC6902 RFC 6902 附录 A 的示例
+func TestJSONPatchRFC6902(t *testing.T) {
+	tests := []struct {
+		name  string
+		doc   string
+		patch string
+		want  string // 为空表示应当失败
+		err   error  // 期望的错误，为 nil 时只要求失败
+	}{
+		{
+			name:  "A.1 adding an object member",
+			doc:   `{"foo":"bar"}`,
+			patch: `[{"op":"add","path":"/baz","value":"qux"}]`,
+			want:  `{"baz":"qux","foo":"bar"}`,
+		},
+		{
+			name:  "A.2 adding an array element",
+			doc:   `{"foo":["bar","baz"]}`,
+			patch: `[{"op":"add","path":"/foo/1","value":"qux"}]`,
+			want:  `{"foo":["bar","qux","baz"]}`,
+		},
+		{
+			name:  "A.3 removing an object member",
+			doc:   `{"baz":"qux","foo":"bar"}`,
+			patch: `[{"op":"remove","path":"/baz"}]`,
+			want:  `{"foo":"bar"}`,
+		},
+		{
+			name:  "A.4 removing an array element",
+			doc:   `{"foo":["bar","qux","baz"]}`,
+			patch: `[{"op":"remove","path":"/foo/1"}]`,
+			want:  `{"foo":["bar","baz"]}`,
+		},
+		{
+			name:  "A.5 replacing a value",
+			doc:   `{"baz":"qux","foo":"bar"}`,
+			patch: `[{"op":"replace","path":"/baz","value":"boo"}]`,
+			want:  `{"baz":"boo","foo":"bar"}`,
+		},
+		{
+			name:  "A.6 moving a value",
+			doc:   `{"foo":{"bar":"baz","waldo":"fred"},"qux":{"corge":"grault"}}`,
+			patch: `[{"op":"move","from":"/foo/waldo","path":"/qux/thud"}]`,
+			want:  `{"foo":{"bar":"baz"},"qux":{"corge":"grault","thud":"fred"}}`,
+		},
+		{
+			name:  "A.7 moving an array element",
+			doc:   `{"foo":["all","grass","cows","eat"]}`,
+			patch: `[{"op":"move","from":"/foo/1","path":"/foo/3"}]`,
+			want:  `{"foo":["all","cows","eat","grass"]}`,
+		},
+		{
+			name:  "A.8 testing a value: success",
+			doc:   `{"baz":"qux","foo":["a",2,"c"]}`,
+			patch: `[{"op":"test","path":"/baz","value":"qux"},{"op":"test","path":"/foo/1","value":2}]`,
+			want:  `{"baz":"qux","foo":["a",2,"c"]}`,
+		},
+		{
+			name:  "A.9 testing a value: error",
+			doc:   `{"baz":"qux"}`,
+			patch: `[{"op":"test","path":"/baz","value":"bar"}]`,
+			err:   ErrTestFailed,
+		},
+		{
+			name:  "A.10 adding a nested member object",
+			doc:   `{"foo":"bar"}`,
+			patch: `[{"op":"add","path":"/child","value":{"grandchild":{}}}]`,
+			want:  `{"foo":"bar","child":{"grandchild":{}}}`,
+		},
+		{
+			name:  "A.11 ignoring unrecognized elements",
+			doc:   `{"foo":"bar"}`,
+			patch: `[{"op":"add","path":"/baz","value":"qux","xyz":123}]`,
+			want:  `{"foo":"bar","baz":"qux"}`,
+		},
+		{
+			name:  "A.12 adding to a nonexistent target",
+			doc:   `{"foo":"bar"}`,
+			patch: `[{"op":"add","path":"/baz/bat","value":"qux"}]`,
+			err:   ErrInvalidPatch,
+		},
+		{
+			// 重复的 op 成员按最后一个解析，remove 不存在的 /baz 失败
+			name:  "A.13 invalid JSON Patch document",
+			doc:   `{"foo":"bar"}`,
+			patch: `[{"op":"add","path":"/baz","value":"qux","op":"remove"}]`,
+		},
+		{
+			name:  "A.14 ~ escape ordering",
+			doc:   `{"/":9,"~1":10}`,
+			patch: `[{"op":"test","path":"/~01","value":10}]`,
+			want:  `{"/":9,"~1":10}`,
+		},
+		{
+			name:  "A.15 comparing strings and numbers",
+			doc:   `{"/":9,"~1":10}`,
+			patch: `[{"op":"test","path":"/~01","value":"10"}]`,
+			err:   ErrTestFailed,
+		},
+		{
+			name:  "A.16 adding an array value",
+			doc:   `{"foo":["bar"]}`,
+			patch: `[{"op":"add","path":"/foo/-","value":["abc","def"]}]`,
+			want:  `{"foo":["bar",["abc","def"]]}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := applyJSONPatch(tt.patch, tt.doc)
+			if tt.want == "" {
+				if err == nil {
+					t.Fatalf("expected error, got %s", got)
+				}
+				if tt.err != nil && !errors.Is(err, tt.err) {
+					t.Fatalf("expected %v, got %v", tt.err, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			assertJSONEqual(t, got, tt.want)
+		})
+	}
+}
+
+// TestJSONPatchInvalid 补丁文档和数组下标的格式校验
+func TestJSONPatchInvalid(t *testing.T) {
+	tests := []struct {
+		name  string
+		doc   string
+		patch string
+	}{
+		{"null document", `{}`, `null`},
+		{"object document", `{}`, `{"op":"add","path":"/a","value":1}`},
+		{"missing op", `{}`, `[{"path":"/a","value":1}]`},
+		{"unknown op", `{}`, `[{"op":"merge","path":"/a","value":1}]`},
+		{"missing value", `{}`, `[{"op":"add","path":"/a"}]`},
+		{"missing from", `{"a":1}`, `[{"op":"move","path":"/b"}]`},
+		{"pointer without slash", `{"a":1}`, `[{"op":"remove","path":"a"}]`},
+		{"index with plus sign", `{"a":[1,2]}`, `[{"op":"remove","path":"/a/+1"}]`},
+		{"negative index", `{"a":[1,2]}`, `[{"op":"remove","path":"/a/-0"}]`},
+		{"leading zero index", `{"a":[1,2]}`, `[{"op":"remove","path":"/a/01"}]`},
+		{"index out of range", `{"a":[1,2]}`, `[{"op":"replace","path":"/a/2","value":3}]`},
+		{"move into own child", `{"a":{"b":1}}`, `[{"op":"move","from":"/a","path":"/a/b/c"}]`},
+		{"remove whole document", `{"a":1}`, `[{"op":"remove","path":""}]`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := applyJSONPatch(tt.patch, tt.doc)
+			if !errors.Is(err, ErrInvalidPatch) {
+				t.Fatalf("expected ErrInvalidPatch, got %s, %v", got, err)
+			}
+		})
+	}
+}
+
+// TestJSONPatchCopyIsDeep copy 得到的值与来源互不影响
+func TestJSONPatchCopyIsDeep(t *testing.T) {
+	got, err := applyJSONPatch(
+		`[{"op":"copy","from":"/a","path":"/b"},{"op":"add","path":"/b/x","value":2}]`,
+		`{"a":{"x":1}}`,
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	assertJSONEqual(t, got, `{"a":{"x":1},"b":{"x":2}}`)
+}
+
+// TestJSONPatchNumbers test 操作按数值比较数字，大整数不丢失精度
+func TestJSONPatchNumbers(t *testing.T) {
+	tests := []struct {
+		name  string
+		doc   string
+		value string
+		ok    bool
+	}{
+		{"same integer", `{"n":10}`, `10`, true},
+		{"same value with exponent", `{"n":10}`, `1e1`, true},
+		{"same value with fraction", `{"n":10}`, `10.0`, true},
+		{"different integer", `{"n":10}`, `11`, false},
+		// 两个值转换为 float64 后相等
+		{"large integer", `{"n":9007199254740993}`, `9007199254740992`, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := applyJSONPatch(`[{"op":"test","path":"/n","value":`+tt.value+`}]`, tt.doc)
+			if tt.ok && err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !tt.ok && !errors.Is(err, ErrTestFailed) {
+				t.Fatalf("expected ErrTestFailed, got %v", err)
+			}
+		})
+	}
+}
+
+func applyJSONPatch(patch, doc string) ([]byte, error) {
+	p, err := NewJSONPatch([]byte(patch))
+	if err != nil {
+		return nil, err
+	}
+	return p.Apply([]byte(doc))
+}
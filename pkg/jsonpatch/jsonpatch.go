@@ -0,0 +1,91 @@
+// Package jsonpatch 实现 RFC 7396 JSON Merge Patch 和 RFC 6902 JSON Patch
+//
+// 补丁作用在 JSON 文档上：调用方先把资源转换成文档，应用补丁后再解码回结构体，
+// 由调用方比较前后的差异并决定修改哪些字段。数字按 json.Number 处理，不会丢失精度。
+package jsonpatch
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"mime"
+)
+
+// 补丁的媒体类型
+const (
+	MergePatchMediaType = "application/merge-patch+json"
+	JSONPatchMediaType  = "application/json-patch+json"
+)
+
+var (
+	// ErrUnsupportedMediaType 不支持的补丁媒体类型
+	ErrUnsupportedMediaType = errors.New("jsonpatch: unsupported media type")
+	// ErrInvalidPatch 补丁文档格式错误，或操作的路径不存在
+	ErrInvalidPatch = errors.New("jsonpatch: invalid patch")
+	// ErrTestFailed JSON Patch 的 test 操作未通过
+	ErrTestFailed = errors.New("jsonpatch: test operation failed")
+	// ErrInvalidDocument 应用补丁后的文档无法解码为目标结构
+	ErrInvalidDocument = errors.New("jsonpatch: patched document is invalid")
+)
+
+// Patch 可以应用到 JSON 文档上的补丁
+type Patch interface {
+	Apply(doc []byte) ([]byte, error)
+}
+
+// Decode 按 Content-Type 解析补丁，contentType 可以带 charset 等参数
+func Decode(contentType string, data []byte) (Patch, error) {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %q", ErrUnsupportedMediaType, contentType)
+	}
+	switch mediaType {
+	case MergePatchMediaType:
+		patch, err := NewMergePatch(data)
+		if err != nil {
+			return nil, err
+		}
+		return patch, nil
+	case JSONPatchMediaType:
+		patch, err := NewJSONPatch(data)
+		if err != nil {
+			return nil, err
+		}
+		return patch, nil
+	}
+	return nil, fmt.Errorf("%w: %q", ErrUnsupportedMediaType, mediaType)
+}
+
+// ApplyTo 把 current 编码为 JSON 文档，应用补丁后解码到 target
+// 补丁结果中出现 target 没有的字段或类型不匹配时返回 ErrInvalidDocument
+func ApplyTo(patch Patch, current, target interface{}) error {
+	doc, err := json.Marshal(current)
+	if err != nil {
+		return err
+	}
+	patched, err := patch.Apply(doc)
+	if err != nil {
+		return err
+	}
+	dec := json.NewDecoder(bytes.NewReader(patched))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(target); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidDocument, err)
+	}
+	return nil
+}
+
+// decodeValue 解析 JSON 值，数字保留为 json.Number
+func decodeValue(data []byte) (interface{}, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	var v interface{}
+	if err := dec.Decode(&v); err != nil {
+		return nil, err
+	}
+	if dec.More() {
+		return nil, errors.New("unexpected data after top-level value")
+	}
+	return v, nil
+}
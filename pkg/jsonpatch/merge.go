@@ -0,0 +1,49 @@
+package jsonpatch
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// MergePatch RFC 7396 合并补丁：对象按字段递归合并，值为 null 的字段被删除，其他值直接替换
+type MergePatch struct {
+	patch interface{}
+}
+
+// NewMergePatch 解析合并补丁
+func NewMergePatch(data []byte) (*MergePatch, error) {
+	patch, err := decodeValue(data)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidPatch, err)
+	}
+	return &MergePatch{patch: patch}, nil
+}
+
+// Apply 把补丁合并到文档上
+func (p *MergePatch) Apply(doc []byte) ([]byte, error) {
+	target, err := decodeValue(doc)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(mergeValue(target, p.patch))
+}
+
+// mergeValue 按 RFC 7396 的 MergePatch 算法合并
+func mergeValue(target, patch interface{}) interface{} {
+	fields, ok := patch.(map[string]interface{})
+	if !ok {
+		return patch
+	}
+	object, ok := target.(map[string]interface{})
+	if !ok {
+		object = make(map[string]interface{}, len(fields))
+	}
+	for name, value := range fields {
+		if value == nil {
+			delete(object, name)
+			continue
+		}
+		object[name] = mergeValue(object[name], value)
+	}
+	return object
+}
@@ -0,0 +1,119 @@
+package jsonpatch
+
+import (
+	"errors"
+	"testing"
+)
+
+// TestMergePatchRFC7396 RFC 7396 附录 A 的示例
+func TestMergePatchRFC7396(t *testing.T) {
+	tests := []struct {
+		doc   string
+		patch string
+		want  string
+	}{
+		{`{"a":"b"}`, `{"a":"c"}`, `{"a":"c"}`},
+		{`{"a":"b"}`, `{"b":"c"}`, `{"a":"b","b":"c"}`},
+		{`{"a":"b"}`, `{"a":null}`, `{}`},
+		{`{"a":"b","b":"c"}`, `{"a":null}`, `{"b":"c"}`},
+		{`{"a":["b"]}`, `{"a":"c"}`, `{"a":"c"}`},
+		{`{"a":"c"}`, `{"a":["b"]}`, `{"a":["b"]}`},
+		{`{"a":{"b":"c"}}`, `{"a":{"b":"d","c":null}}`, `{"a":{"b":"d"}}`},
+		{`{"a":[{"b":"c"}]}`, `{"a":[1]}`, `{"a":[1]}`},
+		{`["a","b"]`, `["c","d"]`, `["c","d"]`},
+		{`{"a":"b"}`, `["c"]`, `["c"]`},
+		{`{"a":"foo"}`, `null`, `null`},
+		{`{"a":"foo"}`, `"bar"`, `"bar"`},
+		{`{"e":null}`, `{"a":1}`, `{"e":null,"a":1}`},
+		{`[1,2]`, `{"a":"b","c":null}`, `{"a":"b"}`},
+		{`{}`, `{"a":{"bb":{"ccc":null}}}`, `{"a":{"bb":{}}}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.doc+" + "+tt.patch, func(t *testing.T) {
+			p, err := NewMergePatch([]byte(tt.patch))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			got, err := p.Apply([]byte(tt.doc))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			assertJSONEqual(t, got, tt.want)
+		})
+	}
+}
+
+// TestDecode 按 Content-Type 选择补丁格式
+func TestDecode(t *testing.T) {
+	tests := []struct {
+		name        string
+		contentType string
+		body        string
+		err         error
+	}{
+		{"merge patch", "application/merge-patch+json", `{"a":1}`, nil},
+		{"merge patch with charset", "application/merge-patch+json; charset=utf-8", `{"a":1}`, nil},
+		{"json patch", "application/json-patch+json", `[{"op":"remove","path":"/a"}]`, nil},
+		{"plain json", "application/json", `{"a":1}`, ErrUnsupportedMediaType},
+		{"malformed content type", "application/", `{"a":1}`, ErrUnsupportedMediaType},
+		{"malformed merge patch", "application/merge-patch+json", `{"a":`, ErrInvalidPatch},
+		{"null json patch", "application/json-patch+json", `null`, ErrInvalidPatch},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Decode(tt.contentType, []byte(tt.body))
+			if tt.err == nil && err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if tt.err != nil && !errors.Is(err, tt.err) {
+				t.Fatalf("expected %v, got %v", tt.err, err)
+			}
+		})
+	}
+}
+
+// TestApplyTo 补丁结果中出现未知字段或类型不匹配时返回 ErrInvalidDocument
+func TestApplyTo(t *testing.T) {
+	type document struct {
+		Name string `json:"name"`
+		Size int    `json:"size"`
+	}
+	current := document{Name: "a", Size: 1}
+
+	tests := []struct {
+		name  string
+		patch string
+		want  document
+		err   error
+	}{
+		{"change field", `{"size":2}`, document{Name: "a", Size: 2}, nil},
+		{"clear field", `{"name":null}`, document{Size: 1}, nil},
+		{"unknown field", `{"color":"red"}`, document{}, ErrInvalidDocument},
+		{"type mismatch", `{"size":"big"}`, document{}, ErrInvalidDocument},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p, err := NewMergePatch([]byte(tt.patch))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			var got document
+			err = ApplyTo(p, current, &got)
+			if tt.err != nil {
+				if !errors.Is(err, tt.err) {
+					t.Fatalf("expected %v, got %v", tt.err, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Fatalf("got %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -1,123 +1,116 @@
 package jwt
 
 import (
-	"crypto/rsa"
-	"crypto/x509"
-	"encoding/pem"
+	"crypto/rand"
+	"encoding/hex"
 	"errors"
 	"fmt"
-	"os"
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
 )
 
 var (
-	// 对称密钥（HS256）
-	secretKey = []byte("your-secret-key-change-in-production")
+	// 密钥环，默认只包含一个HS256开发密钥
+	keyring = mustDefaultKeyring("your-secret-key-change-in-production")
 
-	// RSA密钥对（RS256）
-	privateKey *rsa.PrivateKey
-	publicKey  *rsa.PublicKey
-
-	// Token过期时间
+	// 访问Token过期时间
 	tokenExpiration = 24 * time.Hour
 
-	// 签名方法：HS256 或 RS256
-	signingMethod = "HS256"
+	// 刷新Token过期时间
+	refreshTokenExpiration = 7 * 24 * time.Hour
+)
+
+// IssuedAtPrecision 签发时间等时间声明的精度
+// 用户级别的吊销按签发时间比较，秒级精度会让吊销前同一秒内签发的Token逃过吊销
+const IssuedAtPrecision = time.Millisecond
+
+func init() {
+	jwt.TimePrecision = IssuedAtPrecision
+}
+
+// legacyKid 通过 SetSecretKey / SetRSAPrivateKey 配置的单密钥使用的 kid
+const legacyKid = "default"
+
+// Token类型
+const (
+	TokenTypeAccess  = "access"
+	TokenTypeRefresh = "refresh"
+
+	// TokenTypeTwoFactor 两步验证挑战Token，只能用于换取正式Token
+	TokenTypeTwoFactor = "2fa"
 )
 
-// Claims JWT声明
+// TwoFactorTokenExpiration 两步验证挑战Token过期时间
+const TwoFactorTokenExpiration = 5 * time.Minute
+
+// Claims JWT声明，RegisteredClaims.ID 即 jti，用于吊销
 type Claims struct {
-	UserID   uint64 `json:"user_id"`
-	Username string `json:"username"`
+	UserID    uint64 `json:"user_id"`
+	Username  string `json:"username"`
+	Role      string `json:"role"`
+	TokenType string `json:"token_type"`
 	jwt.RegisteredClaims
 }
 
+// SetKeyring 替换全局密钥环，用于配置多密钥轮换
+func SetKeyring(k *Keyring) error {
+	if _, err := k.Active(); err != nil {
+		return err
+	}
+	keyring = k
+	return nil
+}
+
+// GetJWKS 导出当前密钥环中的公钥集合
+func GetJWKS() JWKS {
+	return keyring.JWKS()
+}
+
 // SetSecretKey 设置JWT对称密钥（HS256）
 func SetSecretKey(key string) {
-	secretKey = []byte(key)
-	signingMethod = "HS256"
+	keyring = mustDefaultKeyring(key)
 }
 
 // SetRSAPrivateKey 设置RSA私钥（RS256）
 func SetRSAPrivateKey(privateKeyPath string) error {
-	keyData, err := os.ReadFile(privateKeyPath)
+	key, err := LoadKey(KeyConfig{Kid: legacyKid, Algorithm: "RS256", PrivateKeyLocation: privateKeyPath})
 	if err != nil {
-		return fmt.Errorf("failed to read private key file: %w", err)
+		return err
 	}
-
-	block, _ := pem.Decode(keyData)
-	if block == nil {
-		return errors.New("failed to decode PEM block")
+	k := NewKeyring()
+	if err := k.Add(key); err != nil {
+		return err
 	}
-
-	// 支持PKCS1和PKCS8格式
-	var key *rsa.PrivateKey
-	switch block.Type {
-	case "RSA PRIVATE KEY":
-		// PKCS1格式
-		key, err = x509.ParsePKCS1PrivateKey(block.Bytes)
-		if err != nil {
-			return fmt.Errorf("failed to parse PKCS1 private key: %w", err)
-		}
-	case "PRIVATE KEY":
-		// PKCS8格式
-		parsedKey, err := x509.ParsePKCS8PrivateKey(block.Bytes)
-		if err != nil {
-			return fmt.Errorf("failed to parse PKCS8 private key: %w", err)
-		}
-		var ok bool
-		key, ok = parsedKey.(*rsa.PrivateKey)
-		if !ok {
-			return errors.New("not an RSA private key")
-		}
-	default:
-		return fmt.Errorf("unsupported key type: %s", block.Type)
+	if err := k.SetActive(legacyKid); err != nil {
+		return err
 	}
-
-	privateKey = key
-	signingMethod = "RS256"
+	keyring = k
 	return nil
 }
 
-// SetRSAPublicKey 设置RSA公钥（RS256）
+// SetRSAPublicKey 设置RSA公钥（RS256），需在 SetRSAPrivateKey 之后调用
 func SetRSAPublicKey(publicKeyPath string) error {
-	keyData, err := os.ReadFile(publicKeyPath)
+	key, err := LoadKey(KeyConfig{Kid: legacyKid, Algorithm: "RS256", PublicKeyLocation: publicKeyPath})
 	if err != nil {
-		return fmt.Errorf("failed to read public key file: %w", err)
+		return err
 	}
+	return keyring.Add(key)
+}
 
-	block, _ := pem.Decode(keyData)
-	if block == nil {
-		return errors.New("failed to decode PEM block")
+func mustDefaultKeyring(secret string) *Keyring {
+	k := NewKeyring()
+	key, err := LoadKey(KeyConfig{Kid: legacyKid, Algorithm: "HS256", SecretKey: secret})
+	if err == nil {
+		err = k.Add(key)
 	}
-
-	var pub interface{}
-	switch block.Type {
-	case "PUBLIC KEY":
-		// PKIX格式
-		pub, err = x509.ParsePKIXPublicKey(block.Bytes)
-		if err != nil {
-			return fmt.Errorf("failed to parse PKIX public key: %w", err)
-		}
-	case "RSA PUBLIC KEY":
-		// PKCS1格式
-		pub, err = x509.ParsePKCS1PublicKey(block.Bytes)
-		if err != nil {
-			return fmt.Errorf("failed to parse PKCS1 public key: %w", err)
-		}
-	default:
-		return fmt.Errorf("unsupported key type: %s", block.Type)
+	if err == nil {
+		err = k.SetActive(legacyKid)
 	}
-
-	var ok bool
-	publicKey, ok = pub.(*rsa.PublicKey)
-	if !ok {
-		return errors.New("not an RSA public key")
+	if err != nil {
+		panic(err)
 	}
-
-	return nil
+	return k
 }
 
 // SetTokenExpiration 设置Token过期时间
@@ -125,59 +118,89 @@ func SetTokenExpiration(duration time.Duration) {
 	tokenExpiration = duration
 }
 
-// GenerateToken 生成JWT Token
-func GenerateToken(userID uint64, username string) (string, error) {
+// GetTokenExpiration 获取Token过期时间
+func GetTokenExpiration() time.Duration {
+	return tokenExpiration
+}
+
+// SetRefreshTokenExpiration 设置刷新Token过期时间
+func SetRefreshTokenExpiration(duration time.Duration) {
+	refreshTokenExpiration = duration
+}
+
+// GetRefreshTokenExpiration 获取刷新Token过期时间
+func GetRefreshTokenExpiration() time.Duration {
+	return refreshTokenExpiration
+}
+
+// GenerateToken 生成访问Token
+func GenerateToken(userID uint64, username, role string) (string, error) {
+	return generateToken(userID, username, role, TokenTypeAccess, tokenExpiration)
+}
+
+// GenerateRefreshToken 生成刷新Token
+func GenerateRefreshToken(userID uint64, username, role string) (string, error) {
+	return generateToken(userID, username, role, TokenTypeRefresh, refreshTokenExpiration)
+}
+
+// GenerateTwoFactorToken 生成两步验证挑战Token
+func GenerateTwoFactorToken(userID uint64, username string) (string, error) {
+	return generateToken(userID, username, "", TokenTypeTwoFactor, TwoFactorTokenExpiration)
+}
+
+// generateToken 生成带 jti 的JWT Token
+func generateToken(userID uint64, username, role, tokenType string, expiration time.Duration) (string, error) {
+	jti, err := newTokenID()
+	if err != nil {
+		return "", err
+	}
+
 	now := time.Now()
 	claims := &Claims{
-		UserID:   userID,
-		Username: username,
+		UserID:    userID,
+		Username:  username,
+		Role:      role,
+		TokenType: tokenType,
 		RegisteredClaims: jwt.RegisteredClaims{
-			ExpiresAt: jwt.NewNumericDate(now.Add(tokenExpiration)),
+			ID:        jti,
+			ExpiresAt: jwt.NewNumericDate(now.Add(expiration)),
 			IssuedAt:  jwt.NewNumericDate(now),
 			NotBefore: jwt.NewNumericDate(now),
 			Issuer:    "flowgo",
 		},
 	}
 
-	var token *jwt.Token
-	if signingMethod == "RS256" {
-		if privateKey == nil {
-			return "", errors.New("RSA private key not set")
-		}
-		token = jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
-		return token.SignedString(privateKey)
-	} else {
-		// 默认使用HS256
-		token = jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-		return token.SignedString(secretKey)
+	key, err := keyring.Active()
+	if err != nil {
+		return "", err
 	}
+	token := jwt.NewWithClaims(key.Method, claims)
+	token.Header["kid"] = key.ID
+	return token.SignedString(key.signKey)
 }
 
-// ParseToken 解析JWT Token
+// ParseToken 解析JWT Token，根据 kid 头选择验证密钥
 func ParseToken(tokenString string) (*Claims, error) {
-	var token *jwt.Token
-	var err error
-
-	if signingMethod == "RS256" {
-		if publicKey == nil {
-			return nil, errors.New("RSA public key not set")
-		}
-		token, err = jwt.ParseWithClaims(tokenString, &Claims{}, func(token *jwt.Token) (interface{}, error) {
-			if _, ok := token.Method.(*jwt.SigningMethodRSA); !ok {
-				return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+	token, err := jwt.ParseWithClaims(tokenString, &Claims{}, func(token *jwt.Token) (interface{}, error) {
+		var key *Key
+		if kid, _ := token.Header["kid"].(string); kid != "" {
+			var ok bool
+			if key, ok = keyring.Lookup(kid); !ok {
+				return nil, fmt.Errorf("unknown signing key: %s", kid)
 			}
-			return publicKey, nil
-		})
-	} else {
-		// 默认使用HS256
-		token, err = jwt.ParseWithClaims(tokenString, &Claims{}, func(token *jwt.Token) (interface{}, error) {
-			if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-				return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+		} else {
+			// 兼容引入密钥环之前签发、没有 kid 的Token
+			active, err := keyring.Active()
+			if err != nil {
+				return nil, err
 			}
-			return secretKey, nil
-		})
-	}
-
+			key = active
+		}
+		if token.Method.Alg() != key.Method.Alg() {
+			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+		}
+		return key.verifyKey, nil
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -188,3 +211,12 @@ func ParseToken(tokenString string) (*Claims, error) {
 
 	return nil, errors.New("invalid token")
 }
+
+// newTokenID 生成随机的 jti
+func newTokenID() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate token id: %w", err)
+	}
+	return hex.EncodeToString(b), nil
+}
@@ -0,0 +1,332 @@
+package jwt
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"sort"
+	"sync"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// KeyConfig 单个签名密钥的配置
+// 非对称算法只提供公钥时，该密钥仅用于验证（例如已轮换下线的旧密钥）
+type KeyConfig struct {
+	Kid                string
+	Algorithm          string // HS256, RS256, ES256, EdDSA
+	SecretKey          string // HS256 使用
+	PrivateKeyLocation string
+	PublicKeyLocation  string
+}
+
+// Key 密钥环中的一个密钥
+type Key struct {
+	ID     string
+	Method jwt.SigningMethod
+
+	signKey   interface{} // 为 nil 表示仅用于验证
+	verifyKey interface{}
+}
+
+// CanSign 检查密钥是否可以用于签名
+func (k *Key) CanSign() bool {
+	return k.signKey != nil
+}
+
+// Keyring 密钥环，保存多个验证密钥和一个当前签名密钥
+type Keyring struct {
+	mu       sync.RWMutex
+	keys     map[string]*Key
+	activeID string
+}
+
+// NewKeyring 创建空的密钥环
+func NewKeyring() *Keyring {
+	return &Keyring{keys: make(map[string]*Key)}
+}
+
+// Add 添加密钥，同一 kid 重复添加时会合并签名密钥和验证密钥
+func (r *Keyring) Add(key *Key) error {
+	if key.ID == "" {
+		return errors.New("key id (kid) is required")
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if existing, ok := r.keys[key.ID]; ok {
+		if existing.Method.Alg() != key.Method.Alg() {
+			return fmt.Errorf("key %s already registered with algorithm %s", key.ID, existing.Method.Alg())
+		}
+		if key.signKey != nil {
+			existing.signKey = key.signKey
+		}
+		if key.verifyKey != nil {
+			existing.verifyKey = key.verifyKey
+		}
+		return nil
+	}
+	r.keys[key.ID] = key
+	return nil
+}
+
+// SetActive 设置当前签名密钥
+func (r *Keyring) SetActive(kid string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	key, ok := r.keys[kid]
+	if !ok {
+		return fmt.Errorf("key %s not found", kid)
+	}
+	if !key.CanSign() {
+		return fmt.Errorf("key %s has no private key and cannot sign", kid)
+	}
+	r.activeID = kid
+	return nil
+}
+
+// Active 获取当前签名密钥
+func (r *Keyring) Active() (*Key, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	key, ok := r.keys[r.activeID]
+	if !ok {
+		return nil, errors.New("no active signing key")
+	}
+	return key, nil
+}
+
+// Lookup 根据 kid 查找密钥
+func (r *Keyring) Lookup(kid string) (*Key, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	key, ok := r.keys[kid]
+	return key, ok
+}
+
+// JWKS 导出全部非对称公钥，对称密钥不会导出
+func (r *Keyring) JWKS() JWKS {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	kids := make([]string, 0, len(r.keys))
+	for kid := range r.keys {
+		kids = append(kids, kid)
+	}
+	sort.Strings(kids)
+
+	set := JWKS{Keys: []JWK{}}
+	for _, kid := range kids {
+		if jwk, ok := toJWK(r.keys[kid]); ok {
+			set.Keys = append(set.Keys, jwk)
+		}
+	}
+	return set
+}
+
+// JWK JSON Web Key（RFC 7517）
+type JWK struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	Alg string `json:"alg"`
+	N   string `json:"n,omitempty"`
+	E   string `json:"e,omitempty"`
+	Crv string `json:"crv,omitempty"`
+	X   string `json:"x,omitempty"`
+	Y   string `json:"y,omitempty"`
+}
+
+// JWKS JSON Web Key Set
+type JWKS struct {
+	Keys []JWK `json:"keys"`
+}
+
+func toJWK(key *Key) (JWK, bool) {
+	jwk := JWK{Kid: key.ID, Use: "sig", Alg: key.Method.Alg()}
+	b64 := base64.RawURLEncoding.EncodeToString
+
+	switch pub := key.verifyKey.(type) {
+	case *rsa.PublicKey:
+		jwk.Kty = "RSA"
+		jwk.N = b64(pub.N.Bytes())
+		jwk.E = b64(big.NewInt(int64(pub.E)).Bytes())
+	case *ecdsa.PublicKey:
+		size := (pub.Curve.Params().BitSize + 7) / 8
+		jwk.Kty = "EC"
+		jwk.Crv = pub.Curve.Params().Name
+		jwk.X = b64(pub.X.FillBytes(make([]byte, size)))
+		jwk.Y = b64(pub.Y.FillBytes(make([]byte, size)))
+	case ed25519.PublicKey:
+		jwk.Kty = "OKP"
+		jwk.Crv = "Ed25519"
+		jwk.X = b64(pub)
+	default:
+		return JWK{}, false
+	}
+	return jwk, true
+}
+
+// LoadKey 根据配置加载密钥
+func LoadKey(cfg KeyConfig) (*Key, error) {
+	method := jwt.GetSigningMethod(cfg.Algorithm)
+	if method == nil {
+		return nil, fmt.Errorf("unsupported signing algorithm: %s", cfg.Algorithm)
+	}
+	key := &Key{ID: cfg.Kid, Method: method}
+
+	if _, ok := method.(*jwt.SigningMethodHMAC); ok {
+		if cfg.SecretKey == "" {
+			return nil, fmt.Errorf("key %s: secret_key is required for %s", cfg.Kid, cfg.Algorithm)
+		}
+		key.signKey = []byte(cfg.SecretKey)
+		key.verifyKey = key.signKey
+		return key, nil
+	}
+
+	if cfg.PrivateKeyLocation != "" {
+		priv, err := readPrivateKey(cfg.PrivateKeyLocation)
+		if err != nil {
+			return nil, fmt.Errorf("key %s: %w", cfg.Kid, err)
+		}
+		key.signKey = priv
+		key.verifyKey = publicKeyOf(priv)
+	}
+	if cfg.PublicKeyLocation != "" {
+		pub, err := readPublicKey(cfg.PublicKeyLocation)
+		if err != nil {
+			return nil, fmt.Errorf("key %s: %w", cfg.Kid, err)
+		}
+		// 同时配置私钥和公钥时必须是同一对密钥，否则签发的Token无法通过验证
+		if key.signKey != nil && !samePublicKey(key.verifyKey, pub) {
+			return nil, fmt.Errorf("key %s: public key does not match private key", cfg.Kid)
+		}
+		key.verifyKey = pub
+	}
+	if key.verifyKey == nil {
+		return nil, fmt.Errorf("key %s: private_key_location or public_key_location is required", cfg.Kid)
+	}
+	if err := checkKeyType(method, key.verifyKey); err != nil {
+		return nil, fmt.Errorf("key %s: %w", cfg.Kid, err)
+	}
+	return key, nil
+}
+
+// checkKeyType 检查密钥类型与签名算法是否匹配
+func checkKeyType(method jwt.SigningMethod, pub interface{}) error {
+	var ok bool
+	switch method.(type) {
+	case *jwt.SigningMethodRSA, *jwt.SigningMethodRSAPSS:
+		_, ok = pub.(*rsa.PublicKey)
+	case *jwt.SigningMethodECDSA:
+		var ecKey *ecdsa.PublicKey
+		if ecKey, ok = pub.(*ecdsa.PublicKey); ok {
+			ok = ecKey.Curve.Params().BitSize == method.(*jwt.SigningMethodECDSA).CurveBits
+		}
+	case *jwt.SigningMethodEd25519:
+		_, ok = pub.(ed25519.PublicKey)
+	}
+	if !ok {
+		return fmt.Errorf("key type does not match algorithm %s", method.Alg())
+	}
+	return nil
+}
+
+// samePublicKey 比较从私钥推导出的公钥和配置的公钥是否相同
+func samePublicKey(derived, pub interface{}) bool {
+	k, ok := derived.(interface{ Equal(crypto.PublicKey) bool })
+	return ok && k.Equal(pub)
+}
+
+// publicKeyOf 从私钥推导公钥
+func publicKeyOf(priv interface{}) interface{} {
+	switch k := priv.(type) {
+	case *rsa.PrivateKey:
+		return &k.PublicKey
+	case *ecdsa.PrivateKey:
+		return &k.PublicKey
+	case ed25519.PrivateKey:
+		return k.Public()
+	}
+	return nil
+}
+
+// readPrivateKey 读取PEM格式私钥，支持PKCS1、SEC1和PKCS8格式
+func readPrivateKey(path string) (interface{}, error) {
+	block, err := readPEM(path)
+	if err != nil {
+		return nil, err
+	}
+
+	switch block.Type {
+	case "RSA PRIVATE KEY":
+		key, err := x509.ParsePKCS1PrivateKey(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse PKCS1 private key: %w", err)
+		}
+		return key, nil
+	case "EC PRIVATE KEY":
+		key, err := x509.ParseECPrivateKey(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse EC private key: %w", err)
+		}
+		return key, nil
+	case "PRIVATE KEY":
+		key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse PKCS8 private key: %w", err)
+		}
+		return key, nil
+	default:
+		return nil, fmt.Errorf("unsupported key type: %s", block.Type)
+	}
+}
+
+// readPublicKey 读取PEM格式公钥，支持PKIX和PKCS1格式
+func readPublicKey(path string) (interface{}, error) {
+	block, err := readPEM(path)
+	if err != nil {
+		return nil, err
+	}
+
+	switch block.Type {
+	case "PUBLIC KEY":
+		key, err := x509.ParsePKIXPublicKey(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse PKIX public key: %w", err)
+		}
+		return key, nil
+	case "RSA PUBLIC KEY":
+		key, err := x509.ParsePKCS1PublicKey(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse PKCS1 public key: %w", err)
+		}
+		return key, nil
+	default:
+		return nil, fmt.Errorf("unsupported key type: %s", block.Type)
+	}
+}
+
+func readPEM(path string) (*pem.Block, error) {
+	keyData, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read key file: %w", err)
+	}
+	block, _ := pem.Decode(keyData)
+	if block == nil {
+		return nil, errors.New("failed to decode PEM block")
+	}
+	return block, nil
+}
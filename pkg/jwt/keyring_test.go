@@ -0,0 +1,93 @@
+package jwt
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// writeKeyPair 把私钥（PKCS8）和公钥（PKIX）写入临时目录，返回两个文件路径
+func writeKeyPair(t *testing.T, priv crypto.Signer) (privPath, pubPath string) {
+	t.Helper()
+	dir := t.TempDir()
+	privDER, err := x509.MarshalPKCS8PrivateKey(priv)
+	if err != nil {
+		t.Fatalf("marshal private key: %v", err)
+	}
+	pubDER, err := x509.MarshalPKIXPublicKey(priv.Public())
+	if err != nil {
+		t.Fatalf("marshal public key: %v", err)
+	}
+	privPath = filepath.Join(dir, "key.pem")
+	pubPath = filepath.Join(dir, "key.pub.pem")
+	if err := os.WriteFile(privPath, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: privDER}), 0o600); err != nil {
+		t.Fatalf("write private key: %v", err)
+	}
+	if err := os.WriteFile(pubPath, pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: pubDER}), 0o644); err != nil {
+		t.Fatalf("write public key: %v", err)
+	}
+	return privPath, pubPath
+}
+
+// TestLoadKeyPair 同时配置私钥和公钥时必须是同一对密钥
+func TestLoadKeyPair(t *testing.T) {
+	rsaKey, _ := rsa.GenerateKey(rand.Reader, 2048)
+	rsaOther, _ := rsa.GenerateKey(rand.Reader, 2048)
+	ecKey, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	ecOther, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	_, edKey, _ := ed25519.GenerateKey(rand.Reader)
+	_, edOther, _ := ed25519.GenerateKey(rand.Reader)
+
+	tests := []struct {
+		alg         string
+		priv, other crypto.Signer
+	}{
+		{"RS256", rsaKey, rsaOther},
+		{"ES256", ecKey, ecOther},
+		{"EdDSA", edKey, edOther},
+	}
+	for _, tt := range tests {
+		t.Run(tt.alg, func(t *testing.T) {
+			privPath, pubPath := writeKeyPair(t, tt.priv)
+			_, otherPubPath := writeKeyPair(t, tt.other)
+
+			key, err := LoadKey(KeyConfig{Kid: "k", Algorithm: tt.alg, PrivateKeyLocation: privPath, PublicKeyLocation: pubPath})
+			if err != nil {
+				t.Fatalf("matching pair: %v", err)
+			}
+			if !key.CanSign() {
+				t.Fatalf("key with private key cannot sign")
+			}
+
+			_, err = LoadKey(KeyConfig{Kid: "k", Algorithm: tt.alg, PrivateKeyLocation: privPath, PublicKeyLocation: otherPubPath})
+			if err == nil || !strings.Contains(err.Error(), "does not match") {
+				t.Fatalf("mismatched pair: expected error, got %v", err)
+			}
+
+			key, err = LoadKey(KeyConfig{Kid: "k", Algorithm: tt.alg, PublicKeyLocation: otherPubPath})
+			if err != nil || key.CanSign() {
+				t.Fatalf("public key only: key=%v, err=%v", key, err)
+			}
+		})
+	}
+}
+
+// TestLoadKeyAlgorithmMismatch 密钥类型与算法不匹配时加载失败
+func TestLoadKeyAlgorithmMismatch(t *testing.T) {
+	ecKey, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	privPath, _ := writeKeyPair(t, ecKey)
+	for _, alg := range []string{"RS256", "ES384", "EdDSA"} {
+		if _, err := LoadKey(KeyConfig{Kid: "k", Algorithm: alg, PrivateKeyLocation: privPath}); err == nil {
+			t.Errorf("%s with P-256 key: expected error", alg)
+		}
+	}
+}
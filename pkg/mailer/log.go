@@ -0,0 +1,38 @@
+package mailer
+
+import (
+	"context"
+	"log"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+// linkPattern 匹配正文中的链接
+var linkPattern = regexp.MustCompile(`https?://[^\s<>"]+`)
+
+// LogMailer 将邮件内容输出到日志，正文中的链接只保留站点地址
+// 邮件中的链接通常带有重置密码、验证邮箱等一次性令牌，不能写入日志；开发时需要链接请使用 file 驱动
+type LogMailer struct{}
+
+// NewLogMailer 创建日志邮件发送器
+func NewLogMailer() *LogMailer {
+	return &LogMailer{}
+}
+
+// Send 输出邮件到日志
+func (m *LogMailer) Send(ctx context.Context, msg Message) error {
+	log.Printf("[mail] to=%s subject=%q\n%s", strings.Join(msg.To, ","), msg.Subject, redactLinks(msg.Body))
+	return nil
+}
+
+// redactLinks 把链接的路径、参数和片段替换为 [redacted]
+func redactLinks(body string) string {
+	return linkPattern.ReplaceAllStringFunc(body, func(link string) string {
+		u, err := url.Parse(link)
+		if err != nil || u.Host == "" {
+			return "[redacted]"
+		}
+		return u.Scheme + "://" + u.Host + "/[redacted]"
+	})
+}
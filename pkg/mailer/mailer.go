@@ -0,0 +1,43 @@
+package mailer
+
+import (
+	"context"
+	"fmt"
+)
+
+// Message 邮件内容
+type Message struct {
+	To      []string
+	Subject string
+	Body    string // 纯文本正文
+}
+
+// Mailer 邮件发送接口
+type Mailer interface {
+	Send(ctx context.Context, msg Message) error
+}
+
+// Config 邮件发送配置
+type Config struct {
+	Driver   string // smtp, file, log
+	Host     string
+	Port     string
+	Username string
+	Password string
+	From     string
+	Dir      string // file 驱动的输出目录
+}
+
+// New 根据配置创建邮件发送器，未配置驱动时使用 log（正文中的链接会被隐去）
+func New(cfg Config) (Mailer, error) {
+	switch cfg.Driver {
+	case "smtp":
+		return NewSMTPMailer(cfg)
+	case "file":
+		return NewFileMailer(cfg.Dir, cfg.From)
+	case "", "log":
+		return NewLogMailer(), nil
+	default:
+		return nil, fmt.Errorf("unsupported mail driver: %s", cfg.Driver)
+	}
+}
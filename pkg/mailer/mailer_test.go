@@ -0,0 +1,214 @@
+package mailer
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"log"
+	"mime"
+	"net"
+	"net/mail"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// smtpSession 测试 SMTP 服务器收到的一封邮件
+type smtpSession struct {
+	from string
+	to   []string
+	data string
+}
+
+// startSMTPServer 启动只处理一个连接的 SMTP 服务器，不支持 STARTTLS 和认证
+func startSMTPServer(t *testing.T) (host, port string, received <-chan smtpSession) {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	t.Cleanup(func() { ln.Close() })
+
+	ch := make(chan smtpSession, 1)
+	go func() {
+		conn, err := ln.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		r := bufio.NewReader(conn)
+		reply := func(line string) { conn.Write([]byte(line + "\r\n")) }
+
+		var s smtpSession
+		reply("220 test ESMTP")
+		for {
+			line, err := r.ReadString('\n')
+			if err != nil {
+				return
+			}
+			line = strings.TrimRight(line, "\r\n")
+			cmd := strings.ToUpper(line)
+			switch {
+			case strings.HasPrefix(cmd, "EHLO"), strings.HasPrefix(cmd, "HELO"):
+				reply("250 test")
+			case strings.HasPrefix(cmd, "MAIL FROM:"):
+				s.from = line[len("MAIL FROM:"):]
+				reply("250 OK")
+			case strings.HasPrefix(cmd, "RCPT TO:"):
+				s.to = append(s.to, line[len("RCPT TO:"):])
+				reply("250 OK")
+			case cmd == "DATA":
+				reply("354 go ahead")
+				var data strings.Builder
+				for {
+					l, err := r.ReadString('\n')
+					if err != nil {
+						return
+					}
+					if l == ".\r\n" {
+						break
+					}
+					data.WriteString(l)
+				}
+				s.data = data.String()
+				reply("250 OK")
+			case cmd == "QUIT":
+				reply("221 bye")
+				ch <- s
+				return
+			default:
+				reply("502 not implemented")
+			}
+		}
+	}()
+
+	host, port, _ = net.SplitHostPort(ln.Addr().String())
+	return host, port, ch
+}
+
+// TestSMTPMailerSend 信封发件人只包含邮箱地址，显示名称和中文主题按 RFC 2047 编码
+func TestSMTPMailerSend(t *testing.T) {
+	host, port, received := startSMTPServer(t)
+	m, err := New(Config{Driver: "smtp", Host: host, Port: port, From: "FlowGo 通知 <no-reply@flowgo.test>"})
+	if err != nil {
+		t.Fatalf("new mailer: %v", err)
+	}
+
+	err = m.Send(context.Background(), Message{
+		To:      []string{"alice@example.com"},
+		Subject: "重置密码",
+		Body:    "点击链接重置密码：\nhttps://flowgo.test/reset?token=abc",
+	})
+	if err != nil {
+		t.Fatalf("send: %v", err)
+	}
+
+	s := <-received
+	if s.from != "<no-reply@flowgo.test>" {
+		t.Errorf("MAIL FROM = %q, want <no-reply@flowgo.test>", s.from)
+	}
+	if len(s.to) != 1 || s.to[0] != "<alice@example.com>" {
+		t.Errorf("RCPT TO = %q", s.to)
+	}
+
+	msg, err := mail.ReadMessage(strings.NewReader(s.data))
+	if err != nil {
+		t.Fatalf("parse message: %v", err)
+	}
+	subject, err := new(mime.WordDecoder).DecodeHeader(msg.Header.Get("Subject"))
+	if err != nil || subject != "重置密码" {
+		t.Errorf("Subject = %q (%v), want 重置密码", msg.Header.Get("Subject"), err)
+	}
+	if raw := msg.Header.Get("Subject"); !strings.HasPrefix(raw, "=?utf-8?q?") {
+		t.Errorf("Subject is not Q-encoded: %q", raw)
+	}
+	from, err := msg.Header.AddressList("From")
+	if err != nil || len(from) != 1 || from[0].Name != "FlowGo 通知" || from[0].Address != "no-reply@flowgo.test" {
+		t.Errorf("From = %q (%v)", msg.Header.Get("From"), err)
+	}
+	if !strings.Contains(s.data, "\r\nhttps://flowgo.test/reset?token=abc") {
+		t.Errorf("body lines are not CRLF terminated: %q", s.data)
+	}
+}
+
+func TestNew(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     Config
+		wantErr bool
+	}{
+		{"default driver", Config{}, false},
+		{"log driver", Config{Driver: "log"}, false},
+		{"file driver", Config{Driver: "file", Dir: t.TempDir(), From: "no-reply@flowgo.test"}, false},
+		{"smtp driver", Config{Driver: "smtp", Host: "localhost", Port: "25", From: "FlowGo <no-reply@flowgo.test>"}, false},
+		{"smtp with invalid from", Config{Driver: "smtp", Host: "localhost", Port: "25", From: "not an address"}, true},
+		{"unknown driver", Config{Driver: "sendmail"}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := New(tt.cfg)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("New() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+// TestFileMailerSend 每封邮件写入一个 .eml 文件
+func TestFileMailerSend(t *testing.T) {
+	dir := t.TempDir()
+	m, err := NewFileMailer(dir, "no-reply@flowgo.test")
+	if err != nil {
+		t.Fatalf("new mailer: %v", err)
+	}
+	for _, to := range []string{"alice@example.com", "bob@example.com"} {
+		if err := m.Send(context.Background(), Message{To: []string{to}, Subject: "hi", Body: "hello"}); err != nil {
+			t.Fatalf("send: %v", err)
+		}
+	}
+
+	files, err := filepath.Glob(filepath.Join(dir, "*.eml"))
+	if err != nil || len(files) != 2 {
+		t.Fatalf("got %d mail files (%v), want 2", len(files), err)
+	}
+	data, err := os.ReadFile(files[0])
+	if err != nil {
+		t.Fatalf("read mail: %v", err)
+	}
+	msg, err := mail.ReadMessage(bytes.NewReader(data))
+	if err != nil {
+		t.Fatalf("parse mail: %v", err)
+	}
+	if msg.Header.Get("To") == "" || msg.Header.Get("Subject") != "hi" {
+		t.Errorf("unexpected headers: %v", msg.Header)
+	}
+}
+
+// TestLogMailerRedactsLinks 日志中不能出现链接里的令牌
+func TestLogMailerRedactsLinks(t *testing.T) {
+	var buf bytes.Buffer
+	log.SetOutput(&buf)
+	t.Cleanup(func() { log.SetOutput(os.Stderr) })
+
+	err := NewLogMailer().Send(context.Background(), Message{
+		To:      []string{"alice@example.com"},
+		Subject: "验证邮箱",
+		Body:    "请打开 https://flowgo.test/verify?token=secret123 完成验证，或访问 http://localhost:8080/reset/secret456。",
+	})
+	if err != nil {
+		t.Fatalf("send: %v", err)
+	}
+
+	out := buf.String()
+	for _, secret := range []string{"secret123", "secret456"} {
+		if strings.Contains(out, secret) {
+			t.Errorf("log contains %s: %s", secret, out)
+		}
+	}
+	for _, want := range []string{"alice@example.com", "https://flowgo.test/[redacted]", "http://localhost:8080/[redacted]"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("log does not contain %q: %s", want, out)
+		}
+	}
+}
@@ -0,0 +1,142 @@
+package rank
+
+import (
+	"math/rand"
+	"strings"
+	"testing"
+)
+
+// TestBetween 生成的键严格位于两个键之间
+func TestBetween(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want string // 为空时只检查顺序
+	}{
+		{"", "", "V"},
+		{"V", "", "W"},
+		{"", "V", "U"},
+		{"z", "", "zV"},
+		{"", "1", "0V"},
+		{"A", "C", "B"},
+		{"A", "B", ""},
+		{"A", "A1", "A0V"},
+		{"Az", "B", ""},
+		{"a1", "a2", "a1V"},
+		{"0001", "0002", ""},
+		{"y", "z", "yV"},
+		{"", "01", "00V"},
+	}
+	for _, tt := range tests {
+		got, err := Between(tt.a, tt.b)
+		if err != nil {
+			t.Fatalf("Between(%q, %q): %v", tt.a, tt.b, err)
+		}
+		if tt.want != "" && got != tt.want {
+			t.Errorf("Between(%q, %q) = %q, want %q", tt.a, tt.b, got, tt.want)
+		}
+		checkKey(t, got)
+		if (tt.a != "" && got <= tt.a) || (tt.b != "" && got >= tt.b) {
+			t.Errorf("Between(%q, %q) = %q is out of order", tt.a, tt.b, got)
+		}
+	}
+}
+
+// TestBetweenInvalid 键不合法或顺序相反时返回错误
+func TestBetweenInvalid(t *testing.T) {
+	tests := []struct{ a, b string }{
+		{"B", "A"},
+		{"A", "A"},
+		{"A0", ""},
+		{"", "10"},
+		{"a-b", ""},
+		{"", "é"},
+	}
+	for _, tt := range tests {
+		if got, err := Between(tt.a, tt.b); err == nil {
+			t.Errorf("Between(%q, %q) = %q, want error", tt.a, tt.b, got)
+		}
+	}
+}
+
+// TestRepeatedInserts 反复在列首、列尾和同一位置插入，键始终有序
+func TestRepeatedInserts(t *testing.T) {
+	t.Run("append", func(t *testing.T) {
+		keys := insertRepeatedly(t, 1000, func(keys []string) int { return len(keys) })
+		assertMaxLen(t, keys, 40)
+	})
+	t.Run("prepend", func(t *testing.T) {
+		keys := insertRepeatedly(t, 1000, func([]string) int { return 0 })
+		assertMaxLen(t, keys, 40)
+	})
+	t.Run("same position", func(t *testing.T) {
+		// 总是插在第一项之后，每次都要在两个越来越接近的键之间取中点
+		insertRepeatedly(t, 200, func(keys []string) int { return min(1, len(keys)) })
+	})
+	t.Run("random", func(t *testing.T) {
+		r := rand.New(rand.NewSource(1))
+		insertRepeatedly(t, 2000, func(keys []string) int { return r.Intn(len(keys) + 1) })
+	})
+}
+
+// insertRepeatedly 执行 n 次插入，pos 返回新键插入的下标，每次插入后检查整列严格递增
+func insertRepeatedly(t *testing.T, n int, pos func(keys []string) int) []string {
+	t.Helper()
+	var keys []string
+	for i := 0; i < n; i++ {
+		p := pos(keys)
+		var a, b string
+		if p > 0 {
+			a = keys[p-1]
+		}
+		if p < len(keys) {
+			b = keys[p]
+		}
+		key, err := Between(a, b)
+		if err != nil {
+			t.Fatalf("insert %d: Between(%q, %q): %v", i, a, b, err)
+		}
+		checkKey(t, key)
+		keys = append(keys[:p], append([]string{key}, keys[p:]...)...)
+		for j := 1; j < len(keys); j++ {
+			if keys[j-1] >= keys[j] {
+				t.Fatalf("insert %d: keys out of order: %q >= %q", i, keys[j-1], keys[j])
+			}
+		}
+	}
+	return keys
+}
+
+// assertMaxLen 追加和插入列首时每次只移动一位，约 30 次才增加一位长度
+func assertMaxLen(t *testing.T, keys []string, max int) {
+	t.Helper()
+	for _, k := range keys {
+		if len(k) > max {
+			t.Fatalf("key %q is longer than %d", k, max)
+		}
+	}
+}
+
+func checkKey(t *testing.T, key string) {
+	t.Helper()
+	if key == "" || strings.HasSuffix(key, "0") {
+		t.Fatalf("invalid generated key %q", key)
+	}
+	if err := validate(key); err != nil {
+		t.Fatalf("generated key fails validation: %v", err)
+	}
+}
+
+// TestAfter 连续追加的键严格递增
+func TestAfter(t *testing.T) {
+	prev := ""
+	for i := 0; i < 100; i++ {
+		next, err := After(prev)
+		if err != nil {
+			t.Fatalf("After(%q): %v", prev, err)
+		}
+		if next <= prev {
+			t.Fatalf("After(%q) = %q is not greater", prev, next)
+		}
+		prev = next
+	}
+}
@@ -0,0 +1,121 @@
+package totp
+
+import (
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+)
+
+// rfcSecret RFC 6238 附录 B 中 SHA1 使用的密钥 "12345678901234567890" 的 Base32 编码
+const rfcSecret = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+
+// TestGenerateCodeRFC6238 RFC 6238 附录 B 的 SHA1 测试向量，取8位验证码的后6位
+func TestGenerateCodeRFC6238(t *testing.T) {
+	tests := []struct {
+		unix int64
+		want string
+	}{
+		{59, "287082"},
+		{1111111109, "081804"},
+		{1111111111, "050471"},
+		{1234567890, "005924"},
+		{2000000000, "279037"},
+		{20000000000, "353130"},
+	}
+	for _, tt := range tests {
+		got, err := GenerateCode(rfcSecret, Step(time.Unix(tt.unix, 0)))
+		if err != nil {
+			t.Fatalf("GenerateCode(%d): %v", tt.unix, err)
+		}
+		if got != tt.want {
+			t.Errorf("GenerateCode(%d) = %s, want %s", tt.unix, got, tt.want)
+		}
+	}
+}
+
+// TestValidate 允许前后各一个时间步的偏移，返回匹配的时间步
+func TestValidate(t *testing.T) {
+	now := time.Unix(1111111111, 0)
+	current := Step(now)
+	tests := []struct {
+		name     string
+		code     string
+		wantStep int64
+		ok       bool
+	}{
+		{"current step", "050471", current, true},
+		{"previous step", mustCode(t, current-1), current - 1, true},
+		{"next step", mustCode(t, current+1), current + 1, true},
+		{"with spaces", " 050471 ", current, true},
+		{"outside skew", mustCode(t, current-2), 0, false},
+		{"wrong code", "000000", 0, false},
+		{"too short", "50471", 0, false},
+		{"eight digits", "14050471", 0, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			step, ok := Validate(rfcSecret, tt.code, now)
+			if ok != tt.ok || step != tt.wantStep {
+				t.Fatalf("Validate(%q) = %d, %v, want %d, %v", tt.code, step, ok, tt.wantStep, tt.ok)
+			}
+		})
+	}
+}
+
+// TestDecodeSecret 密钥不区分大小写，忽略空格和填充
+func TestDecodeSecret(t *testing.T) {
+	for _, secret := range []string{
+		strings.ToLower(rfcSecret),
+		"GEZD GNBV GY3T QOJQ GEZD GNBV GY3T QOJQ",
+		rfcSecret + "====",
+	} {
+		got, err := GenerateCode(secret, Step(time.Unix(59, 0)))
+		if err != nil || got != "287082" {
+			t.Errorf("GenerateCode(%q) = %s, %v", secret, got, err)
+		}
+	}
+	if _, err := GenerateCode("not base32!", 1); err == nil {
+		t.Errorf("expected error for invalid secret")
+	}
+	if _, ok := Validate("not base32!", "287082", time.Unix(59, 0)); ok {
+		t.Errorf("invalid secret validated")
+	}
+}
+
+// TestGenerateSecretAndURI 生成的密钥可以直接用于生成验证码
+func TestGenerateSecretAndURI(t *testing.T) {
+	secret, err := GenerateSecret()
+	if err != nil {
+		t.Fatalf("GenerateSecret: %v", err)
+	}
+	if len(secret) != 32 {
+		t.Fatalf("secret length = %d, want 32", len(secret))
+	}
+	code, err := GenerateCode(secret, Step(time.Now()))
+	if err != nil {
+		t.Fatalf("GenerateCode: %v", err)
+	}
+	if _, ok := Validate(secret, code, time.Now()); !ok {
+		t.Fatalf("generated code does not validate")
+	}
+
+	u, err := url.Parse(URI("FlowGo", "alice", secret))
+	if err != nil {
+		t.Fatalf("parse uri: %v", err)
+	}
+	q := u.Query()
+	if u.Scheme != "otpauth" || u.Host != "totp" || u.Path != "/FlowGo:alice" ||
+		q.Get("secret") != secret || q.Get("issuer") != "FlowGo" || q.Get("digits") != "6" || q.Get("period") != "30" {
+		t.Fatalf("unexpected uri %s", u)
+	}
+}
+
+func mustCode(t *testing.T, step int64) string {
+	t.Helper()
+	code, err := GenerateCode(rfcSecret, step)
+	if err != nil {
+		t.Fatalf("GenerateCode: %v", err)
+	}
+	return code
+}
@@ -0,0 +1,67 @@
+package utils
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"strings"
+)
+
+// sealedPrefix 密文前缀，用于区分加密前保存的明文
+const sealedPrefix = "enc:v1:"
+
+// SecretBox 使用 AES-256-GCM 加密保存在数据库中的敏感字段
+type SecretBox struct {
+	aead cipher.AEAD
+}
+
+// NewSecretBox 创建加密器，密钥为任意长度的字符串，经 SHA-256 派生为 AES-256 密钥
+func NewSecretBox(key string) (*SecretBox, error) {
+	if key == "" {
+		return nil, errors.New("encryption key is empty")
+	}
+	sum := sha256.Sum256([]byte(key))
+	block, err := aes.NewCipher(sum[:])
+	if err != nil {
+		return nil, err
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+	return &SecretBox{aead: aead}, nil
+}
+
+// Seal 加密明文，返回带前缀的 base64 文本，随机 nonce 保存在密文开头
+func (b *SecretBox) Seal(plaintext string) (string, error) {
+	nonce := make([]byte, b.aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", err
+	}
+	sealed := b.aead.Seal(nonce, nonce, []byte(plaintext), nil)
+	return sealedPrefix + base64.RawStdEncoding.EncodeToString(sealed), nil
+}
+
+// Open 解密 Seal 的结果；没有密文前缀的值是加密前保存的明文，原样返回
+func (b *SecretBox) Open(value string) (string, error) {
+	encoded, ok := strings.CutPrefix(value, sealedPrefix)
+	if !ok {
+		return value, nil
+	}
+	sealed, err := base64.RawStdEncoding.DecodeString(encoded)
+	if err != nil {
+		return "", err
+	}
+	size := b.aead.NonceSize()
+	if len(sealed) < size {
+		return "", errors.New("ciphertext too short")
+	}
+	plaintext, err := b.aead.Open(nil, sealed[:size], sealed[size:], nil)
+	if err != nil {
+		return "", err
+	}
+	return string(plaintext), nil
+}
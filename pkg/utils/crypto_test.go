@@ -0,0 +1,57 @@
+package utils
+
+import (
+	"strings"
+	"testing"
+)
+
+// TestSecretBox 加密结果带前缀且每次不同，只有同一密钥才能解密
+func TestSecretBox(t *testing.T) {
+	box, err := NewSecretBox("test-key")
+	if err != nil {
+		t.Fatalf("new secret box: %v", err)
+	}
+
+	a, err := box.Seal("GEZDGNBVGY3TQOJQ")
+	if err != nil {
+		t.Fatalf("seal: %v", err)
+	}
+	b, err := box.Seal("GEZDGNBVGY3TQOJQ")
+	if err != nil {
+		t.Fatalf("seal: %v", err)
+	}
+	if !strings.HasPrefix(a, sealedPrefix) || strings.Contains(a, "GEZDGNBVGY3TQOJQ") {
+		t.Fatalf("unexpected ciphertext %q", a)
+	}
+	if a == b {
+		t.Fatalf("ciphertexts of the same plaintext are equal")
+	}
+
+	got, err := box.Open(a)
+	if err != nil || got != "GEZDGNBVGY3TQOJQ" {
+		t.Fatalf("Open() = %q, %v", got, err)
+	}
+
+	other, _ := NewSecretBox("other-key")
+	if _, err := other.Open(a); err == nil {
+		t.Fatalf("expected error when opening with another key")
+	}
+	if _, err := box.Open(a[:len(a)-4]); err == nil {
+		t.Fatalf("expected error for truncated ciphertext")
+	}
+}
+
+// TestSecretBoxOpenPlaintext 加密前保存的明文原样返回
+func TestSecretBoxOpenPlaintext(t *testing.T) {
+	box, err := NewSecretBox("test-key")
+	if err != nil {
+		t.Fatalf("new secret box: %v", err)
+	}
+	got, err := box.Open("GEZDGNBVGY3TQOJQ")
+	if err != nil || got != "GEZDGNBVGY3TQOJQ" {
+		t.Fatalf("Open() = %q, %v", got, err)
+	}
+	if _, err := NewSecretBox(""); err == nil {
+		t.Fatalf("expected error for empty key")
+	}
+}